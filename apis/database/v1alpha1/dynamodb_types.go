@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 )
 
 // DynamoDB instance states.
@@ -247,6 +249,7 @@ type DynamoTableObservation struct {
 // A DynamoTableStatus represents the observed state of a DynamoDB Table.
 type DynamoTableStatus struct {
 	runtimev1alpha1.ResourceStatus `json:",inline"`
+	v1alpha3.DriftStatus           `json:",inline"`
 	AtProvider                     DynamoTableObservation `json:"atProvider"`
 }
 
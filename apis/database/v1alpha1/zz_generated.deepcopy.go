@@ -218,6 +218,7 @@ func (in *DynamoTableSpec) DeepCopy() *DynamoTableSpec {
 func (in *DynamoTableStatus) DeepCopyInto(out *DynamoTableStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.DriftStatus.DeepCopyInto(&out.DriftStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
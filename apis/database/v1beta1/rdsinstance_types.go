@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 )
 
 // SQL database engines.
@@ -1073,6 +1075,7 @@ type RDSInstanceObservation struct {
 // An RDSInstanceStatus represents the observed state of an RDSInstance.
 type RDSInstanceStatus struct {
 	runtimev1alpha1.ResourceStatus `json:",inline"`
+	v1alpha3.DriftStatus           `json:",inline"`
 	AtProvider                     RDSInstanceObservation `json:"atProvider,omitempty"`
 }
 
@@ -900,6 +900,7 @@ func (in *RDSInstanceSpec) DeepCopy() *RDSInstanceSpec {
 func (in *RDSInstanceStatus) DeepCopyInto(out *RDSInstanceStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.DriftStatus.DeepCopyInto(&out.DriftStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
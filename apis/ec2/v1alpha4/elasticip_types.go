@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+
+	ec2v1beta1 "github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+)
+
+// Domains of elastic IP addresses.
+const (
+	ElasticIPDomainVPC      = "vpc"
+	ElasticIPDomainStandard = "standard"
+)
+
+// ElasticIPParameters define the desired state of an AWS Elastic IP address.
+type ElasticIPParameters struct {
+	// Domain indicates whether the address is for use in a VPC (vpc) or in
+	// EC2-Classic (standard). Addresses in the standard domain are identified
+	// by their public IP address rather than an allocation ID, and cannot be
+	// tagged.
+	// +kubebuilder:validation:Enum=vpc;standard
+	// +optional
+	// +immutable
+	Domain *string `json:"domain,omitempty"`
+
+	// Address is an Elastic IP address to recover or an IPv4 address from an
+	// address pool.
+	// +optional
+	// +immutable
+	Address *string `json:"address,omitempty"`
+
+	// PublicIPv4Pool is the ID of an address pool that you own. Use this
+	// parameter to let Amazon EC2 select an address from the address pool.
+	// +optional
+	// +immutable
+	PublicIPv4Pool *string `json:"publicIpv4Pool,omitempty"`
+
+	// NetworkBorderGroup is the location from which the IP address is
+	// advertised.
+	// +optional
+	// +immutable
+	NetworkBorderGroup *string `json:"networkBorderGroup,omitempty"`
+
+	// Tags represents to current ec2 tags. Tags are ignored for addresses
+	// in the standard domain, which cannot be tagged.
+	// +optional
+	Tags []ec2v1beta1.Tag `json:"tags,omitempty"`
+}
+
+// An ElasticIPSpec defines the desired state of an ElasticIP.
+type ElasticIPSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  ElasticIPParameters `json:"forProvider"`
+}
+
+// ElasticIPObservation keeps the state for the external resource
+type ElasticIPObservation struct {
+	// The ID that AWS assigns to represent the allocation of the address for
+	// use in a VPC.
+	AllocationID string `json:"allocationId,omitempty"`
+
+	// The Elastic IP address.
+	PublicIP string `json:"publicIp,omitempty"`
+
+	// Indicates whether the address is for use in a VPC (vpc) or in
+	// EC2-Classic (standard).
+	Domain string `json:"domain,omitempty"`
+
+	// The ID representing the association of the address with an instance or
+	// a network interface.
+	AssociationID string `json:"associationId,omitempty"`
+
+	// The ID of the instance the address is associated with.
+	InstanceID string `json:"instanceId,omitempty"`
+
+	// The ID of the network interface the address is associated with.
+	NetworkInterfaceID string `json:"networkInterfaceId,omitempty"`
+
+	// The ID of the AWS account that owns the network interface.
+	NetworkInterfaceOwnerID string `json:"networkInterfaceOwnerId,omitempty"`
+
+	// The private IP address associated with the Elastic IP address.
+	PrivateIPAddress string `json:"privateIpAddress,omitempty"`
+
+	// The ID of the address pool.
+	PublicIPv4Pool string `json:"publicIpv4Pool,omitempty"`
+
+	// The location from which the IP address is advertised.
+	NetworkBorderGroup string `json:"networkBorderGroup,omitempty"`
+
+	// Tags of the ElasticIP, as observed in AWS.
+	Tags []ec2v1beta1.Tag `json:"tags,omitempty"`
+}
+
+// An ElasticIPStatus represents the observed state of an ElasticIP.
+type ElasticIPStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     ElasticIPObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// An ElasticIP is a managed resource that represents an AWS Elastic IP
+// address.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="IP",type="string",JSONPath=".status.atProvider.publicIp"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,aws}
+type ElasticIP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticIPSpec   `json:"spec"`
+	Status ElasticIPStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticIPList contains a list of ElasticIPs
+type ElasticIPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticIP `json:"items"`
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+
+	ec2v1beta1 "github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+)
+
+// NATGatewayParameters define the desired state of an AWS VPC NAT Gateway.
+type NATGatewayParameters struct {
+	// AllocationID is the allocation ID of the Elastic IP address to
+	// associate with the NAT gateway.
+	// +optional
+	// +immutable
+	AllocationID *string `json:"allocationId,omitempty"`
+
+	// AllocationIDRef references an ElasticIP to retrieve its allocationId
+	// +optional
+	// +immutable
+	AllocationIDRef *runtimev1alpha1.Reference `json:"allocationIdRef,omitempty"`
+
+	// AllocationIDSelector selects a reference to an ElasticIP to retrieve
+	// its allocationId
+	// +optional
+	AllocationIDSelector *runtimev1alpha1.Selector `json:"allocationIdSelector,omitempty"`
+
+	// SubnetID is the ID of the subnet in which to create the NAT gateway.
+	// +optional
+	// +immutable
+	SubnetID *string `json:"subnetId,omitempty"`
+
+	// SubnetIDRef references a Subnet to retrieve its subnetId
+	// +optional
+	// +immutable
+	SubnetIDRef *runtimev1alpha1.Reference `json:"subnetIdRef,omitempty"`
+
+	// SubnetIDSelector selects a reference to a Subnet to retrieve its
+	// subnetId
+	// +optional
+	SubnetIDSelector *runtimev1alpha1.Selector `json:"subnetIdSelector,omitempty"`
+
+	// Tags represents to current ec2 tags.
+	// +optional
+	Tags []ec2v1beta1.Tag `json:"tags,omitempty"`
+}
+
+// A NATGatewaySpec defines the desired state of a NATGateway.
+type NATGatewaySpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  NATGatewayParameters `json:"forProvider"`
+}
+
+// NATGatewayAddress describes the IP addresses and network interface
+// associated with a NAT gateway.
+type NATGatewayAddress struct {
+	// The allocation ID of the Elastic IP address that's associated with the
+	// NAT gateway.
+	AllocationID string `json:"allocationId,omitempty"`
+
+	// The ID of the network interface associated with the NAT gateway.
+	NetworkInterfaceID string `json:"networkInterfaceId,omitempty"`
+
+	// The private IP address associated with the Elastic IP address.
+	PrivateIP string `json:"privateIp,omitempty"`
+
+	// The Elastic IP address associated with the NAT gateway.
+	PublicIP string `json:"publicIp,omitempty"`
+}
+
+// NATGatewayObservation keeps the state for the external resource
+type NATGatewayObservation struct {
+	// The ID of the NAT gateway.
+	NATGatewayID string `json:"natGatewayId,omitempty"`
+
+	// The state of the NAT gateway, one of pending, failed, available,
+	// deleting or deleted.
+	State string `json:"state,omitempty"`
+
+	// If the NAT gateway could not be created, specifies the error code for
+	// the failure.
+	FailureCode string `json:"failureCode,omitempty"`
+
+	// If the NAT gateway could not be created, specifies the error message
+	// for the failure.
+	FailureMessage string `json:"failureMessage,omitempty"`
+
+	// The IP addresses and network interface associated with the NAT
+	// gateway.
+	NATGatewayAddresses []NATGatewayAddress `json:"natGatewayAddresses,omitempty"`
+
+	// The ID of the VPC in which the NAT gateway is located.
+	VPCID string `json:"vpcId,omitempty"`
+
+	// The date and time the NAT gateway was created.
+	CreateTime *metav1.Time `json:"createTime,omitempty"`
+
+	// Tags of the NATGateway, as observed in AWS.
+	Tags []ec2v1beta1.Tag `json:"tags,omitempty"`
+}
+
+// A NATGatewayStatus represents the observed state of a NATGateway.
+type NATGatewayStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     NATGatewayObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A NATGateway is a managed resource that represents an AWS VPC NAT Gateway.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="SUBNET",type="string",JSONPath=".spec.forProvider.subnetId"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,aws}
+type NATGateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NATGatewaySpec   `json:"spec"`
+	Status NATGatewayStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NATGatewayList contains a list of NATGateways
+type NATGatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NATGateway `json:"items"`
+}
@@ -44,10 +44,12 @@ func (mg *RouteTable) ResolveReferences(ctx context.Context, c client.Reader) er
 	mg.Spec.ForProvider.VPCID = aws.String(rsp.ResolvedValue)
 	mg.Spec.ForProvider.VPCIDRef = rsp.ResolvedReference
 
-	// Resolve spec.routes[].gatewayID
+	// Resolve spec.routes[].gatewayID, spec.routes[].natGatewayID and
+	// spec.routes[].vpcPeeringConnectionID. Routes have exactly one target, so
+	// unresolved targets are left unset.
 	for i := range mg.Spec.ForProvider.Routes {
 		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
-			CurrentValue: aws.StringValue(mg.Spec.ForProvider.Routes[i].GatewayID),
+			CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Routes[i].GatewayID),
 			Reference:    mg.Spec.ForProvider.Routes[i].GatewayIDRef,
 			Selector:     mg.Spec.ForProvider.Routes[i].GatewayIDSelector,
 			To:           reference.To{Managed: &ec2v1beta1.InternetGateway{}, List: &ec2v1beta1.InternetGatewayList{}},
@@ -56,8 +58,34 @@ func (mg *RouteTable) ResolveReferences(ctx context.Context, c client.Reader) er
 		if err != nil {
 			return err
 		}
-		mg.Spec.ForProvider.Routes[i].GatewayID = aws.String(rsp.ResolvedValue)
+		mg.Spec.ForProvider.Routes[i].GatewayID = reference.ToPtrValue(rsp.ResolvedValue)
 		mg.Spec.ForProvider.Routes[i].GatewayIDRef = rsp.ResolvedReference
+
+		rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Routes[i].NatGatewayID),
+			Reference:    mg.Spec.ForProvider.Routes[i].NatGatewayIDRef,
+			Selector:     mg.Spec.ForProvider.Routes[i].NatGatewayIDSelector,
+			To:           reference.To{Managed: &NATGateway{}, List: &NATGatewayList{}},
+			Extract:      reference.ExternalName(),
+		})
+		if err != nil {
+			return err
+		}
+		mg.Spec.ForProvider.Routes[i].NatGatewayID = reference.ToPtrValue(rsp.ResolvedValue)
+		mg.Spec.ForProvider.Routes[i].NatGatewayIDRef = rsp.ResolvedReference
+
+		rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Routes[i].VPCPeeringConnectionID),
+			Reference:    mg.Spec.ForProvider.Routes[i].VPCPeeringConnectionIDRef,
+			Selector:     mg.Spec.ForProvider.Routes[i].VPCPeeringConnectionIDSelector,
+			To:           reference.To{Managed: &VPCPeeringConnection{}, List: &VPCPeeringConnectionList{}},
+			Extract:      reference.ExternalName(),
+		})
+		if err != nil {
+			return err
+		}
+		mg.Spec.ForProvider.Routes[i].VPCPeeringConnectionID = reference.ToPtrValue(rsp.ResolvedValue)
+		mg.Spec.ForProvider.Routes[i].VPCPeeringConnectionIDRef = rsp.ResolvedReference
 	}
 
 	// Resolve spec.associations[].subnetID
@@ -78,3 +106,108 @@ func (mg *RouteTable) ResolveReferences(ctx context.Context, c client.Reader) er
 
 	return nil
 }
+
+// ResolveReferences of this SecurityGroupRule
+func (mg *SecurityGroupRule) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.securityGroupID
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.SecurityGroupID),
+		Reference:    mg.Spec.ForProvider.SecurityGroupIDRef,
+		Selector:     mg.Spec.ForProvider.SecurityGroupIDSelector,
+		To:           reference.To{Managed: &ec2v1beta1.SecurityGroup{}, List: &ec2v1beta1.SecurityGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return err
+	}
+	mg.Spec.ForProvider.SecurityGroupID = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.SecurityGroupIDRef = rsp.ResolvedReference
+
+	// Resolve spec.sourceSecurityGroupID
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.SourceSecurityGroupID),
+		Reference:    mg.Spec.ForProvider.SourceSecurityGroupIDRef,
+		Selector:     mg.Spec.ForProvider.SourceSecurityGroupIDSelector,
+		To:           reference.To{Managed: &ec2v1beta1.SecurityGroup{}, List: &ec2v1beta1.SecurityGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return err
+	}
+	mg.Spec.ForProvider.SourceSecurityGroupID = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.SourceSecurityGroupIDRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this NATGateway
+func (mg *NATGateway) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.allocationID
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: aws.StringValue(mg.Spec.ForProvider.AllocationID),
+		Reference:    mg.Spec.ForProvider.AllocationIDRef,
+		Selector:     mg.Spec.ForProvider.AllocationIDSelector,
+		To:           reference.To{Managed: &ElasticIP{}, List: &ElasticIPList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return err
+	}
+	mg.Spec.ForProvider.AllocationID = aws.String(rsp.ResolvedValue)
+	mg.Spec.ForProvider.AllocationIDRef = rsp.ResolvedReference
+
+	// Resolve spec.subnetID
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: aws.StringValue(mg.Spec.ForProvider.SubnetID),
+		Reference:    mg.Spec.ForProvider.SubnetIDRef,
+		Selector:     mg.Spec.ForProvider.SubnetIDSelector,
+		To:           reference.To{Managed: &ec2v1beta1.Subnet{}, List: &ec2v1beta1.SubnetList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return err
+	}
+	mg.Spec.ForProvider.SubnetID = aws.String(rsp.ResolvedValue)
+	mg.Spec.ForProvider.SubnetIDRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this VPCPeeringConnection
+func (mg *VPCPeeringConnection) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.vpcID
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: aws.StringValue(mg.Spec.ForProvider.VPCID),
+		Reference:    mg.Spec.ForProvider.VPCIDRef,
+		Selector:     mg.Spec.ForProvider.VPCIDSelector,
+		To:           reference.To{Managed: &ec2v1beta1.VPC{}, List: &ec2v1beta1.VPCList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return err
+	}
+	mg.Spec.ForProvider.VPCID = aws.String(rsp.ResolvedValue)
+	mg.Spec.ForProvider.VPCIDRef = rsp.ResolvedReference
+
+	// Resolve spec.peerVpcID
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: aws.StringValue(mg.Spec.ForProvider.PeerVPCID),
+		Reference:    mg.Spec.ForProvider.PeerVPCIDRef,
+		Selector:     mg.Spec.ForProvider.PeerVPCIDSelector,
+		To:           reference.To{Managed: &ec2v1beta1.VPC{}, List: &ec2v1beta1.VPCList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return err
+	}
+	mg.Spec.ForProvider.PeerVPCID = aws.String(rsp.ResolvedValue)
+	mg.Spec.ForProvider.PeerVPCIDRef = rsp.ResolvedReference
+
+	return nil
+}
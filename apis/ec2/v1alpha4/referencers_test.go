@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	aws "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+func TestRouteTableResolveReferences(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		routes []Route
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		routes []Route
+		want   want
+	}{
+		"NonGatewayTarget": {
+			reason: "The gateway of a route to another kind of target should be left unset.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			routes: []Route{{DestinationCIDRBlock: aws.String("0.0.0.0/0"), NatGatewayID: aws.String("nat-1")}},
+			want: want{
+				routes: []Route{{DestinationCIDRBlock: aws.String("0.0.0.0/0"), NatGatewayID: aws.String("nat-1")}},
+			},
+		},
+		"GatewayRef": {
+			reason: "The gateway of a route should be resolved from its reference.",
+			c: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj runtime.Object) error {
+				meta.SetExternalName(obj.(metav1.Object), "igw-1")
+				return nil
+			})},
+			routes: []Route{{DestinationCIDRBlock: aws.String("0.0.0.0/0"), GatewayIDRef: &v1alpha1.Reference{Name: "igw"}}},
+			want: want{
+				routes: []Route{{DestinationCIDRBlock: aws.String("0.0.0.0/0"), GatewayID: aws.String("igw-1"), GatewayIDRef: &v1alpha1.Reference{Name: "igw"}}},
+			},
+		},
+		"GetGatewayError": {
+			reason: "Errors resolving the gateway reference should be returned.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			routes: []Route{{DestinationCIDRBlock: aws.String("0.0.0.0/0"), GatewayIDRef: &v1alpha1.Reference{Name: "igw"}}},
+			want: want{
+				routes: []Route{{DestinationCIDRBlock: aws.String("0.0.0.0/0"), GatewayIDRef: &v1alpha1.Reference{Name: "igw"}}},
+				err:    errors.Wrap(errBoom, "cannot get managed resource"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &RouteTable{Spec: RouteTableSpec{ForProvider: RouteTableParameters{VPCID: aws.String("vpc-1"), Routes: tc.routes}}}
+			err := mg.ResolveReferences(context.Background(), tc.c)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolveReferences(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.routes, mg.Spec.ForProvider.Routes); diff != "" {
+				t.Errorf("\n%s\nResolveReferences(...): -want routes, +got routes:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupRuleResolveReferences(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      SecurityGroupRuleParameters
+		want   SecurityGroupRuleParameters
+	}{
+		"CIDRIP": {
+			reason: "The source security group of a rule that allows traffic from a CIDR block should be left unset.",
+			p:      SecurityGroupRuleParameters{SecurityGroupID: aws.String("sg-1"), CIDRIP: aws.String("10.0.0.0/16")},
+			want:   SecurityGroupRuleParameters{SecurityGroupID: aws.String("sg-1"), CIDRIP: aws.String("10.0.0.0/16")},
+		},
+		"NoSecurityGroup": {
+			reason: "The security group of a rule should be left unset if it has no reference.",
+			p:      SecurityGroupRuleParameters{SourceSecurityGroupID: aws.String("sg-2")},
+			want:   SecurityGroupRuleParameters{SourceSecurityGroupID: aws.String("sg-2")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &SecurityGroupRule{Spec: SecurityGroupRuleSpec{ForProvider: tc.p}}
+			err := mg.ResolveReferences(context.Background(), &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))})
+			if err != nil {
+				t.Errorf("\n%s\nResolveReferences(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, mg.Spec.ForProvider); diff != "" {
+				t.Errorf("\n%s\nResolveReferences(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
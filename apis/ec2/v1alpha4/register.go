@@ -48,6 +48,42 @@ var (
 	RouteTableGroupVersionKind = SchemeGroupVersion.WithKind(RouteTableKind)
 )
 
+// SecurityGroupRule type metadata.
+var (
+	SecurityGroupRuleKind             = reflect.TypeOf(SecurityGroupRule{}).Name()
+	SecurityGroupRuleGroupKind        = schema.GroupKind{Group: Group, Kind: SecurityGroupRuleKind}.String()
+	SecurityGroupRuleKindAPIVersion   = SecurityGroupRuleKind + "." + SchemeGroupVersion.String()
+	SecurityGroupRuleGroupVersionKind = SchemeGroupVersion.WithKind(SecurityGroupRuleKind)
+)
+
+// ElasticIP type metadata.
+var (
+	ElasticIPKind             = reflect.TypeOf(ElasticIP{}).Name()
+	ElasticIPGroupKind        = schema.GroupKind{Group: Group, Kind: ElasticIPKind}.String()
+	ElasticIPKindAPIVersion   = ElasticIPKind + "." + SchemeGroupVersion.String()
+	ElasticIPGroupVersionKind = SchemeGroupVersion.WithKind(ElasticIPKind)
+)
+
+// NATGateway type metadata.
+var (
+	NATGatewayKind             = reflect.TypeOf(NATGateway{}).Name()
+	NATGatewayGroupKind        = schema.GroupKind{Group: Group, Kind: NATGatewayKind}.String()
+	NATGatewayKindAPIVersion   = NATGatewayKind + "." + SchemeGroupVersion.String()
+	NATGatewayGroupVersionKind = SchemeGroupVersion.WithKind(NATGatewayKind)
+)
+
+// VPCPeeringConnection type metadata.
+var (
+	VPCPeeringConnectionKind             = reflect.TypeOf(VPCPeeringConnection{}).Name()
+	VPCPeeringConnectionGroupKind        = schema.GroupKind{Group: Group, Kind: VPCPeeringConnectionKind}.String()
+	VPCPeeringConnectionKindAPIVersion   = VPCPeeringConnectionKind + "." + SchemeGroupVersion.String()
+	VPCPeeringConnectionGroupVersionKind = SchemeGroupVersion.WithKind(VPCPeeringConnectionKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&RouteTable{}, &RouteTableList{})
+	SchemeBuilder.Register(&SecurityGroupRule{}, &SecurityGroupRuleList{})
+	SchemeBuilder.Register(&ElasticIP{}, &ElasticIPList{})
+	SchemeBuilder.Register(&NATGateway{}, &NATGatewayList{})
+	SchemeBuilder.Register(&VPCPeeringConnection{}, &VPCPeeringConnectionList{})
 }
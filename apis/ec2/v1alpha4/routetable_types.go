@@ -31,6 +31,11 @@ type Route struct {
 	// +optional
 	DestinationCIDRBlock *string `json:"destinationCidrBlock,omitempty"`
 
+	// The IPv6 CIDR block used for the destination match. Routing decisions
+	// are based on the most specific match.
+	// +optional
+	DestinationIPv6CIDRBlock *string `json:"destinationIpv6CidrBlock,omitempty"`
+
 	// The ID of an internet gateway or virtual private gateway attached to your
 	// VPC.
 	// +optional
@@ -41,6 +46,48 @@ type Route struct {
 
 	// A selector to select a referencer to retrieve the ID of a gateway
 	GatewayIDSelector *runtimev1alpha1.Selector `json:"gatewayIdSelector,omitempty"`
+
+	// The ID of a NAT gateway.
+	// +optional
+	NatGatewayID *string `json:"natGatewayId,omitempty"`
+
+	// A referencer to retrieve the ID of a NAT gateway
+	// +optional
+	NatGatewayIDRef *runtimev1alpha1.Reference `json:"natGatewayIdRef,omitempty"`
+
+	// A selector to select a referencer to retrieve the ID of a NAT gateway
+	// +optional
+	NatGatewayIDSelector *runtimev1alpha1.Selector `json:"natGatewayIdSelector,omitempty"`
+
+	// The ID of a transit gateway.
+	// +optional
+	TransitGatewayID *string `json:"transitGatewayId,omitempty"`
+
+	// The ID of a VPC peering connection.
+	// +optional
+	VPCPeeringConnectionID *string `json:"vpcPeeringConnectionId,omitempty"`
+
+	// A referencer to retrieve the ID of a VPC peering connection
+	// +optional
+	VPCPeeringConnectionIDRef *runtimev1alpha1.Reference `json:"vpcPeeringConnectionIdRef,omitempty"`
+
+	// A selector to select a referencer to retrieve the ID of a VPC peering
+	// connection
+	// +optional
+	VPCPeeringConnectionIDSelector *runtimev1alpha1.Selector `json:"vpcPeeringConnectionIdSelector,omitempty"`
+
+	// The ID of a network interface.
+	// +optional
+	NetworkInterfaceID *string `json:"networkInterfaceId,omitempty"`
+
+	// The ID of a NAT instance in your VPC. The operation fails if you specify
+	// an instance ID unless exactly one network interface is attached.
+	// +optional
+	InstanceID *string `json:"instanceId,omitempty"`
+
+	// The ID of an egress-only internet gateway. Only valid for IPv6 routes.
+	// +optional
+	EgressOnlyInternetGatewayID *string `json:"egressOnlyInternetGatewayId,omitempty"`
 }
 
 // RouteState describes a route state in the route table.
@@ -54,9 +101,39 @@ type RouteState struct {
 	// decisions are based on the most specific match.
 	DestinationCIDRBlock string `json:"destinationCidrBlock,omitempty"`
 
+	// The IPv6 CIDR block used for the destination match.
+	DestinationIPv6CIDRBlock string `json:"destinationIpv6CidrBlock,omitempty"`
+
+	// The prefix of the AWS service used for the destination match.
+	DestinationPrefixListID string `json:"destinationPrefixListId,omitempty"`
+
 	// The ID of an internet gateway or virtual private gateway attached to your
 	// VPC.
 	GatewayID string `json:"gatewayId,omitempty"`
+
+	// The ID of a NAT gateway.
+	NatGatewayID string `json:"natGatewayId,omitempty"`
+
+	// The ID of a transit gateway.
+	TransitGatewayID string `json:"transitGatewayId,omitempty"`
+
+	// The ID of a VPC peering connection.
+	VPCPeeringConnectionID string `json:"vpcPeeringConnectionId,omitempty"`
+
+	// The ID of the network interface.
+	NetworkInterfaceID string `json:"networkInterfaceId,omitempty"`
+
+	// The ID of a NAT instance in your VPC.
+	InstanceID string `json:"instanceId,omitempty"`
+
+	// The ID of the egress-only internet gateway.
+	EgressOnlyInternetGatewayID string `json:"egressOnlyInternetGatewayId,omitempty"`
+
+	// Describes how the route was created. CreateRouteTable indicates the
+	// route was automatically created when the route table was created,
+	// CreateRoute that it was manually added, and EnableVgwRoutePropagation
+	// that it was propagated by route propagation.
+	Origin string `json:"origin,omitempty"`
 }
 
 // Association describes an association between a route table and a subnet.
@@ -94,9 +171,13 @@ type AssociationState struct {
 // RouteTableParameters define the desired state of an AWS VPC Route Table.
 type RouteTableParameters struct {
 	// The associations between the route table and one or more subnets.
+	// Subnets that are not specified are disassociated from the route table.
 	Associations []Association `json:"associations"`
 
-	// the routes in the route table
+	// the routes in the route table. Each route has exactly one destination
+	// and one target. Routes that are not specified are deleted, except for
+	// the local routes of the VPC, propagated routes, and routes to prefix
+	// lists, which are created by VPC endpoints.
 	Routes []Route `json:"routes"`
 
 	// Tags represents to current ec2 tags.
@@ -137,6 +218,9 @@ type RouteTableObservation struct {
 
 	// The actual associations created for the route table.
 	Associations []AssociationState `json:"associations,omitempty"`
+
+	// Tags of the RouteTable, as observed in AWS.
+	Tags []ec2v1beta1.Tag `json:"tags,omitempty"`
 }
 
 // A RouteTableStatus represents the observed state of a RouteTable.
@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// Types of security group rules.
+const (
+	SecurityGroupRuleTypeIngress = "ingress"
+	SecurityGroupRuleTypeEgress  = "egress"
+)
+
+// SecurityGroupRuleParameters define the desired state of an AWS security
+// group rule. A rule allows traffic from (or to) exactly one of a CIDR block,
+// an IPv6 CIDR block, a prefix list, or a security group.
+type SecurityGroupRuleParameters struct {
+	// Type of the rule, either ingress (inbound) or egress (outbound).
+	// +kubebuilder:validation:Enum=ingress;egress
+	// +immutable
+	Type string `json:"type"`
+
+	// SecurityGroupID is the ID of the security group the rule belongs to.
+	// +optional
+	// +immutable
+	SecurityGroupID *string `json:"securityGroupId,omitempty"`
+
+	// SecurityGroupIDRef references a SecurityGroup to retrieve its
+	// securityGroupId.
+	// +optional
+	// +immutable
+	SecurityGroupIDRef *runtimev1alpha1.Reference `json:"securityGroupIdRef,omitempty"`
+
+	// SecurityGroupIDSelector selects a reference to a SecurityGroup to
+	// retrieve its securityGroupId.
+	// +optional
+	SecurityGroupIDSelector *runtimev1alpha1.Selector `json:"securityGroupIdSelector,omitempty"`
+
+	// The IP protocol name (tcp, udp, icmp, icmpv6) or number. Use -1 to
+	// specify all protocols, in which case traffic on all ports is allowed
+	// regardless of any ports you specify.
+	// +immutable
+	IPProtocol string `json:"ipProtocol"`
+
+	// The start of port range for the TCP and UDP protocols, or an ICMP/ICMPv6
+	// type number. A value of -1 indicates all ICMP/ICMPv6 types.
+	// +optional
+	// +immutable
+	FromPort *int64 `json:"fromPort,omitempty"`
+
+	// The end of port range for the TCP and UDP protocols, or an ICMP/ICMPv6
+	// code. A value of -1 indicates all ICMP/ICMPv6 codes.
+	// +optional
+	// +immutable
+	ToPort *int64 `json:"toPort,omitempty"`
+
+	// The IPv4 CIDR range the rule allows traffic from (or to).
+	// +optional
+	// +immutable
+	CIDRIP *string `json:"cidrIp,omitempty"`
+
+	// The IPv6 CIDR range the rule allows traffic from (or to).
+	// +optional
+	// +immutable
+	CIDRIPv6 *string `json:"cidrIpv6,omitempty"`
+
+	// The ID of the prefix list the rule allows traffic from (or to).
+	// +optional
+	// +immutable
+	PrefixListID *string `json:"prefixListId,omitempty"`
+
+	// SourceSecurityGroupID is the ID of the security group the rule allows
+	// traffic from (or to).
+	// +optional
+	// +immutable
+	SourceSecurityGroupID *string `json:"sourceSecurityGroupId,omitempty"`
+
+	// SourceSecurityGroupIDRef references a SecurityGroup to retrieve its
+	// sourceSecurityGroupId.
+	// +optional
+	// +immutable
+	SourceSecurityGroupIDRef *runtimev1alpha1.Reference `json:"sourceSecurityGroupIdRef,omitempty"`
+
+	// SourceSecurityGroupIDSelector selects a reference to a SecurityGroup to
+	// retrieve its sourceSecurityGroupId.
+	// +optional
+	SourceSecurityGroupIDSelector *runtimev1alpha1.Selector `json:"sourceSecurityGroupIdSelector,omitempty"`
+
+	// The ID of the AWS account that owns the source security group, if it is
+	// owned by another account.
+	// +optional
+	// +immutable
+	SourceSecurityGroupOwnerID *string `json:"sourceSecurityGroupOwnerId,omitempty"`
+
+	// A description of the rule.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// A SecurityGroupRuleSpec defines the desired state of a SecurityGroupRule.
+type SecurityGroupRuleSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  SecurityGroupRuleParameters `json:"forProvider"`
+}
+
+// A SecurityGroupRuleStatus represents the observed state of a
+// SecurityGroupRule.
+type SecurityGroupRuleStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SecurityGroupRule is a managed resource that represents a single rule of
+// an AWS security group. Security group rules have no ID, so a
+// SecurityGroupRule identifies its rule by its parameters, which are
+// immutable. Rules of a security group that is managed by a SecurityGroup are
+// revoked unless that SecurityGroup ignores unspecified rules.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.forProvider.type"
+// +kubebuilder:printcolumn:name="SECURITY-GROUP",type="string",JSONPath=".spec.forProvider.securityGroupId"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,aws}
+type SecurityGroupRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecurityGroupRuleSpec   `json:"spec"`
+	Status SecurityGroupRuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecurityGroupRuleList contains a list of SecurityGroupRules
+type SecurityGroupRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityGroupRule `json:"items"`
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha4
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+
+	ec2v1beta1 "github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+)
+
+// VPCPeeringConnectionOptions describe the options of one side of a VPC
+// peering connection.
+type VPCPeeringConnectionOptions struct {
+	// AllowDNSResolutionFromRemoteVPC enables a local VPC to resolve public
+	// DNS hostnames to private IP addresses when queried from instances in the
+	// peer VPC.
+	// +optional
+	AllowDNSResolutionFromRemoteVPC *bool `json:"allowDnsResolutionFromRemoteVpc,omitempty"`
+}
+
+// VPCPeeringConnectionParameters define the desired state of an AWS VPC
+// peering connection.
+type VPCPeeringConnectionParameters struct {
+	// VPCID is the ID of the requester VPC.
+	// +optional
+	// +immutable
+	VPCID *string `json:"vpcId,omitempty"`
+
+	// VPCIDRef references a VPC to retrieve its vpcId
+	// +optional
+	// +immutable
+	VPCIDRef *runtimev1alpha1.Reference `json:"vpcIdRef,omitempty"`
+
+	// VPCIDSelector selects a reference to a VPC to retrieve its vpcId
+	// +optional
+	VPCIDSelector *runtimev1alpha1.Selector `json:"vpcIdSelector,omitempty"`
+
+	// PeerVPCID is the ID of the accepter VPC.
+	// +optional
+	// +immutable
+	PeerVPCID *string `json:"peerVpcId,omitempty"`
+
+	// PeerVPCIDRef references a VPC to retrieve its vpcId as the peerVpcId
+	// +optional
+	// +immutable
+	PeerVPCIDRef *runtimev1alpha1.Reference `json:"peerVpcIdRef,omitempty"`
+
+	// PeerVPCIDSelector selects a reference to a VPC to retrieve its vpcId as
+	// the peerVpcId
+	// +optional
+	PeerVPCIDSelector *runtimev1alpha1.Selector `json:"peerVpcIdSelector,omitempty"`
+
+	// PeerOwnerID is the AWS account ID of the owner of the accepter VPC.
+	// Defaults to the account of the requester.
+	// +optional
+	// +immutable
+	PeerOwnerID *string `json:"peerOwnerId,omitempty"`
+
+	// PeerRegion is the region of the accepter VPC. Defaults to the region of
+	// the requester.
+	// +optional
+	// +immutable
+	PeerRegion *string `json:"peerRegion,omitempty"`
+
+	// AutoAccept the peering connection using the credentials of the
+	// requester. This only succeeds if both VPCs are owned by the same
+	// account. The peering connection is always accepted if an accepter
+	// Provider is referenced.
+	// +optional
+	AutoAccept *bool `json:"autoAccept,omitempty"`
+
+	// RequesterPeeringOptions are the options of the requester side of the
+	// peering connection, which can be set once it is active.
+	// +optional
+	RequesterPeeringOptions *VPCPeeringConnectionOptions `json:"requesterPeeringOptions,omitempty"`
+
+	// AccepterPeeringOptions are the options of the accepter side of the
+	// peering connection, which can be set once it is active. They are set
+	// using the credentials of the accepter Provider if one is referenced.
+	// +optional
+	AccepterPeeringOptions *VPCPeeringConnectionOptions `json:"accepterPeeringOptions,omitempty"`
+
+	// Tags represents to current ec2 tags.
+	// +optional
+	Tags []ec2v1beta1.Tag `json:"tags,omitempty"`
+}
+
+// A VPCPeeringConnectionSpec defines the desired state of a
+// VPCPeeringConnection.
+type VPCPeeringConnectionSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+
+	// AccepterProviderReference specifies the Provider that is used to
+	// accept the peering connection and set the options of its accepter
+	// side, typically one for the account and region of the accepter VPC.
+	// +optional
+	AccepterProviderReference *corev1.ObjectReference `json:"accepterProviderRef,omitempty"`
+
+	ForProvider VPCPeeringConnectionParameters `json:"forProvider"`
+}
+
+// VPCPeeringConnectionVPCInfo describes a VPC of a VPC peering connection.
+type VPCPeeringConnectionVPCInfo struct {
+	// The ID of the VPC.
+	VPCID string `json:"vpcId,omitempty"`
+
+	// The AWS account ID of the owner of the VPC.
+	OwnerID string `json:"ownerId,omitempty"`
+
+	// The region in which the VPC is located.
+	Region string `json:"region,omitempty"`
+
+	// The IPv4 CIDR blocks of the VPC.
+	CIDRBlocks []string `json:"cidrBlocks,omitempty"`
+
+	// The IPv6 CIDR blocks of the VPC.
+	IPv6CIDRBlocks []string `json:"ipv6CidrBlocks,omitempty"`
+
+	// Whether the VPC resolves public DNS hostnames to private IP addresses
+	// when queried from instances in the peer VPC.
+	AllowDNSResolutionFromRemoteVPC bool `json:"allowDnsResolutionFromRemoteVpc,omitempty"`
+}
+
+// VPCPeeringConnectionObservation keeps the state for the external resource
+type VPCPeeringConnectionObservation struct {
+	// The ID of the VPC peering connection.
+	VPCPeeringConnectionID string `json:"vpcPeeringConnectionId,omitempty"`
+
+	// The status of the VPC peering connection, one of initiating-request,
+	// pending-acceptance, provisioning, active, rejected, failed, expired,
+	// deleting or deleted.
+	Status string `json:"status,omitempty"`
+
+	// A message that provides more information about the status, if
+	// applicable.
+	StatusMessage string `json:"statusMessage,omitempty"`
+
+	// The time that an unaccepted VPC peering connection will expire.
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// Information about the requester VPC.
+	RequesterVPCInfo VPCPeeringConnectionVPCInfo `json:"requesterVpcInfo,omitempty"`
+
+	// Information about the accepter VPC.
+	AccepterVPCInfo VPCPeeringConnectionVPCInfo `json:"accepterVpcInfo,omitempty"`
+
+	// Tags of the VPCPeeringConnection, as observed in AWS.
+	Tags []ec2v1beta1.Tag `json:"tags,omitempty"`
+}
+
+// A VPCPeeringConnectionStatus represents the observed state of a
+// VPCPeeringConnection.
+type VPCPeeringConnectionStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     VPCPeeringConnectionObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A VPCPeeringConnection is a managed resource that represents an AWS VPC
+// peering connection. Peering connections with a VPC of another account or
+// region are accepted using the accepter Provider, if one is referenced.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="VPC",type="string",JSONPath=".spec.forProvider.vpcId"
+// +kubebuilder:printcolumn:name="PEER-VPC",type="string",JSONPath=".spec.forProvider.peerVpcId"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,aws}
+type VPCPeeringConnection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VPCPeeringConnectionSpec   `json:"spec"`
+	Status VPCPeeringConnectionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VPCPeeringConnectionList contains a list of VPCPeeringConnections
+type VPCPeeringConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VPCPeeringConnection `json:"items"`
+}
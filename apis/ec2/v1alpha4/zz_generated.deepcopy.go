@@ -23,6 +23,7 @@ package v1alpha4
 import (
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+	"k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -71,6 +72,346 @@ func (in *AssociationState) DeepCopy() *AssociationState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticIP) DeepCopyInto(out *ElasticIP) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIP.
+func (in *ElasticIP) DeepCopy() *ElasticIP {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticIP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticIPList) DeepCopyInto(out *ElasticIPList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticIP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIPList.
+func (in *ElasticIPList) DeepCopy() *ElasticIPList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticIPList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticIPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticIPObservation) DeepCopyInto(out *ElasticIPObservation) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]v1beta1.Tag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIPObservation.
+func (in *ElasticIPObservation) DeepCopy() *ElasticIPObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticIPObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticIPParameters) DeepCopyInto(out *ElasticIPParameters) {
+	*out = *in
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = new(string)
+		**out = **in
+	}
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicIPv4Pool != nil {
+		in, out := &in.PublicIPv4Pool, &out.PublicIPv4Pool
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkBorderGroup != nil {
+		in, out := &in.NetworkBorderGroup, &out.NetworkBorderGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]v1beta1.Tag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIPParameters.
+func (in *ElasticIPParameters) DeepCopy() *ElasticIPParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticIPParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticIPSpec) DeepCopyInto(out *ElasticIPSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIPSpec.
+func (in *ElasticIPSpec) DeepCopy() *ElasticIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticIPStatus) DeepCopyInto(out *ElasticIPStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIPStatus.
+func (in *ElasticIPStatus) DeepCopy() *ElasticIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGateway) DeepCopyInto(out *NATGateway) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGateway.
+func (in *NATGateway) DeepCopy() *NATGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATGateway) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayAddress) DeepCopyInto(out *NATGatewayAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayAddress.
+func (in *NATGatewayAddress) DeepCopy() *NATGatewayAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayList) DeepCopyInto(out *NATGatewayList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NATGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayList.
+func (in *NATGatewayList) DeepCopy() *NATGatewayList {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATGatewayList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayObservation) DeepCopyInto(out *NATGatewayObservation) {
+	*out = *in
+	if in.NATGatewayAddresses != nil {
+		in, out := &in.NATGatewayAddresses, &out.NATGatewayAddresses
+		*out = make([]NATGatewayAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreateTime != nil {
+		in, out := &in.CreateTime, &out.CreateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]v1beta1.Tag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayObservation.
+func (in *NATGatewayObservation) DeepCopy() *NATGatewayObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayParameters) DeepCopyInto(out *NATGatewayParameters) {
+	*out = *in
+	if in.AllocationID != nil {
+		in, out := &in.AllocationID, &out.AllocationID
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllocationIDRef != nil {
+		in, out := &in.AllocationIDRef, &out.AllocationIDRef
+		*out = new(v1alpha1.Reference)
+		**out = **in
+	}
+	if in.AllocationIDSelector != nil {
+		in, out := &in.AllocationIDSelector, &out.AllocationIDSelector
+		*out = new(v1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubnetID != nil {
+		in, out := &in.SubnetID, &out.SubnetID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubnetIDRef != nil {
+		in, out := &in.SubnetIDRef, &out.SubnetIDRef
+		*out = new(v1alpha1.Reference)
+		**out = **in
+	}
+	if in.SubnetIDSelector != nil {
+		in, out := &in.SubnetIDSelector, &out.SubnetIDSelector
+		*out = new(v1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]v1beta1.Tag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayParameters.
+func (in *NATGatewayParameters) DeepCopy() *NATGatewayParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewaySpec) DeepCopyInto(out *NATGatewaySpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewaySpec.
+func (in *NATGatewaySpec) DeepCopy() *NATGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayStatus) DeepCopyInto(out *NATGatewayStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayStatus.
+func (in *NATGatewayStatus) DeepCopy() *NATGatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
@@ -79,50 +420,277 @@ func (in *Route) DeepCopyInto(out *Route) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DestinationIPv6CIDRBlock != nil {
+		in, out := &in.DestinationIPv6CIDRBlock, &out.DestinationIPv6CIDRBlock
+		*out = new(string)
+		**out = **in
+	}
 	if in.GatewayID != nil {
 		in, out := &in.GatewayID, &out.GatewayID
 		*out = new(string)
 		**out = **in
 	}
-	if in.GatewayIDRef != nil {
-		in, out := &in.GatewayIDRef, &out.GatewayIDRef
+	if in.GatewayIDRef != nil {
+		in, out := &in.GatewayIDRef, &out.GatewayIDRef
+		*out = new(v1alpha1.Reference)
+		**out = **in
+	}
+	if in.GatewayIDSelector != nil {
+		in, out := &in.GatewayIDSelector, &out.GatewayIDSelector
+		*out = new(v1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NatGatewayID != nil {
+		in, out := &in.NatGatewayID, &out.NatGatewayID
+		*out = new(string)
+		**out = **in
+	}
+	if in.NatGatewayIDRef != nil {
+		in, out := &in.NatGatewayIDRef, &out.NatGatewayIDRef
+		*out = new(v1alpha1.Reference)
+		**out = **in
+	}
+	if in.NatGatewayIDSelector != nil {
+		in, out := &in.NatGatewayIDSelector, &out.NatGatewayIDSelector
+		*out = new(v1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransitGatewayID != nil {
+		in, out := &in.TransitGatewayID, &out.TransitGatewayID
+		*out = new(string)
+		**out = **in
+	}
+	if in.VPCPeeringConnectionID != nil {
+		in, out := &in.VPCPeeringConnectionID, &out.VPCPeeringConnectionID
+		*out = new(string)
+		**out = **in
+	}
+	if in.VPCPeeringConnectionIDRef != nil {
+		in, out := &in.VPCPeeringConnectionIDRef, &out.VPCPeeringConnectionIDRef
+		*out = new(v1alpha1.Reference)
+		**out = **in
+	}
+	if in.VPCPeeringConnectionIDSelector != nil {
+		in, out := &in.VPCPeeringConnectionIDSelector, &out.VPCPeeringConnectionIDSelector
+		*out = new(v1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkInterfaceID != nil {
+		in, out := &in.NetworkInterfaceID, &out.NetworkInterfaceID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceID != nil {
+		in, out := &in.InstanceID, &out.InstanceID
+		*out = new(string)
+		**out = **in
+	}
+	if in.EgressOnlyInternetGatewayID != nil {
+		in, out := &in.EgressOnlyInternetGatewayID, &out.EgressOnlyInternetGatewayID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteState) DeepCopyInto(out *RouteState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteState.
+func (in *RouteState) DeepCopy() *RouteState {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteTable) DeepCopyInto(out *RouteTable) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTable.
+func (in *RouteTable) DeepCopy() *RouteTable {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteTable) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteTableList) DeepCopyInto(out *RouteTableList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RouteTable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTableList.
+func (in *RouteTableList) DeepCopy() *RouteTableList {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTableList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteTableList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteTableObservation) DeepCopyInto(out *RouteTableObservation) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]RouteState, len(*in))
+		copy(*out, *in)
+	}
+	if in.Associations != nil {
+		in, out := &in.Associations, &out.Associations
+		*out = make([]AssociationState, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]v1beta1.Tag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTableObservation.
+func (in *RouteTableObservation) DeepCopy() *RouteTableObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTableObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteTableParameters) DeepCopyInto(out *RouteTableParameters) {
+	*out = *in
+	if in.Associations != nil {
+		in, out := &in.Associations, &out.Associations
+		*out = make([]Association, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]Route, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]v1beta1.Tag, len(*in))
+		copy(*out, *in)
+	}
+	if in.VPCID != nil {
+		in, out := &in.VPCID, &out.VPCID
+		*out = new(string)
+		**out = **in
+	}
+	if in.VPCIDRef != nil {
+		in, out := &in.VPCIDRef, &out.VPCIDRef
 		*out = new(v1alpha1.Reference)
 		**out = **in
 	}
-	if in.GatewayIDSelector != nil {
-		in, out := &in.GatewayIDSelector, &out.GatewayIDSelector
+	if in.VPCIDSelector != nil {
+		in, out := &in.VPCIDSelector, &out.VPCIDSelector
 		*out = new(v1alpha1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
-func (in *Route) DeepCopy() *Route {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTableParameters.
+func (in *RouteTableParameters) DeepCopy() *RouteTableParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(Route)
+	out := new(RouteTableParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RouteState) DeepCopyInto(out *RouteState) {
+func (in *RouteTableSpec) DeepCopyInto(out *RouteTableSpec) {
 	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteState.
-func (in *RouteState) DeepCopy() *RouteState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTableSpec.
+func (in *RouteTableSpec) DeepCopy() *RouteTableSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RouteState)
+	out := new(RouteTableSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RouteTable) DeepCopyInto(out *RouteTable) {
+func (in *RouteTableStatus) DeepCopyInto(out *RouteTableStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTableStatus.
+func (in *RouteTableStatus) DeepCopy() *RouteTableStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTableStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -130,18 +698,18 @@ func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTable.
-func (in *RouteTable) DeepCopy() *RouteTable {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRule.
+func (in *SecurityGroupRule) DeepCopy() *SecurityGroupRule {
 	if in == nil {
 		return nil
 	}
-	out := new(RouteTable)
+	out := new(SecurityGroupRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RouteTable) DeepCopyObject() runtime.Object {
+func (in *SecurityGroupRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -149,31 +717,31 @@ func (in *RouteTable) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RouteTableList) DeepCopyInto(out *RouteTableList) {
+func (in *SecurityGroupRuleList) DeepCopyInto(out *SecurityGroupRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RouteTable, len(*in))
+		*out = make([]SecurityGroupRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTableList.
-func (in *RouteTableList) DeepCopy() *RouteTableList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRuleList.
+func (in *SecurityGroupRuleList) DeepCopy() *SecurityGroupRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(RouteTableList)
+	out := new(SecurityGroupRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RouteTableList) DeepCopyObject() runtime.Object {
+func (in *SecurityGroupRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -181,52 +749,226 @@ func (in *RouteTableList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RouteTableObservation) DeepCopyInto(out *RouteTableObservation) {
+func (in *SecurityGroupRuleParameters) DeepCopyInto(out *SecurityGroupRuleParameters) {
 	*out = *in
-	if in.Routes != nil {
-		in, out := &in.Routes, &out.Routes
-		*out = make([]RouteState, len(*in))
-		copy(*out, *in)
+	if in.SecurityGroupID != nil {
+		in, out := &in.SecurityGroupID, &out.SecurityGroupID
+		*out = new(string)
+		**out = **in
 	}
-	if in.Associations != nil {
-		in, out := &in.Associations, &out.Associations
-		*out = make([]AssociationState, len(*in))
-		copy(*out, *in)
+	if in.SecurityGroupIDRef != nil {
+		in, out := &in.SecurityGroupIDRef, &out.SecurityGroupIDRef
+		*out = new(v1alpha1.Reference)
+		**out = **in
+	}
+	if in.SecurityGroupIDSelector != nil {
+		in, out := &in.SecurityGroupIDSelector, &out.SecurityGroupIDSelector
+		*out = new(v1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FromPort != nil {
+		in, out := &in.FromPort, &out.FromPort
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ToPort != nil {
+		in, out := &in.ToPort, &out.ToPort
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CIDRIP != nil {
+		in, out := &in.CIDRIP, &out.CIDRIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.CIDRIPv6 != nil {
+		in, out := &in.CIDRIPv6, &out.CIDRIPv6
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrefixListID != nil {
+		in, out := &in.PrefixListID, &out.PrefixListID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SourceSecurityGroupID != nil {
+		in, out := &in.SourceSecurityGroupID, &out.SourceSecurityGroupID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SourceSecurityGroupIDRef != nil {
+		in, out := &in.SourceSecurityGroupIDRef, &out.SourceSecurityGroupIDRef
+		*out = new(v1alpha1.Reference)
+		**out = **in
+	}
+	if in.SourceSecurityGroupIDSelector != nil {
+		in, out := &in.SourceSecurityGroupIDSelector, &out.SourceSecurityGroupIDSelector
+		*out = new(v1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SourceSecurityGroupOwnerID != nil {
+		in, out := &in.SourceSecurityGroupOwnerID, &out.SourceSecurityGroupOwnerID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTableObservation.
-func (in *RouteTableObservation) DeepCopy() *RouteTableObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRuleParameters.
+func (in *SecurityGroupRuleParameters) DeepCopy() *SecurityGroupRuleParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(RouteTableObservation)
+	out := new(SecurityGroupRuleParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RouteTableParameters) DeepCopyInto(out *RouteTableParameters) {
+func (in *SecurityGroupRuleSpec) DeepCopyInto(out *SecurityGroupRuleSpec) {
 	*out = *in
-	if in.Associations != nil {
-		in, out := &in.Associations, &out.Associations
-		*out = make([]Association, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRuleSpec.
+func (in *SecurityGroupRuleSpec) DeepCopy() *SecurityGroupRuleSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Routes != nil {
-		in, out := &in.Routes, &out.Routes
-		*out = make([]Route, len(*in))
+	out := new(SecurityGroupRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRuleStatus) DeepCopyInto(out *SecurityGroupRuleStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRuleStatus.
+func (in *SecurityGroupRuleStatus) DeepCopy() *SecurityGroupRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringConnection) DeepCopyInto(out *VPCPeeringConnection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringConnection.
+func (in *VPCPeeringConnection) DeepCopy() *VPCPeeringConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VPCPeeringConnection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringConnectionList) DeepCopyInto(out *VPCPeeringConnectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VPCPeeringConnection, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringConnectionList.
+func (in *VPCPeeringConnectionList) DeepCopy() *VPCPeeringConnectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringConnectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VPCPeeringConnectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringConnectionObservation) DeepCopyInto(out *VPCPeeringConnectionObservation) {
+	*out = *in
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	in.RequesterVPCInfo.DeepCopyInto(&out.RequesterVPCInfo)
+	in.AccepterVPCInfo.DeepCopyInto(&out.AccepterVPCInfo)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]v1beta1.Tag, len(*in))
 		copy(*out, *in)
 	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringConnectionObservation.
+func (in *VPCPeeringConnectionObservation) DeepCopy() *VPCPeeringConnectionObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringConnectionObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringConnectionOptions) DeepCopyInto(out *VPCPeeringConnectionOptions) {
+	*out = *in
+	if in.AllowDNSResolutionFromRemoteVPC != nil {
+		in, out := &in.AllowDNSResolutionFromRemoteVPC, &out.AllowDNSResolutionFromRemoteVPC
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringConnectionOptions.
+func (in *VPCPeeringConnectionOptions) DeepCopy() *VPCPeeringConnectionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringConnectionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringConnectionParameters) DeepCopyInto(out *VPCPeeringConnectionParameters) {
+	*out = *in
 	if in.VPCID != nil {
 		in, out := &in.VPCID, &out.VPCID
 		*out = new(string)
@@ -242,48 +984,123 @@ func (in *RouteTableParameters) DeepCopyInto(out *RouteTableParameters) {
 		*out = new(v1alpha1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PeerVPCID != nil {
+		in, out := &in.PeerVPCID, &out.PeerVPCID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PeerVPCIDRef != nil {
+		in, out := &in.PeerVPCIDRef, &out.PeerVPCIDRef
+		*out = new(v1alpha1.Reference)
+		**out = **in
+	}
+	if in.PeerVPCIDSelector != nil {
+		in, out := &in.PeerVPCIDSelector, &out.PeerVPCIDSelector
+		*out = new(v1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PeerOwnerID != nil {
+		in, out := &in.PeerOwnerID, &out.PeerOwnerID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PeerRegion != nil {
+		in, out := &in.PeerRegion, &out.PeerRegion
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutoAccept != nil {
+		in, out := &in.AutoAccept, &out.AutoAccept
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequesterPeeringOptions != nil {
+		in, out := &in.RequesterPeeringOptions, &out.RequesterPeeringOptions
+		*out = new(VPCPeeringConnectionOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccepterPeeringOptions != nil {
+		in, out := &in.AccepterPeeringOptions, &out.AccepterPeeringOptions
+		*out = new(VPCPeeringConnectionOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]v1beta1.Tag, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTableParameters.
-func (in *RouteTableParameters) DeepCopy() *RouteTableParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringConnectionParameters.
+func (in *VPCPeeringConnectionParameters) DeepCopy() *VPCPeeringConnectionParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(RouteTableParameters)
+	out := new(VPCPeeringConnectionParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RouteTableSpec) DeepCopyInto(out *RouteTableSpec) {
+func (in *VPCPeeringConnectionSpec) DeepCopyInto(out *VPCPeeringConnectionSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	if in.AccepterProviderReference != nil {
+		in, out := &in.AccepterProviderReference, &out.AccepterProviderReference
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTableSpec.
-func (in *RouteTableSpec) DeepCopy() *RouteTableSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringConnectionSpec.
+func (in *VPCPeeringConnectionSpec) DeepCopy() *VPCPeeringConnectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RouteTableSpec)
+	out := new(VPCPeeringConnectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RouteTableStatus) DeepCopyInto(out *RouteTableStatus) {
+func (in *VPCPeeringConnectionStatus) DeepCopyInto(out *VPCPeeringConnectionStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTableStatus.
-func (in *RouteTableStatus) DeepCopy() *RouteTableStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringConnectionStatus.
+func (in *VPCPeeringConnectionStatus) DeepCopy() *VPCPeeringConnectionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RouteTableStatus)
+	out := new(VPCPeeringConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringConnectionVPCInfo) DeepCopyInto(out *VPCPeeringConnectionVPCInfo) {
+	*out = *in
+	if in.CIDRBlocks != nil {
+		in, out := &in.CIDRBlocks, &out.CIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPv6CIDRBlocks != nil {
+		in, out := &in.IPv6CIDRBlocks, &out.IPv6CIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringConnectionVPCInfo.
+func (in *VPCPeeringConnectionVPCInfo) DeepCopy() *VPCPeeringConnectionVPCInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringConnectionVPCInfo)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -80,6 +80,9 @@ type InternetGatewayObservation struct {
 
 	// The ID of the AWS account that owns the internet gateway.
 	OwnerID string `json:"ownerID"`
+
+	// Tags of the InternetGateway, as observed in AWS.
+	Tags []Tag `json:"tags,omitempty"`
 }
 
 // An InternetGatewayStatus represents the observed state of an InternetGateway.
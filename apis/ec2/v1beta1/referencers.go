@@ -76,6 +76,49 @@ func (mg *SecurityGroup) ResolveReferences(ctx context.Context, c client.Reader)
 	mg.Spec.ForProvider.VPCID = reference.ToPtrValue(rsp.ResolvedValue)
 	mg.Spec.ForProvider.VPCIDRef = rsp.ResolvedReference
 
+	// Resolve spec.ingress[].userIdGroupPairs[]
+	if err := resolveUserIDGroupPairs(ctx, r, mg.Spec.ForProvider.Ingress); err != nil {
+		return err
+	}
+
+	// Resolve spec.egress[].userIdGroupPairs[]
+	return resolveUserIDGroupPairs(ctx, r, mg.Spec.ForProvider.Egress)
+}
+
+// resolveUserIDGroupPairs resolves the groupID and vpcID of the user ID group
+// pairs of the supplied permissions.
+func resolveUserIDGroupPairs(ctx context.Context, r *reference.APIResolver, perms []IPPermission) error {
+	for i := range perms {
+		for j := range perms[i].UserIDGroupPairs {
+			pair := &perms[i].UserIDGroupPairs[j]
+
+			rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+				CurrentValue: reference.FromPtrValue(pair.GroupID),
+				Reference:    pair.GroupIDRef,
+				Selector:     pair.GroupIDSelector,
+				To:           reference.To{Managed: &SecurityGroup{}, List: &SecurityGroupList{}},
+				Extract:      reference.ExternalName(),
+			})
+			if err != nil {
+				return err
+			}
+			pair.GroupID = reference.ToPtrValue(rsp.ResolvedValue)
+			pair.GroupIDRef = rsp.ResolvedReference
+
+			rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+				CurrentValue: reference.FromPtrValue(pair.VPCID),
+				Reference:    pair.VPCIDRef,
+				Selector:     pair.VPCIDSelector,
+				To:           reference.To{Managed: &VPC{}, List: &VPCList{}},
+				Extract:      reference.ExternalName(),
+			})
+			if err != nil {
+				return err
+			}
+			pair.VPCID = reference.ToPtrValue(rsp.ResolvedValue)
+			pair.VPCIDRef = rsp.ResolvedReference
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	aws "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+func TestSecurityGroupResolveReferences(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// getFn sets the external names of the referenced SecurityGroup and VPC.
+	getFn := test.NewMockGetFn(nil, func(obj runtime.Object) error {
+		switch obj.(type) {
+		case *SecurityGroup:
+			meta.SetExternalName(obj.(metav1.Object), "sg-1")
+		case *VPC:
+			meta.SetExternalName(obj.(metav1.Object), "vpc-1")
+		}
+		return nil
+	})
+
+	// listFn returns a single SecurityGroup or VPC that matches any selector.
+	listFn := test.NewMockListFn(nil, func(obj runtime.Object) error {
+		switch l := obj.(type) {
+		case *SecurityGroupList:
+			sg := SecurityGroup{ObjectMeta: metav1.ObjectMeta{Name: "sg"}}
+			meta.SetExternalName(&sg, "sg-1")
+			l.Items = []SecurityGroup{sg}
+		case *VPCList:
+			vpc := VPC{ObjectMeta: metav1.ObjectMeta{Name: "vpc"}}
+			meta.SetExternalName(&vpc, "vpc-1")
+			l.Items = []VPC{vpc}
+		}
+		return nil
+	})
+
+	selector := &v1alpha1.Selector{MatchLabels: map[string]string{"cool": "true"}}
+
+	type want struct {
+		pairs []UserIDGroupPair
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		pairs  []UserIDGroupPair
+		want   want
+	}{
+		"NoReferences": {
+			reason: "The group and VPC of a pair without references or selectors should be left unchanged.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom), MockList: test.NewMockListFn(errBoom)},
+			pairs:  []UserIDGroupPair{{GroupID: aws.String("sg-2")}},
+			want: want{
+				pairs: []UserIDGroupPair{{GroupID: aws.String("sg-2")}},
+			},
+		},
+		"References": {
+			reason: "The group and VPC of a pair should be resolved from their references.",
+			c:      &test.MockClient{MockGet: getFn},
+			pairs: []UserIDGroupPair{{
+				GroupIDRef: &v1alpha1.Reference{Name: "sg"},
+				VPCIDRef:   &v1alpha1.Reference{Name: "vpc"},
+			}},
+			want: want{
+				pairs: []UserIDGroupPair{{
+					GroupID:    aws.String("sg-1"),
+					GroupIDRef: &v1alpha1.Reference{Name: "sg"},
+					VPCID:      aws.String("vpc-1"),
+					VPCIDRef:   &v1alpha1.Reference{Name: "vpc"},
+				}},
+			},
+		},
+		"Selectors": {
+			reason: "The group and VPC of a pair should be resolved from their selectors.",
+			c:      &test.MockClient{MockList: listFn},
+			pairs: []UserIDGroupPair{{
+				GroupIDSelector: selector,
+				VPCIDSelector:   selector,
+			}},
+			want: want{
+				pairs: []UserIDGroupPair{{
+					GroupID:         aws.String("sg-1"),
+					GroupIDRef:      &v1alpha1.Reference{Name: "sg"},
+					GroupIDSelector: selector,
+					VPCID:           aws.String("vpc-1"),
+					VPCIDRef:        &v1alpha1.Reference{Name: "vpc"},
+					VPCIDSelector:   selector,
+				}},
+			},
+		},
+		"GetGroupError": {
+			reason: "Errors resolving the group reference of a pair should be returned.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			pairs:  []UserIDGroupPair{{GroupIDRef: &v1alpha1.Reference{Name: "sg"}}},
+			want: want{
+				pairs: []UserIDGroupPair{{GroupIDRef: &v1alpha1.Reference{Name: "sg"}}},
+				err:   errors.Wrap(errBoom, "cannot get managed resource"),
+			},
+		},
+		"ListVPCError": {
+			reason: "Errors resolving the VPC selector of a pair should be returned.",
+			c:      &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			pairs:  []UserIDGroupPair{{GroupID: aws.String("sg-2"), VPCIDSelector: selector}},
+			want: want{
+				pairs: []UserIDGroupPair{{GroupID: aws.String("sg-2"), VPCIDSelector: selector}},
+				err:   errors.Wrap(errBoom, "cannot list managed resources"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		for _, direction := range []string{"Ingress", "Egress"} {
+			t.Run(name+direction, func(t *testing.T) {
+				perms := []IPPermission{{IPProtocol: "tcp", UserIDGroupPairs: append([]UserIDGroupPair(nil), tc.pairs...)}}
+				mg := &SecurityGroup{Spec: SecurityGroupSpec{ForProvider: SecurityGroupParameters{VPCID: aws.String("vpc-1")}}}
+				if direction == "Ingress" {
+					mg.Spec.ForProvider.Ingress = perms
+				} else {
+					mg.Spec.ForProvider.Egress = perms
+				}
+
+				err := mg.ResolveReferences(context.Background(), tc.c)
+				if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+					t.Errorf("\n%s\nResolveReferences(...): -want error, +got error:\n%s", tc.reason, diff)
+				}
+				if diff := cmp.Diff(tc.want.pairs, perms[0].UserIDGroupPairs); diff != "" {
+					t.Errorf("\n%s\nResolveReferences(...): -want pairs, +got pairs:\n%s", tc.reason, diff)
+				}
+			})
+		}
+	}
+}
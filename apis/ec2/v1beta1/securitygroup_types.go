@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 )
 
 // SecurityGroupParameters define the desired state of an AWS VPC Security
@@ -33,14 +35,24 @@ type SecurityGroupParameters struct {
 	// +immutable
 	GroupName string `json:"groupName"`
 
-	// One or more inbound rules associated with the security group.
+	// One or more inbound rules associated with the security group. Inbound
+	// rules that are not specified are revoked.
 	// +optional
 	Ingress []IPPermission `json:"ingress,omitempty"`
 
 	// [EC2-VPC] One or more outbound rules associated with the security group.
+	// Outbound rules that are not specified are revoked, including the
+	// default rules that allow all outbound traffic. The default rules are
+	// kept if no outbound rules are specified.
 	// +optional
 	Egress []IPPermission `json:"egress,omitempty"`
 
+	// IgnoreUnspecifiedRules prevents inbound and outbound rules that are not
+	// specified from being revoked, so that they may be managed elsewhere,
+	// for example by SecurityGroupRules.
+	// +optional
+	IgnoreUnspecifiedRules *bool `json:"ignoreUnspecifiedRules,omitempty"`
+
 	// Tags represents to current ec2 tags.
 	// +optional
 	Tags []Tag `json:"tags,omitempty"`
@@ -118,9 +130,18 @@ type UserIDGroupPair struct {
 	// +optional
 	GroupID *string `json:"groupId,omitempty"`
 
-	// The name of the security group. In a request, use this parameter for a security
-	// group in EC2-Classic or a default VPC only. For a security group in a nondefault
-	// VPC, use the security group ID.
+	// GroupIDRef references a SecurityGroup to retrieve its groupId
+	// +optional
+	GroupIDRef *runtimev1alpha1.Reference `json:"groupIdRef,omitempty"`
+
+	// GroupIDSelector selects a reference to a SecurityGroup to retrieve its
+	// groupId
+	// +optional
+	GroupIDSelector *runtimev1alpha1.Selector `json:"groupIdSelector,omitempty"`
+
+	// The name of the security group. Use this parameter for a security group
+	// in EC2-Classic only. For a security group in a VPC, use the security
+	// group ID.
 	//
 	// For a referenced security group in another VPC, this value is not returned
 	// if the referenced security group is deleted.
@@ -142,6 +163,14 @@ type UserIDGroupPair struct {
 	// +optional
 	VPCID *string `json:"vpcId,omitempty"`
 
+	// VPCIDRef references a VPC to retrieve its vpcId
+	// +optional
+	VPCIDRef *runtimev1alpha1.Reference `json:"vpcIdRef,omitempty"`
+
+	// VPCIDSelector selects a reference to a VPC to retrieve its vpcId
+	// +optional
+	VPCIDSelector *runtimev1alpha1.Selector `json:"vpcIdSelector,omitempty"`
+
 	// The ID of the VPC peering connection, if applicable.
 	// +optional
 	VPCPeeringConnectionID *string `json:"vpcPeeringConnectionId,omitempty"`
@@ -209,11 +238,15 @@ type SecurityGroupObservation struct {
 
 	// SecurityGroupID is the ID of the SecurityGroup.
 	SecurityGroupID string `json:"securityGroupID"`
+
+	// Tags of the SecurityGroup, as observed in AWS.
+	Tags []Tag `json:"tags,omitempty"`
 }
 
 // A SecurityGroupStatus represents the observed state of a SecurityGroup.
 type SecurityGroupStatus struct {
 	runtimev1alpha1.ResourceStatus `json:",inline"`
+	v1alpha3.DriftStatus           `json:",inline"`
 	AtProvider                     SecurityGroupObservation `json:"atProvider"`
 }
 
@@ -95,6 +95,9 @@ type SubnetObservation struct {
 
 	// SubnetID is the ID of the Subnet.
 	SubnetID string `json:"subnetId,omitempty"`
+
+	// Tags of the Subnet, as observed in AWS.
+	Tags []Tag `json:"tags,omitempty"`
 }
 
 // A SubnetStatus represents the observed state of a Subnet.
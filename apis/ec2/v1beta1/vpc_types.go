@@ -72,6 +72,21 @@ type VPCParameters struct {
 	// +immutable
 	CIDRBlock string `json:"cidrBlock"`
 
+	// SecondaryCIDRBlocks are additional IPv4 network ranges to associate
+	// with the VPC, in CIDR notation. Secondary CIDR blocks that are not
+	// specified are disassociated from the VPC, so an empty list disassociates
+	// all of them. If unset, it is initialized with the secondary CIDR blocks
+	// already associated with the VPC.
+	// +optional
+	// +nullable
+	SecondaryCIDRBlocks []string `json:"secondaryCidrBlocks"`
+
+	// AmazonProvidedIPv6CIDRBlock requests an Amazon-provided IPv6 CIDR block
+	// with a /56 prefix length for the VPC. If false, the IPv6 CIDR blocks of
+	// the VPC are disassociated from it.
+	// +optional
+	AmazonProvidedIPv6CIDRBlock *bool `json:"amazonProvidedIpv6CidrBlock,omitempty"`
+
 	// A boolean flag to enable/disable DNS support in the VPC
 	// +optional
 	EnableDNSSupport *bool `json:"enableDnsSupport,omitempty"`
@@ -114,6 +129,9 @@ type VPCObservation struct {
 
 	// VPCState is the current state of the VPC.
 	VPCState string `json:"vpcState,omitempty"`
+
+	// Tags of the VPC, as observed in AWS.
+	Tags []Tag `json:"tags,omitempty"`
 }
 
 // A VPCStatus represents the observed state of a VPC.
@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 )
 
 // ClusterStatusType is the status of an EKS cluster.
@@ -282,6 +284,7 @@ type ClusterSpec struct {
 // A ClusterStatus represents the observed state of an EKS Cluster.
 type ClusterStatus struct {
 	runtimev1alpha1.ResourceStatus `json:",inline"`
+	v1alpha3.DriftStatus           `json:",inline"`
 	AtProvider                     ClusterObservation `json:"atProvider,omitempty"`
 }
 
@@ -181,6 +181,7 @@ func (in *ClusterSpec) DeepCopy() *ClusterSpec {
 func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.DriftStatus.DeepCopyInto(&out.DriftStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
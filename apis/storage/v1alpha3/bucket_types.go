@@ -51,6 +51,10 @@ type S3BucketParameters struct {
 	// provisioning.
 	// +kubebuilder:validation:Enum=Read;Write;ReadWrite
 	LocalPermission *storagev1alpha1.LocalPermissionType `json:"localPermission"`
+
+	// Tags of the bucket.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // S3BucketSpec defines the desired state of S3Bucket
@@ -173,6 +173,13 @@ func (in *S3BucketParameters) DeepCopyInto(out *S3BucketParameters) {
 		*out = new(v1alpha1.LocalPermissionType)
 		**out = **in
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BucketParameters.
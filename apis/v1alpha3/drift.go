@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+// A FieldDrift describes a field of a managed resource's spec whose desired
+// value differs from the value observed on its external resource.
+type FieldDrift struct {
+	// Path of the field, e.g. spec.forProvider.engineVersion.
+	Path string `json:"path"`
+
+	// Observed is the JSON encoded value of the field observed on the external
+	// resource. Sensitive values are redacted.
+	// +optional
+	Observed string `json:"observed,omitempty"`
+
+	// Desired is the JSON encoded value of the field desired by the managed
+	// resource's spec. Sensitive values are redacted.
+	// +optional
+	Desired string `json:"desired,omitempty"`
+}
+
+// A DriftStatus reports how the external resource of a managed resource
+// differs from the desired state described by its spec.
+type DriftStatus struct {
+	// Drift lists the fields of the managed resource's spec that differ from
+	// its external resource as of the last time it was observed. It is empty
+	// when the external resource is up to date.
+	// +optional
+	Drift []FieldDrift `json:"drift,omitempty"`
+}
+
+// GetDrift of this DriftStatus.
+func (s *DriftStatus) GetDrift() []FieldDrift {
+	return s.Drift
+}
+
+// SetDrift of this DriftStatus.
+func (s *DriftStatus) SetDrift(d []FieldDrift) {
+	s.Drift = d
+}
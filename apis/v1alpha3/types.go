@@ -36,21 +36,143 @@ type ProviderSpec struct {
 	// If set to true, credentialsSecretRef will be ignored.
 	// +optional
 	UseServiceAccount *bool `json:"useServiceAccount,omitempty"`
+
+	// Profile of the AWS shared config or credentials file stored in the
+	// credentials Secret that should be used to authenticate. Defaults to
+	// 'default'.
+	// +optional
+	Profile *string `json:"profile,omitempty"`
+
+	// AssumeRole configures an IAM role that will be assumed using STS
+	// AssumeRole on top of the credentials obtained from either the
+	// credentials Secret or the ServiceAccount.
+	// +optional
+	AssumeRole *AssumeRoleOptions `json:"assumeRole,omitempty"`
+
+	// Endpoint overrides the endpoints used to connect to AWS services, for
+	// example in order to use an AWS compatible API such as LocalStack.
+	// +optional
+	Endpoint *EndpointConfig `json:"endpoint,omitempty"`
+
+	// AllowedAccountIDs is a list of the IDs of the AWS accounts that this
+	// Provider may be used with. Managed resources will not be reconciled
+	// using credentials that belong to any other account.
+	// +optional
+	AllowedAccountIDs []string `json:"allowedAccountIDs,omitempty"`
+
+	// ForbiddenAccountIDs is a list of the IDs of the AWS accounts that this
+	// Provider must not be used with. Managed resources will not be reconciled
+	// using credentials that belong to any of these accounts.
+	// +optional
+	ForbiddenAccountIDs []string `json:"forbiddenAccountIDs,omitempty"`
+
+	// DefaultTags are added to the tags of every taggable managed resource
+	// that uses this Provider. Tags specified by a managed resource override
+	// default tags with the same key.
+	// +optional
+	DefaultTags map[string]string `json:"defaultTags,omitempty"`
+}
+
+// AssumeRoleOptions configures how an IAM role is assumed.
+type AssumeRoleOptions struct {
+	// RoleARN is the Amazon Resource Name (ARN) of the IAM role to assume.
+	RoleARN string `json:"roleARN"`
+
+	// ExternalID is a unique identifier that might be required when assuming
+	// a role in another account.
+	// +optional
+	ExternalID *string `json:"externalID,omitempty"`
+
+	// SessionName is an identifier for the assumed role session. A unique
+	// name is generated if it is omitted.
+	// +optional
+	SessionName *string `json:"sessionName,omitempty"`
+
+	// Duration of the role session, such as 15m or 1h. Defaults to 15m. Note
+	// that role chaining limits sessions to a maximum of one hour.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RoleChain is a list of IAM roles to assume in order after RoleARN has
+	// been assumed. Each role is assumed using the credentials of the role
+	// that precedes it.
+	// +optional
+	RoleChain []ChainedRole `json:"roleChain,omitempty"`
+}
+
+// A ChainedRole is an IAM role that is assumed using the credentials of the
+// role that precedes it in a role chain.
+type ChainedRole struct {
+	// RoleARN is the Amazon Resource Name (ARN) of the IAM role to assume.
+	RoleARN string `json:"roleARN"`
+
+	// ExternalID is a unique identifier that might be required when assuming
+	// a role in another account.
+	// +optional
+	ExternalID *string `json:"externalID,omitempty"`
+}
+
+// EndpointConfig configures the endpoints used to connect to AWS services.
+type EndpointConfig struct {
+	// URL of the endpoint used for all AWS services that are not overridden
+	// by Services, e.g. http://localstack:4566.
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// Services maps AWS service endpoint identifiers, such as ec2, s3 or rds,
+	// to the URL of the endpoint that should be used for that service.
+	// +optional
+	Services map[string]string `json:"services,omitempty"`
+
+	// S3ForcePathStyle forces S3 requests to use path-style addressing, i.e.
+	// https://s3.amazonaws.com/BUCKET instead of https://BUCKET.s3.amazonaws.com.
+	// +optional
+	S3ForcePathStyle *bool `json:"s3ForcePathStyle,omitempty"`
+
+	// InsecureSkipTLSVerify disables verification of the TLS certificates
+	// presented by AWS service endpoints. This should only be used for
+	// testing.
+	// +optional
+	InsecureSkipTLSVerify *bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
+// A ProviderStatus represents the observed state of a Provider.
+type ProviderStatus struct {
+	runtimev1alpha1.ConditionedStatus `json:",inline"`
+
+	// AccountID is the ID of the AWS account that the Provider's credentials
+	// belong to.
+	// +optional
+	AccountID string `json:"accountID,omitempty"`
+
+	// ARN is the Amazon Resource Name (ARN) of the IAM identity that the
+	// Provider's credentials belong to.
+	// +optional
+	ARN string `json:"arn,omitempty"`
+
+	// ValidatedRegion is the region in which the Provider's credentials were
+	// last validated.
+	// +optional
+	ValidatedRegion string `json:"validatedRegion,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 
 // A Provider configures an AWS 'provider', i.e. a connection to a particular
 // AWS account using a particular AWS IAM role.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".spec.region"
+// +kubebuilder:printcolumn:name="ACCOUNT-ID",type="string",JSONPath=".status.accountID"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentialsSecretRef.name",priority=1
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,aws}
 type Provider struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ProviderSpec `json:"spec"`
+	Spec   ProviderSpec   `json:"spec"`
+	Status ProviderStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
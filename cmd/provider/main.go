@@ -19,16 +19,21 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	crossplaneapis "github.com/crossplane/crossplane/apis"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/crossplane/provider-aws/apis"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/controller"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 func main() {
@@ -36,9 +41,32 @@ func main() {
 		app        = kingpin.New(filepath.Base(os.Args[0]), "AWS support for Crossplane.").DefaultEnvars()
 		debug      = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		syncPeriod = app.Flag("sync", "Controller manager sync period duration such as 300ms, 1.5h or 2h45m").Short('s').Default("1h").Duration()
+
+		leaderElection          = app.Flag("leader-election", "Use leader election so that only one replica of the provider runs its controllers at a time.").Bool()
+		leaderElectionNamespace = app.Flag("leader-election-namespace", "Namespace in which to create the leader election ConfigMap. Defaults to the namespace the provider runs in.").String()
+		leaderElectionID        = app.Flag("leader-election-id", "Name of the leader election ConfigMap.").Default("crossplane-leader-election-provider-aws").String()
+		maxReconciles           = app.Flag("max-concurrent-reconciles", "Maximum number of reconciles each controller may run concurrently.").Default("1").Int()
+		metricsAddress          = app.Flag("metrics-bind-address", "Address at which to serve Prometheus metrics. Metrics are not served if set to 0.").Default(":8080").String()
+		probeAddress            = app.Flag("health-probe-bind-address", "Address at which to serve the /healthz and /readyz probe endpoints. Probes are not served if empty.").Default(":8081").String()
+		namespace               = app.Flag("namespace", "Namespace to watch. All namespaces are watched if empty. Cluster scoped resources are always watched, but the credentials Secrets of Providers must be in this namespace.").String()
+		enabledKinds            = app.Flag("enable", "API group, such as ec2.aws.crossplane.io, or kind qualified by its group, such as VPC.ec2.aws.crossplane.io, whose controllers should run. May be repeated. The controllers of all kinds run if omitted. Enable aws.crossplane.io to validate the credentials of Providers.").Strings()
+		disabledKinds           = app.Flag("disable", "API group or kind qualified by its group whose controllers should not run, even if enabled. May be repeated.").Strings()
+		labelSelector           = app.Flag("label-selector", "Label selector, such as env=prod,team!=web, that restricts the resources controllers reconcile. All resources are reconciled if empty.").String()
+
+		requestsPerSecond  = app.Flag("aws-requests-per-second", "Rate at which AWS API requests may be made to each service in each region. Requests are not limited if zero.").Default(strconv.FormatFloat(awsclients.DefaultRequestsPerSecond, 'f', -1, 64)).Float64()
+		requestBurst       = app.Flag("aws-request-burst", "Maximum number of AWS API requests that may be made to each service in each region in a burst. Must be at least 1 if requests are limited.").Default(strconv.Itoa(awsclients.DefaultRequestBurst)).Int()
+		maxAttempts        = app.Flag("aws-max-attempts", "Number of times an AWS API request is attempted before it fails.").Default(strconv.Itoa(awsclients.DefaultMaxAttempts)).Int()
+		maxBackoff         = app.Flag("aws-max-backoff", "Maximum delay before retrying a failed AWS API request.").Default(awsclients.DefaultMaxBackoff.String()).Duration()
+		maxThrottleBackoff = app.Flag("aws-max-throttle-backoff", "Maximum delay before retrying a throttled AWS API request.").Default(awsclients.DefaultMaxThrottleBackoff.String()).Duration()
+
+		allowCredentialProcess = app.Flag("allow-credential-process", "Allow the credentials Secrets of Providers to obtain credentials by running a credential_process. The process runs inside the provider, so anyone who can write a credentials Secret can run any command with the provider's permissions.").Bool()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
+	if *requestsPerSecond > 0 && *requestBurst < 1 {
+		kingpin.Fatalf("--aws-request-burst must be at least 1 when --aws-requests-per-second is positive")
+	}
+
 	zl := zap.New(zap.UseDevMode(*debug))
 	log := logging.NewLogrLogger(zl.WithName("provider-aws"))
 	if *debug {
@@ -48,17 +76,45 @@ func main() {
 		ctrl.SetLogger(zl)
 	}
 
-	log.Debug("Starting", "sync-period", syncPeriod.String())
+	log.Debug("Starting", "sync-period", syncPeriod.String(), "leader-election", *leaderElection, "namespace", *namespace, "label-selector", *labelSelector)
+
+	awsclients.AllowCredentialProcess = *allowCredentialProcess
+	awsclients.DefaultRateLimiter = awsclients.NewRateLimiter(*requestsPerSecond, *requestBurst)
+	awsclients.DefaultRetryOptions = awsclients.RetryOptions{
+		MaxAttempts:        *maxAttempts,
+		MaxBackoff:         *maxBackoff,
+		MaxThrottleBackoff: *maxThrottleBackoff,
+	}
 
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{SyncPeriod: syncPeriod})
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		SyncPeriod:              syncPeriod,
+		LeaderElection:          *leaderElection,
+		LeaderElectionNamespace: *leaderElectionNamespace,
+		LeaderElectionID:        *leaderElectionID,
+		MetricsBindAddress:      *metricsAddress,
+		HealthProbeBindAddress:  *probeAddress,
+		Namespace:               *namespace,
+	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
+	kingpin.FatalIfError(mgr.AddHealthzCheck("ping", healthz.Ping), "Cannot add health check")
+	kingpin.FatalIfError(mgr.AddReadyzCheck("ping", healthz.Ping), "Cannot add readiness check")
+
+	o := config.Options{
+		MaxConcurrentReconciles: *maxReconciles,
+		EnabledKinds:            *enabledKinds,
+		DisabledKinds:           *disabledKinds,
+	}
+	if *labelSelector != "" {
+		o.LabelSelector, err = labels.Parse(*labelSelector)
+		kingpin.FatalIfError(err, "Cannot parse label selector")
+	}
 
 	kingpin.FatalIfError(crossplaneapis.AddToScheme(mgr.GetScheme()), "Cannot add core Crossplane APIs to scheme")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add AWS APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log), "Cannot setup AWS controllers")
+	kingpin.FatalIfError(controller.Setup(mgr, log, o), "Cannot setup AWS controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 
 }
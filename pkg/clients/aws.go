@@ -19,8 +19,9 @@ package aws
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
-	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"strconv"
@@ -28,15 +29,24 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/aws/aws-sdk-go-v2/aws/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/go-ini/ini"
 	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 )
 
 // DefaultSection for INI files.
 const DefaultSection = ini.DefaultSection
 
+const (
+	errGetCallerIdentity    = "cannot get caller identity"
+	errFmtAccountNotAllowed = "AWS account %q is not one of the provider's allowed account IDs"
+	errFmtAccountForbidden  = "AWS account %q is one of the provider's forbidden account IDs"
+)
+
 // A FieldOption determines how common Go types are translated to the types
 // required by the AWS Go SDK.
 type FieldOption int
@@ -83,68 +93,184 @@ func CredentialsIDSecret(data []byte, profile string) (string, string, error) {
 // AuthMethod is a method of authenticating to the AWS API
 type AuthMethod func(context.Context, []byte, string, string) (*aws.Config, error)
 
-// UseProviderSecret - AWS configuration which can be used to issue requests against AWS API
+// UseProviderSecret - AWS configuration which can be used to issue requests
+// against AWS API. The supplied data must be an AWS shared config or
+// credentials file; credentials are resolved from the supplied profile as
+// described by SharedConfigCredentials. Requests made using the config are
+// instrumented by InstrumentHandlers, planned by PlanRequests, and are rate
+// limited and retried per the DefaultRateLimiter and DefaultRetryOptions.
 func UseProviderSecret(_ context.Context, data []byte, profile, region string) (*aws.Config, error) {
-	id, secret, err := CredentialsIDSecret(data, profile)
+	config, err := external.LoadDefaultAWSConfig(external.SharedConfig{Region: region})
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to parse credentials")
+		return nil, err
 	}
 
-	creds := aws.Credentials{
-		AccessKeyID:     id,
-		SecretAccessKey: secret,
-	}
+	InstrumentHandlers(&config.Handlers)
+	PlanRequests(&config.Handlers)
+	Throttle(&config, DefaultRateLimiter, DefaultRetryOptions)
 
-	shared := external.SharedConfig{
-		Credentials: creds,
-		Region:      region,
+	creds, err := SharedConfigCredentials(config, data, profile)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse credentials")
 	}
-
-	config, err := external.LoadDefaultAWSConfig(shared)
-	return &config, err
+	config.Credentials = creds
+	return &config, nil
 }
 
 // UsePodServiceAccount assumes an IAM role configured via a ServiceAccount.
 // https://docs.aws.amazon.com/eks/latest/userguide/iam-roles-for-service-accounts.html
 //
-// TODO(hasheddan): This should be replaced by the implementation of the Web
-// Identity Token Provider in the following PR after merge and subsequent
-// release of AWS SDK: https://github.com/aws/aws-sdk-go-v2/pull/488
-func UsePodServiceAccount(ctx context.Context, _ []byte, _, region string) (*aws.Config, error) {
+// The returned config's credentials are refreshed by assuming the role again
+// using the pod's web identity token before they expire. Requests made using
+// the config are instrumented by InstrumentHandlers, planned by PlanRequests,
+// and are rate limited and retried per the DefaultRateLimiter and
+// DefaultRetryOptions.
+func UsePodServiceAccount(_ context.Context, _ []byte, _, region string) (*aws.Config, error) {
 	cfg, err := external.LoadDefaultAWSConfig()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load default AWS config")
 	}
 	cfg.Region = region
-	svc := sts.New(cfg)
+	InstrumentHandlers(&cfg.Handlers)
+	PlanRequests(&cfg.Handlers)
+	Throttle(&cfg, DefaultRateLimiter, DefaultRetryOptions)
+	cfg.Credentials = stscreds.NewWebIdentityRoleProvider(
+		sts.New(cfg),
+		os.Getenv("AWS_ROLE_ARN"),
+		strconv.FormatInt(time.Now().UnixNano(), 10),
+		stscreds.IdentityTokenFile(os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")),
+	)
+	return &cfg, nil
+}
 
-	b, err := ioutil.ReadFile(os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"))
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to read web identity token file in pod")
-	}
-	token := string(b)
-	sess := strconv.FormatInt(time.Now().UnixNano(), 10)
-	role := os.Getenv("AWS_ROLE_ARN")
-	resp, err := svc.AssumeRoleWithWebIdentityRequest(
-		&sts.AssumeRoleWithWebIdentityInput{
-			RoleSessionName:  &sess,
-			WebIdentityToken: &token,
-			RoleArn:          &role,
-		}).Send(ctx)
+// WithProviderSpec returns an AuthMethod that uses the supplied AuthMethod to
+// produce an *aws.Config, then layers the configuration of the supplied
+// ProviderSpec, such as endpoint overrides and an IAM role to assume, on top
+// of it.
+func WithProviderSpec(spec v1alpha3.ProviderSpec, auth AuthMethod) AuthMethod {
+	return func(ctx context.Context, data []byte, profile, region string) (*aws.Config, error) {
+		cfg, err := auth(ctx, data, profile, region)
+		if err != nil {
+			return nil, err
+		}
+		// Endpoints are overridden first so that any roles are assumed using
+		// the overridden STS endpoint.
+		cfg = AssumeRole(OverrideEndpoints(cfg, spec.Endpoint), spec.AssumeRole)
+		if len(spec.AllowedAccountIDs) == 0 && len(spec.ForbiddenAccountIDs) == 0 {
+			return cfg, nil
+		}
+		// The account is checked after any roles are assumed, because that is
+		// the account managed resources will be reconciled in.
+		if err := CheckAccountID(ctx, cfg, spec.AllowedAccountIDs, spec.ForbiddenAccountIDs); err != nil {
+			return nil, err
+		}
+		return cfg, nil
+	}
+}
+
+// CheckAccountID returns an error if the AWS account that the credentials of
+// the supplied *aws.Config belong to is not one of the supplied allowed
+// accounts, or is one of the supplied forbidden accounts. Any account is
+// allowed if no allowed accounts are supplied.
+func CheckAccountID(ctx context.Context, cfg *aws.Config, allowed, forbidden []string) error {
+	rsp, err := sts.New(*cfg).GetCallerIdentityRequest(&sts.GetCallerIdentityInput{}).Send(ctx)
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, errGetCallerIdentity)
 	}
-	creds := aws.Credentials{
-		AccessKeyID:     aws.StringValue(resp.Credentials.AccessKeyId),
-		SecretAccessKey: aws.StringValue(resp.Credentials.SecretAccessKey),
-		SessionToken:    aws.StringValue(resp.Credentials.SessionToken),
+	id := aws.StringValue(rsp.Account)
+	if len(allowed) > 0 && !containsString(allowed, id) {
+		return errors.Errorf(errFmtAccountNotAllowed, id)
 	}
-	shared := external.SharedConfig{
-		Credentials: creds,
-		Region:      region,
+	if containsString(forbidden, id) {
+		return errors.Errorf(errFmtAccountForbidden, id)
 	}
-	config, err := external.LoadDefaultAWSConfig(shared)
-	return &config, err
+	return nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// OverrideEndpoints returns a copy of the supplied *aws.Config that resolves
+// AWS service endpoints using the supplied EndpointConfig, falling back to the
+// supplied config's resolver for any service that is not overridden. The
+// supplied *aws.Config is returned unchanged if no EndpointConfig is supplied.
+func OverrideEndpoints(cfg *aws.Config, e *v1alpha3.EndpointConfig) *aws.Config {
+	if cfg == nil || e == nil {
+		return cfg
+	}
+	c := cfg.Copy()
+	fallback := c.EndpointResolver
+	c.EndpointResolver = aws.EndpointResolverFunc(func(service, region string) (aws.Endpoint, error) {
+		u, ok := e.Services[service]
+		if !ok {
+			u = aws.StringValue(e.URL)
+		}
+		if u == "" && fallback != nil {
+			return fallback.ResolveEndpoint(service, region)
+		}
+		return aws.Endpoint{URL: u, SigningRegion: region}, nil
+	})
+	if aws.BoolValue(e.InsecureSkipTLSVerify) {
+		b, ok := c.HTTPClient.(*aws.BuildableHTTPClient)
+		if !ok {
+			b = aws.NewBuildableHTTPClient()
+		}
+		c.HTTPClient = b.WithTransportOptions(func(t *http.Transport) {
+			t.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // nolint:gosec
+		})
+	}
+
+	// Service clients are built from a copy of the config, so we record the
+	// EndpointConfig as a config source in order for clients that need them
+	// to read service specific options such as S3ForcePathStyle.
+	c.ConfigSources = append(append([]interface{}{}, c.ConfigSources...), *e)
+	return &c
+}
+
+// S3ForcePathStyle returns true if the supplied config was produced by
+// OverrideEndpoints with an EndpointConfig that forces S3 path-style
+// addressing.
+func S3ForcePathStyle(cfg aws.Config) bool {
+	for _, s := range cfg.ConfigSources {
+		if e, ok := s.(v1alpha3.EndpointConfig); ok {
+			return aws.BoolValue(e.S3ForcePathStyle)
+		}
+	}
+	return false
+}
+
+// AssumeRole returns a copy of the supplied *aws.Config whose credentials are
+// obtained by using STS AssumeRole to assume the supplied IAM role, followed
+// by each role in its chain. The supplied *aws.Config is returned unchanged if
+// no role is supplied.
+func AssumeRole(cfg *aws.Config, o *v1alpha3.AssumeRoleOptions) *aws.Config {
+	if cfg == nil || o == nil {
+		return cfg
+	}
+	c := cfg.Copy()
+	c.Credentials = newAssumeRoleProvider(c, o, o.RoleARN, o.ExternalID)
+	for _, r := range o.RoleChain {
+		c.Credentials = newAssumeRoleProvider(c, o, r.RoleARN, r.ExternalID)
+	}
+	return &c
+}
+
+// newAssumeRoleProvider returns a provider that assumes the supplied role
+// using the credentials of the supplied config.
+func newAssumeRoleProvider(cfg aws.Config, o *v1alpha3.AssumeRoleOptions, roleARN string, externalID *string) aws.CredentialsProvider {
+	return stscreds.NewAssumeRoleProvider(sts.New(cfg), roleARN, func(p *stscreds.AssumeRoleProviderOptions) {
+		p.ExternalID = externalID
+		p.RoleSessionName = aws.StringValue(o.SessionName)
+		if o.Duration != nil {
+			p.Duration = o.Duration.Duration
+		}
+	})
 }
 
 // TODO(muvaf): All the types that use CreateJSONPatch are known during
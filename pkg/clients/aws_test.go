@@ -19,9 +19,19 @@ package aws
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/stscreds"
+	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 )
 
 const (
@@ -62,3 +72,141 @@ func TestUseProviderSecret(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(config).NotTo(BeNil())
 }
+
+func TestAssumeRole(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cfg := &aws.Config{Region: "us-west-2", Credentials: aws.NewStaticCredentialsProvider("id", "secret", "")}
+
+	// no role to assume
+	g.Expect(AssumeRole(cfg, nil)).To(BeIdenticalTo(cfg))
+
+	// role chain
+	o := &v1alpha3.AssumeRoleOptions{
+		RoleARN:   "arn:aws:iam::123456789012:role/first",
+		RoleChain: []v1alpha3.ChainedRole{{RoleARN: "arn:aws:iam::210987654321:role/second"}},
+	}
+	assumed := AssumeRole(cfg, o)
+	g.Expect(assumed).NotTo(BeIdenticalTo(cfg))
+	g.Expect(assumed.Region).To(Equal(cfg.Region))
+	g.Expect(assumed.Credentials).To(BeAssignableToTypeOf(&stscreds.AssumeRoleProvider{}))
+	g.Expect(cfg.Credentials).To(BeAssignableToTypeOf(aws.StaticCredentialsProvider{}))
+}
+
+func TestWithProviderSpec(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	testProfile := "default"
+	testRegion := "us-west-2"
+	credentials := []byte(fmt.Sprintf(awsCredentialsFileFormat, testProfile, "testID", "testSecret"))
+
+	spec := v1alpha3.ProviderSpec{
+		AssumeRole: &v1alpha3.AssumeRoleOptions{RoleARN: "arn:aws:iam::123456789012:role/example"},
+	}
+	config, err := WithProviderSpec(spec, UseProviderSecret)(context.TODO(), credentials, testProfile, testRegion)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Region).To(Equal(testRegion))
+	g.Expect(config.Credentials).To(BeAssignableToTypeOf(&stscreds.AssumeRoleProvider{}))
+
+	// errors from the underlying auth method are returned
+	_, err = WithProviderSpec(spec, UseProviderSecret)(context.TODO(), credentials, "foo", testRegion)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestOverrideEndpoints(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	testRegion := "us-west-2"
+	cfg, err := UseProviderSecret(context.TODO(), []byte(fmt.Sprintf(awsCredentialsFileFormat, "default", "testID", "testSecret")), "default", testRegion)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// no endpoint overrides
+	g.Expect(OverrideEndpoints(cfg, nil)).To(BeIdenticalTo(cfg))
+	g.Expect(S3ForcePathStyle(*cfg)).To(BeFalse())
+
+	e := &v1alpha3.EndpointConfig{
+		URL:                   aws.String("http://localstack:4566"),
+		Services:              map[string]string{"s3": "http://minio:9000"},
+		S3ForcePathStyle:      aws.Bool(true),
+		InsecureSkipTLSVerify: aws.Bool(true),
+	}
+	overridden := OverrideEndpoints(cfg, e)
+	g.Expect(S3ForcePathStyle(*overridden)).To(BeTrue())
+	g.Expect(overridden.HTTPClient).NotTo(BeIdenticalTo(cfg.HTTPClient))
+
+	ep, err := overridden.EndpointResolver.ResolveEndpoint("ec2", testRegion)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ep).To(Equal(aws.Endpoint{URL: "http://localstack:4566", SigningRegion: testRegion}))
+
+	ep, err = overridden.EndpointResolver.ResolveEndpoint("s3", testRegion)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ep).To(Equal(aws.Endpoint{URL: "http://minio:9000", SigningRegion: testRegion}))
+
+	// services that are not overridden fall back to the default resolver
+	overridden = OverrideEndpoints(cfg, &v1alpha3.EndpointConfig{Services: map[string]string{"s3": "http://minio:9000"}})
+	ep, err = overridden.EndpointResolver.ResolveEndpoint("ec2", testRegion)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ep.URL).To(Equal("https://ec2.us-west-2.amazonaws.com"))
+}
+
+func TestCheckAccountID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>arn:aws:iam::123456789012:user/cool</Arn>
+    <UserId>AIDACOOL</UserId>
+    <Account>123456789012</Account>
+  </GetCallerIdentityResult>
+  <ResponseMetadata><RequestId>cool</RequestId></ResponseMetadata>
+</GetCallerIdentityResponse>`))
+	}))
+	defer srv.Close()
+
+	cfg, err := UseProviderSecret(context.TODO(), []byte(fmt.Sprintf(awsCredentialsFileFormat, "default", "testID", "testSecret")), "default", "us-west-2")
+	if err != nil {
+		t.Fatalf("UseProviderSecret(...): %s", err)
+	}
+	cfg = OverrideEndpoints(cfg, &v1alpha3.EndpointConfig{URL: aws.String(srv.URL)})
+
+	type args struct {
+		allowed   []string
+		forbidden []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"NoRestrictions": {
+			reason: "Any account should be allowed if no accounts are allowed or forbidden.",
+		},
+		"Allowed": {
+			reason: "An allowed account should be allowed.",
+			args:   args{allowed: []string{"210987654321", "123456789012"}},
+		},
+		"NotAllowed": {
+			reason: "An account that is not allowed should not be allowed.",
+			args:   args{allowed: []string{"210987654321"}},
+			want:   errors.Errorf(errFmtAccountNotAllowed, "123456789012"),
+		},
+		"NotForbidden": {
+			reason: "An account that is not forbidden should be allowed.",
+			args:   args{forbidden: []string{"210987654321"}},
+		},
+		"Forbidden": {
+			reason: "A forbidden account should not be allowed.",
+			args:   args{forbidden: []string{"123456789012"}},
+			want:   errors.Errorf(errFmtAccountForbidden, "123456789012"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := CheckAccountID(context.TODO(), cfg, tc.args.allowed, tc.args.forbidden)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCheckAccountID(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
+)
+
+const (
+	errRetrieveCredentials = "cannot retrieve AWS credentials"
+
+	// DefaultConfigExpiryWindow is the default amount of time before their
+	// credentials expire at which cached configs are discarded.
+	DefaultConfigExpiryWindow = 5 * time.Minute
+)
+
+// DefaultConfigCache is the ConfigCache used by ProviderAuthMethod.
+var DefaultConfigCache = NewConfigCache(DefaultConfigExpiryWindow)
+
+// A ConfigCache caches the *aws.Config produced for a Provider so that it
+// may be reused by every controller that connects using that Provider. Cached
+// configs are keyed by the UID of their Provider and the profile and region
+// they were produced for, and are discarded when the spec of the Provider or
+// the resource version of its credentials Secret changes, when their
+// credentials are about to expire, or when they are invalidated.
+type ConfigCache struct {
+	expiryWindow time.Duration
+	now          func() time.Time
+
+	mu      sync.Mutex
+	entries map[configCacheKey]configCacheEntry
+}
+
+type configCacheKey struct {
+	uid     types.UID
+	profile string
+	region  string
+}
+
+type configCacheEntry struct {
+	version string
+	config  aws.Config
+	expires time.Time
+}
+
+// NewConfigCache returns a ConfigCache that discards configs once their
+// credentials will expire within the supplied window.
+func NewConfigCache(expiryWindow time.Duration) *ConfigCache {
+	return &ConfigCache{
+		expiryWindow: expiryWindow,
+		now:          time.Now,
+		entries:      map[configCacheKey]configCacheEntry{},
+	}
+}
+
+// AuthMethod returns an AuthMethod that returns a copy of the *aws.Config
+// cached for the supplied Provider and its credentials Secret, if any.
+// Otherwise it produces an *aws.Config using the supplied AuthMethod and
+// caches it. Configs are not cached for Providers without a UID.
+func (c *ConfigCache) AuthMethod(p *v1alpha3.Provider, s *corev1.Secret, auth AuthMethod) AuthMethod {
+	return func(ctx context.Context, data []byte, profile, region string) (*aws.Config, error) {
+		if p.GetUID() == "" {
+			return auth(ctx, data, profile, region)
+		}
+
+		sv := ""
+		if s != nil {
+			sv = s.GetResourceVersion()
+		}
+		// We version on the Provider's generation rather than its resource
+		// version so that updates to its status don't discard the config.
+		k := configCacheKey{uid: p.GetUID(), profile: profile, region: region}
+		version := strings.Join([]string{strconv.FormatInt(p.GetGeneration(), 10), sv}, "/")
+		if cfg, ok := c.get(k, version); ok {
+			return cfg, nil
+		}
+
+		cfg, err := auth(ctx, data, profile, region)
+		if err != nil {
+			return nil, err
+		}
+
+		// We retrieve credentials before caching the config in order to
+		// learn when they will expire. This also ensures we don't cache a
+		// config with credentials that can't be retrieved.
+		creds, err := cfg.Credentials.Retrieve(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, errRetrieveCredentials)
+		}
+		e := configCacheEntry{version: version, config: cfg.Copy()}
+		if creds.CanExpire {
+			e.expires = creds.Expires
+		}
+
+		c.mu.Lock()
+		c.entries[k] = e
+		c.mu.Unlock()
+
+		return cfg, nil
+	}
+}
+
+// Invalidate discards the configs cached for the Provider with the supplied
+// UID, if any.
+func (c *ConfigCache) Invalidate(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if k.uid == uid {
+			delete(c.entries, k)
+		}
+	}
+}
+
+func (c *ConfigCache) get(k configCacheKey, version string) (*aws.Config, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[k]
+	if !ok {
+		return nil, false
+	}
+	if e.version != version || (!e.expires.IsZero() && c.now().Add(c.expiryWindow).After(e.expires)) {
+		delete(c.entries, k)
+		return nil, false
+	}
+
+	// Callers may modify the config they're returned, so we always return a
+	// copy of the cached config.
+	cfg := e.config.Copy()
+	return &cfg, true
+}
+
+// ProviderAuthMethod returns an AuthMethod for the supplied Provider and its
+// credentials Secret, which may be nil if the Provider does not use one. The
+// returned AuthMethod layers the Provider's spec over the supplied AuthMethod,
+// and caches the resulting *aws.Config in the DefaultConfigCache.
+func ProviderAuthMethod(p *v1alpha3.Provider, s *corev1.Secret, auth AuthMethod) AuthMethod {
+	return DefaultConfigCache.AuthMethod(p, s, WithProviderSpec(p.Spec, auth))
+}
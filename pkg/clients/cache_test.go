@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
+)
+
+func TestConfigCache(t *testing.T) {
+	now := time.Now()
+	errBoom := errors.New("boom")
+
+	provider := func(uid string, generation int64) *v1alpha3.Provider {
+		return &v1alpha3.Provider{ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid), Generation: generation}}
+	}
+	secret := func(version string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{ResourceVersion: version}}
+	}
+	creds := func(expires time.Time) aws.CredentialsProvider {
+		return aws.StaticCredentialsProvider{Value: aws.Credentials{
+			AccessKeyID:     "id",
+			SecretAccessKey: "secret",
+			CanExpire:       !expires.IsZero(),
+			Expires:         expires,
+		}}
+	}
+
+	type call struct {
+		p *v1alpha3.Provider
+		s *corev1.Secret
+
+		// region defaults to us-east-1.
+		region string
+
+		// invalidate the Provider's configs before the call.
+		invalidate bool
+	}
+	type want struct {
+		err   error
+		calls int
+	}
+
+	cases := map[string]struct {
+		reason string
+		creds  aws.CredentialsProvider
+		authFn AuthMethod
+		calls  []call
+		want   want
+	}{
+		"Reused": {
+			reason: "A cached config should be reused while neither the Provider nor its Secret change.",
+			creds:  creds(time.Time{}),
+			calls:  []call{{p: provider("uid", 1), s: secret("1")}, {p: provider("uid", 1), s: secret("1")}},
+			want:   want{calls: 1},
+		},
+		"ProviderChanged": {
+			reason: "A cached config should be discarded when its Provider changes.",
+			creds:  creds(time.Time{}),
+			calls:  []call{{p: provider("uid", 1), s: secret("1")}, {p: provider("uid", 2), s: secret("1")}},
+			want:   want{calls: 2},
+		},
+		"ProviderStatusChanged": {
+			reason: "A cached config should be reused when only the status of its Provider changes.",
+			creds:  creds(time.Time{}),
+			calls: []call{
+				{p: &v1alpha3.Provider{ObjectMeta: metav1.ObjectMeta{UID: "uid", Generation: 1, ResourceVersion: "1"}}},
+				{p: &v1alpha3.Provider{ObjectMeta: metav1.ObjectMeta{UID: "uid", Generation: 1, ResourceVersion: "2"}}},
+			},
+			want: want{calls: 1},
+		},
+		"SecretChanged": {
+			reason: "A cached config should be discarded when its Provider's Secret changes.",
+			creds:  creds(time.Time{}),
+			calls:  []call{{p: provider("uid", 1), s: secret("1")}, {p: provider("uid", 1), s: secret("2")}},
+			want:   want{calls: 2},
+		},
+		"RegionChanged": {
+			reason: "Configs produced for different regions should be cached separately.",
+			creds:  creds(time.Time{}),
+			calls: []call{
+				{p: provider("uid", 1), s: secret("1")},
+				{p: provider("uid", 1), s: secret("1"), region: "us-west-2"},
+				{p: provider("uid", 1), s: secret("1")},
+				{p: provider("uid", 1), s: secret("1"), region: "us-west-2"},
+			},
+			want: want{calls: 2},
+		},
+		"Invalidated": {
+			reason: "Configs should be discarded when their Provider is invalidated.",
+			creds:  creds(time.Time{}),
+			calls: []call{
+				{p: provider("uid", 1), s: secret("1")},
+				{p: provider("uid", 1), s: secret("1"), region: "us-west-2"},
+				{p: provider("uid", 1), s: secret("1"), invalidate: true},
+				{p: provider("uid", 1), s: secret("1"), region: "us-west-2"},
+			},
+			want: want{calls: 4},
+		},
+		"NoSecret": {
+			reason: "A config should be cached for Providers that don't use a Secret.",
+			creds:  creds(time.Time{}),
+			calls:  []call{{p: provider("uid", 1)}, {p: provider("uid", 1)}},
+			want:   want{calls: 1},
+		},
+		"AboutToExpire": {
+			reason: "A cached config should be discarded when its credentials are about to expire.",
+			creds:  creds(now.Add(DefaultConfigExpiryWindow / 2)),
+			calls:  []call{{p: provider("uid", 1)}, {p: provider("uid", 1)}},
+			want:   want{calls: 2},
+		},
+		"NotAboutToExpire": {
+			reason: "A cached config should be reused while its credentials are not about to expire.",
+			creds:  creds(now.Add(DefaultConfigExpiryWindow * 2)),
+			calls:  []call{{p: provider("uid", 1)}, {p: provider("uid", 1)}},
+			want:   want{calls: 1},
+		},
+		"NoUID": {
+			reason: "A config should not be cached for a Provider without a UID.",
+			creds:  creds(time.Time{}),
+			calls:  []call{{p: &v1alpha3.Provider{}}, {p: &v1alpha3.Provider{}}},
+			want:   want{calls: 2},
+		},
+		"AuthError": {
+			reason: "Errors from the underlying AuthMethod should be returned.",
+			authFn: func(_ context.Context, _ []byte, _, _ string) (*aws.Config, error) { return nil, errBoom },
+			calls:  []call{{p: provider("uid", 1)}},
+			want:   want{err: errBoom},
+		},
+		"CredentialsError": {
+			reason: "Configs whose credentials can't be retrieved should not be cached.",
+			creds:  aws.StaticCredentialsProvider{},
+			calls:  []call{{p: provider("uid", 1)}},
+			want:   want{err: errors.Wrap(&aws.StaticCredentialsEmptyError{}, errRetrieveCredentials), calls: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			auth := tc.authFn
+			if auth == nil {
+				auth = func(_ context.Context, _ []byte, _, region string) (*aws.Config, error) {
+					calls++
+					return &aws.Config{Region: region, Credentials: tc.creds}, nil
+				}
+			}
+
+			c := NewConfigCache(DefaultConfigExpiryWindow)
+			c.now = func() time.Time { return now }
+
+			var err error
+			for _, call := range tc.calls {
+				region := call.region
+				if region == "" {
+					region = "us-east-1"
+				}
+				if call.invalidate {
+					c.Invalidate(call.p.GetUID())
+				}
+				var cfg *aws.Config
+				cfg, err = c.AuthMethod(call.p, call.s, auth)(context.Background(), nil, DefaultSection, region)
+				if err != nil {
+					break
+				}
+				if diff := cmp.Diff(region, cfg.Region); diff != "" {
+					t.Errorf("\n%s\nc.AuthMethod(...): -want region, +got region:\n%s", tc.reason, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.AuthMethod(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nc.AuthMethod(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
+)
+
+const (
+	errGetProvider          = "cannot get provider"
+	errNoProviderSecret     = "provider does not have a secret reference"
+	errGetProviderSecret    = "cannot get provider secret"
+	errServiceAccountConfig = "cannot create new AWS configuration using IAM roles for ServiceAccount"
+	errSecretConfig         = "cannot create new AWS configuration"
+	errNewExternalClient    = "cannot create new external client"
+)
+
+// GetConfig returns an *aws.Config for the referenced Provider. The config
+// authenticates using either the Provider's ServiceAccount or its credentials
+// Secret, and honours the rest of the Provider's spec.
+func GetConfig(ctx context.Context, c client.Reader, ref *corev1.ObjectReference) (*aws.Config, error) {
+	p := &v1alpha3.Provider{}
+	if err := c.Get(ctx, meta.NamespacedNameOf(ref), p); err != nil {
+		return nil, errors.Wrap(err, errGetProvider)
+	}
+
+	if aws.BoolValue(p.Spec.UseServiceAccount) {
+		cfg, err := ProviderAuthMethod(p, nil, UsePodServiceAccount)(ctx, []byte{}, DefaultSection, p.Spec.Region)
+		return cfg, errors.Wrap(err, errServiceAccountConfig)
+	}
+
+	if p.GetCredentialsSecretReference() == nil {
+		return nil, errors.New(errNoProviderSecret)
+	}
+
+	s := &corev1.Secret{}
+	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
+	if err := c.Get(ctx, n, s); err != nil {
+		return nil, errors.Wrap(err, errGetProviderSecret)
+	}
+
+	profile := DefaultSection
+	if p.Spec.Profile != nil {
+		profile = *p.Spec.Profile
+	}
+	cfg, err := ProviderAuthMethod(p, s, UseProviderSecret)(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], profile, p.Spec.Region)
+	return cfg, errors.Wrap(err, errSecretConfig)
+}
+
+// An ExternalClientFn returns a managed.ExternalClient that uses the supplied
+// *aws.Config to connect to AWS.
+type ExternalClientFn func(cfg *aws.Config) (managed.ExternalClient, error)
+
+// A Connector is a managed.ExternalConnecter that produces an ExternalClient
+// for any managed resource by resolving its Provider into an *aws.Config.
+type Connector struct {
+	kube        client.Reader
+	newClientFn ExternalClientFn
+}
+
+// NewConnector returns a Connector that uses the supplied ExternalClientFn to
+// produce an ExternalClient once the managed resource's Provider has been
+// resolved into an *aws.Config.
+func NewConnector(kube client.Reader, fn ExternalClientFn) *Connector {
+	return &Connector{kube: kube, newClientFn: fn}
+}
+
+// Connect resolves the Provider of the supplied managed resource into an
+// *aws.Config and uses it to produce an ExternalClient. The ExternalClient of
+// an observe-only managed resource never changes its external resource, while
+// that of a managed resource in dry-run mode reports how it would change it.
+// The ExternalClient of a paused managed resource does nothing.
+func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if IsPaused(mg) {
+		return NewPausedExternal(), nil
+	}
+	Resume(mg)
+
+	cfg, err := GetConfig(ctx, c.kube, mg.GetProviderReference())
+	if err != nil {
+		return nil, err
+	}
+	e, err := c.newClientFn(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewExternalClient)
+	}
+	if IsDryRun(mg) {
+		e = DryRun(e)
+	}
+	if IsObserveOnly(mg) {
+		return ObserveOnly(e), nil
+	}
+	return e, nil
+}
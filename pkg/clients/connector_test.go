@@ -0,0 +1,197 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
+)
+
+const (
+	testProviderName    = "cool-provider"
+	testSecretNamespace = "cool-namespace"
+	testSecretName      = "cool-secret"
+	testSecretKey       = "credentials"
+	testRegion          = "us-east-1"
+	testCredentials     = `[default]
+aws_access_key_id = cool-id
+aws_secret_access_key = cool-secret`
+)
+
+func testProvider(withSecretRef bool) v1alpha3.Provider {
+	p := v1alpha3.Provider{Spec: v1alpha3.ProviderSpec{Region: testRegion}}
+	if withSecretRef {
+		p.Spec.CredentialsSecretRef = &runtimev1alpha1.SecretKeySelector{
+			SecretReference: runtimev1alpha1.SecretReference{Namespace: testSecretNamespace, Name: testSecretName},
+			Key:             testSecretKey,
+		}
+	}
+	return p
+}
+
+func testKube(p *v1alpha3.Provider, s *corev1.Secret) client.Reader {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+			switch {
+			case p != nil && key == client.ObjectKey{Name: testProviderName}:
+				p.DeepCopyInto(obj.(*v1alpha3.Provider))
+				return nil
+			case s != nil && key == client.ObjectKey{Namespace: testSecretNamespace, Name: testSecretName}:
+				s.DeepCopyInto(obj.(*corev1.Secret))
+				return nil
+			}
+			return errBoom
+		},
+	}
+}
+
+var errBoom = errors.New("boom")
+
+func TestGetConfig(t *testing.T) {
+	withSecretRef := testProvider(true)
+	withoutSecretRef := testProvider(false)
+	secret := &corev1.Secret{Data: map[string][]byte{testSecretKey: []byte(testCredentials)}}
+
+	type want struct {
+		region string
+		creds  aws.Credentials
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		want   want
+	}{
+		"GetProviderError": {
+			reason: "Errors getting the Provider should be returned.",
+			kube:   testKube(nil, nil),
+			want:   want{err: errors.Wrap(errBoom, errGetProvider)},
+		},
+		"NoSecretReference": {
+			reason: "An error should be returned if the Provider does not reference a credentials Secret.",
+			kube:   testKube(&withoutSecretRef, nil),
+			want:   want{err: errors.New(errNoProviderSecret)},
+		},
+		"GetSecretError": {
+			reason: "Errors getting the Provider's credentials Secret should be returned.",
+			kube:   testKube(&withSecretRef, nil),
+			want:   want{err: errors.Wrap(errBoom, errGetProviderSecret)},
+		},
+		"Successful": {
+			reason: "A config using the credentials and region of the Provider should be returned.",
+			kube:   testKube(&withSecretRef, secret),
+			want: want{
+				region: testRegion,
+				creds:  aws.Credentials{AccessKeyID: "cool-id", SecretAccessKey: "cool-secret", Source: aws.StaticCredentialsProviderName},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := GetConfig(context.Background(), tc.kube, &corev1.ObjectReference{Name: testProviderName})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetConfig(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.region, cfg.Region); diff != "" {
+				t.Errorf("\n%s\nGetConfig(...): -want region, +got region:\n%s", tc.reason, diff)
+			}
+			creds, err := cfg.Credentials.Retrieve(context.Background())
+			if err != nil {
+				t.Fatalf("\n%s\ncfg.Credentials.Retrieve(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.creds, creds); diff != "" {
+				t.Errorf("\n%s\nGetConfig(...): -want credentials, +got credentials:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type testExternal struct {
+	managed.ExternalClient
+	cfg *aws.Config
+}
+
+func TestConnector(t *testing.T) {
+	withSecretRef := testProvider(true)
+	secret := &corev1.Secret{Data: map[string][]byte{testSecretKey: []byte(testCredentials)}}
+
+	mg := &fake.Managed{ProviderReferencer: fake.ProviderReferencer{Ref: &corev1.ObjectReference{Name: testProviderName}}}
+
+	type want struct {
+		region string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		fn     ExternalClientFn
+		want   want
+	}{
+		"GetConfigError": {
+			reason: "Errors resolving the managed resource's Provider should be returned.",
+			kube:   testKube(nil, nil),
+			want:   want{err: errors.Wrap(errBoom, errGetProvider)},
+		},
+		"NewClientError": {
+			reason: "Errors producing an ExternalClient should be returned.",
+			kube:   testKube(&withSecretRef, secret),
+			fn:     func(_ *aws.Config) (managed.ExternalClient, error) { return nil, errBoom },
+			want:   want{err: errors.Wrap(errBoom, errNewExternalClient)},
+		},
+		"Successful": {
+			reason: "An ExternalClient using the config of the managed resource's Provider should be returned.",
+			kube:   testKube(&withSecretRef, secret),
+			fn:     func(cfg *aws.Config) (managed.ExternalClient, error) { return &testExternal{cfg: cfg}, nil },
+			want:   want{region: testRegion},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, err := NewConnector(tc.kube, tc.fn).Connect(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.Connect(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.region, e.(*testExternal).cfg.Region); diff != "" {
+				t.Errorf("\n%s\nc.Connect(...): -want region, +got region:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
+)
+
+const (
+	// DriftPathPrefix is the path of the parameters of a managed resource's
+	// spec that are checked for drift.
+	DriftPathPrefix = "spec.forProvider"
+
+	// redacted replaces the values of sensitive fields.
+	redacted = "(redacted)"
+
+	// maxDriftMessageFields is the maximum number of drifted fields described
+	// by a drift event.
+	maxDriftMessageFields = 10
+
+	reasonDetectedDrift event.Reason = "DetectedDrift"
+)
+
+// sensitiveFields are substrings of the (lower case) names of fields whose
+// values must never be reported.
+var sensitiveFields = []string{"password", "secret", "token", "credential"}
+
+// NewFieldDrift returns a FieldDrift describing the field at the supplied path,
+// redacting its observed and desired values if the field is sensitive.
+func NewFieldDrift(path string, observed, desired interface{}) v1alpha3.FieldDrift {
+	d := v1alpha3.FieldDrift{Path: path, Observed: encode(observed), Desired: encode(desired)}
+	if isSensitive(path) {
+		d.Observed, d.Desired = redact(d.Observed), redact(d.Desired)
+	}
+	return d
+}
+
+// DiffPatch returns the fields of the supplied patch that differ from the
+// zero value when compared using the supplied options. The patch is expected
+// to be a pointer to parameters produced by computing a JSON merge patch from
+// the supplied observed parameters to the desired ones, in which case these
+// fields are exactly those that would make an up-to-date check that compares
+// the patch to its zero value fail. Lists, maps, and optional objects are
+// reported as a whole.
+func DiffPatch(observed, patch interface{}, opts ...cmp.Option) []v1alpha3.FieldDrift {
+	r := &driftReporter{observed: reflect.ValueOf(observed), seen: map[string]bool{}}
+	zero := reflect.New(reflect.TypeOf(patch).Elem()).Interface()
+	cmp.Equal(zero, patch, append(opts, cmp.Reporter(r))...)
+	return r.drift
+}
+
+type driftReporter struct {
+	observed reflect.Value
+	path     cmp.Path
+	seen     map[string]bool
+	drift    []v1alpha3.FieldDrift
+}
+
+func (r *driftReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *driftReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *driftReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	path, observed, desired := r.resolve()
+	if r.seen[path] {
+		return
+	}
+	r.seen[path] = true
+	r.drift = append(r.drift, NewFieldDrift(path, valueOf(observed), valueOf(desired)))
+}
+
+// resolve the current path into a field path, and the observed and desired
+// values at that path. Paths are resolved no deeper than the first list or
+// map they traverse.
+func (r *driftReporter) resolve() (string, reflect.Value, reflect.Value) {
+	segments := []string{DriftPathPrefix}
+	observed := r.observed
+	_, desired := r.path.Index(0).Values()
+
+	for i := 1; i < len(r.path); i++ {
+		switch s := r.path.Index(i).(type) {
+		case cmp.Indirect:
+			if !observed.IsValid() || observed.IsNil() {
+				observed = reflect.Value{}
+			} else {
+				observed = observed.Elem()
+			}
+		case cmp.StructField:
+			if name := jsonName(r.path.Index(i-1).Type(), s.Name()); name != "" {
+				segments = append(segments, name)
+			}
+			if observed.IsValid() {
+				observed = observed.FieldByName(s.Name())
+			}
+		default:
+			// Lists, maps, and anything else we don't know how to traverse
+			// are reported as a whole.
+			return strings.Join(segments, "."), observed, desired
+		}
+		_, desired = r.path.Index(i).Values()
+	}
+	return strings.Join(segments, "."), observed, desired
+}
+
+// jsonName returns the name of the supplied field of the supplied struct type
+// when encoded as JSON, or an empty string if the field is inlined.
+func jsonName(t reflect.Type, field string) string {
+	f, ok := t.FieldByName(field)
+	if !ok {
+		return field
+	}
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	switch {
+	case name == "" && f.Anonymous:
+		return ""
+	case name == "":
+		return field
+	}
+	return name
+}
+
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func encode(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		if rv.IsNil() {
+			return ""
+		}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+func isSensitive(path string) bool {
+	p := strings.ToLower(path)
+	for _, s := range sensitiveFields {
+		if strings.Contains(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(v string) string {
+	if v == "" {
+		return ""
+	}
+	return redacted
+}
+
+// A DriftReporter reports how the external resource of a managed resource
+// differs from the desired state described by its spec.
+type DriftReporter interface {
+	GetDrift() []v1alpha3.FieldDrift
+	SetDrift(d []v1alpha3.FieldDrift)
+}
+
+// ReportDrift records the supplied drift using the supplied DriftReporter,
+// which is typically the status of the supplied object. An event is emitted
+// for the object when its drift changes, unless it is no longer drifted.
+func ReportDrift(rec event.Recorder, o runtime.Object, r DriftReporter, d []v1alpha3.FieldDrift) {
+	changed := !reflect.DeepEqual(r.GetDrift(), d)
+	r.SetDrift(d)
+	if !changed || len(d) == 0 {
+		return
+	}
+	rec.Event(o, event.Normal(reasonDetectedDrift, DescribeDrift(d)))
+}
+
+// DescribeDrift returns a human readable description of the supplied drift.
+func DescribeDrift(d []v1alpha3.FieldDrift) string {
+	fields := make([]string, 0, len(d))
+	for i, f := range d {
+		if i == maxDriftMessageFields {
+			fields = append(fields, fmt.Sprintf("and %d more", len(d)-i))
+			break
+		}
+		fields = append(fields, fmt.Sprintf("%s (observed %s, desired %s)", f.Path, orNone(f.Observed), orNone(f.Desired)))
+	}
+	return "External resource differs from the desired state: " + strings.Join(fields, ", ")
+}
+
+func orNone(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
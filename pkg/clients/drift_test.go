@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
+)
+
+type testNested struct {
+	Size *int64 `json:"size,omitempty"`
+}
+
+type TestInline struct {
+	Zone *string `json:"zone,omitempty"`
+}
+
+type testParameters struct {
+	TestInline     `json:",inline"`
+	Name           *string           `json:"name,omitempty"`
+	Nested         *testNested       `json:"nested,omitempty"`
+	List           []string          `json:"list,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	MasterPassword *string           `json:"masterPassword,omitempty"`
+	Ignored        *string           `json:"ignored,omitempty"`
+}
+
+func TestDiffPatch(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		observed *testParameters
+		patch    *testParameters
+		want     []v1alpha3.FieldDrift
+	}{
+		"NoDrift": {
+			reason:   "An empty patch should not be reported as drift.",
+			observed: &testParameters{Name: String("cool")},
+			patch:    &testParameters{},
+		},
+		"Fields": {
+			reason:   "Fields set in the patch should be reported using their JSON paths. Nested objects should be reported as a whole.",
+			observed: &testParameters{Name: String("cool"), Nested: &testNested{Size: Int64(1)}, TestInline: TestInline{Zone: String("a")}},
+			patch:    &testParameters{Name: String("cooler"), Nested: &testNested{Size: Int64(2)}, TestInline: TestInline{Zone: String("b")}},
+			want: []v1alpha3.FieldDrift{
+				{Path: "spec.forProvider.zone", Observed: `"a"`, Desired: `"b"`},
+				{Path: "spec.forProvider.name", Observed: `"cool"`, Desired: `"cooler"`},
+				{Path: "spec.forProvider.nested", Observed: `{"size":1}`, Desired: `{"size":2}`},
+			},
+		},
+		"Missing": {
+			reason:   "Fields that were not observed should be reported without an observed value.",
+			observed: &testParameters{},
+			patch:    &testParameters{Name: String("cool")},
+			want: []v1alpha3.FieldDrift{
+				{Path: "spec.forProvider.name", Desired: `"cool"`},
+			},
+		},
+		"ListsAndMaps": {
+			reason:   "Lists and maps should be reported as a whole.",
+			observed: &testParameters{List: []string{"a"}, Tags: map[string]string{"k": "v"}},
+			patch:    &testParameters{List: []string{"a", "b", "c"}, Tags: map[string]string{"k": "w", "l": "x"}},
+			want: []v1alpha3.FieldDrift{
+				{Path: "spec.forProvider.list", Observed: `["a"]`, Desired: `["a","b","c"]`},
+				{Path: "spec.forProvider.tags", Observed: `{"k":"v"}`, Desired: `{"k":"w","l":"x"}`},
+			},
+		},
+		"Sensitive": {
+			reason:   "The values of sensitive fields should be redacted.",
+			observed: &testParameters{MasterPassword: String("hunter2")},
+			patch:    &testParameters{MasterPassword: String("hunter3")},
+			want: []v1alpha3.FieldDrift{
+				{Path: "spec.forProvider.masterPassword", Observed: redacted, Desired: redacted},
+			},
+		},
+		"Ignored": {
+			reason:   "Fields ignored by the supplied options should not be reported.",
+			observed: &testParameters{},
+			patch:    &testParameters{Ignored: String("cool")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DiffPatch(tc.observed, tc.patch, cmpopts.IgnoreFields(testParameters{}, "Ignored"))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiffPatch(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type testRecorder struct {
+	events []event.Event
+}
+
+func (r *testRecorder) Event(_ runtime.Object, e event.Event) { r.events = append(r.events, e) }
+
+func (r *testRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func TestReportDrift(t *testing.T) {
+	drift := []v1alpha3.FieldDrift{{Path: "spec.forProvider.name", Observed: `"cool"`, Desired: `"cooler"`}}
+
+	cases := map[string]struct {
+		reason    string
+		current   []v1alpha3.FieldDrift
+		drift     []v1alpha3.FieldDrift
+		wantEvent bool
+	}{
+		"NewDrift": {
+			reason:    "An event should be emitted when drift is first detected.",
+			drift:     drift,
+			wantEvent: true,
+		},
+		"SameDrift": {
+			reason:  "No event should be emitted when the drift has not changed.",
+			current: drift,
+			drift:   drift,
+		},
+		"NoDrift": {
+			reason:  "No event should be emitted when the drift is resolved.",
+			current: drift,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &testRecorder{}
+			s := &v1alpha3.DriftStatus{Drift: tc.current}
+			ReportDrift(rec, &fake.Managed{}, s, tc.drift)
+			if diff := cmp.Diff(tc.drift, s.Drift); diff != "" {
+				t.Errorf("\n%s\nReportDrift(...): -want drift, +got drift:\n%s", tc.reason, diff)
+			}
+			if got := len(rec.events) > 0; got != tc.wantEvent {
+				t.Errorf("\n%s\nReportDrift(...): want event %t, got %t", tc.reason, tc.wantEvent, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyDryRun is the key of the annotation that puts a managed
+// resource in dry-run mode when its value is "true". The AWS API calls that
+// would create, update or delete the external resource of a managed resource
+// in dry-run mode are reported by its DryRun condition instead of being made.
+const AnnotationKeyDryRun = "aws.crossplane.io/dry-run"
+
+const (
+	dryRunHandlerName = "crossplane.provider-aws.DryRun"
+
+	errDryRun = "request was not sent because the managed resource is in dry-run mode"
+)
+
+// Error codes returned by EC2 in response to requests with DryRun set.
+const (
+	errCodeDryRunOperation       = "DryRunOperation"
+	errCodeUnauthorizedOperation = "UnauthorizedOperation"
+)
+
+// readOnlyOperationPrefixes are the prefixes of the names of AWS API
+// operations that never change anything, and are thus made in dry-run mode.
+var readOnlyOperationPrefixes = []string{"Describe", "Get", "List", "Head"}
+
+// TypeDryRun resources are in dry-run mode.
+const TypeDryRun runtimev1alpha1.ConditionType = "DryRun"
+
+// Reasons a resource in dry-run mode does or does not have pending changes.
+const (
+	ReasonPendingChanges   runtimev1alpha1.ConditionReason = "External resource has pending changes"
+	ReasonNoPendingChanges runtimev1alpha1.ConditionReason = "External resource has no pending changes"
+)
+
+// PendingChanges returns a condition indicating that the supplied AWS API
+// calls would be made to change the external resource of a managed resource
+// were it not in dry-run mode.
+func PendingChanges(calls []PlannedCall) runtimev1alpha1.Condition {
+	msgs := make([]string, len(calls))
+	for i := range calls {
+		msgs[i] = calls[i].String()
+	}
+	return runtimev1alpha1.Condition{
+		Type:               TypeDryRun,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPendingChanges,
+		Message:            strings.Join(msgs, "; "),
+	}
+}
+
+// NoPendingChanges returns a condition indicating that no AWS API calls would
+// be made to change the external resource of a managed resource were it not
+// in dry-run mode.
+func NoPendingChanges() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeDryRun,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoPendingChanges,
+	}
+}
+
+// IsDryRun returns true if the supplied managed resource is in dry-run mode.
+func IsDryRun(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyDryRun] == "true"
+}
+
+// A PlannedCall is an AWS API call that would have been made were a managed
+// resource not in dry-run mode.
+type PlannedCall struct {
+	// Service that would have been called, e.g. EC2.
+	Service string
+
+	// Operation that would have been called, e.g. CreateRoute.
+	Operation string
+
+	// Input of the operation, with any sensitive fields redacted.
+	Input string
+
+	// Permitted is true if AWS confirmed that the call would be permitted,
+	// false if AWS confirmed that it would not, and nil if AWS does not
+	// support checking.
+	Permitted *bool
+}
+
+func (c PlannedCall) String() string {
+	permitted := ""
+	switch {
+	case c.Permitted == nil:
+	case *c.Permitted:
+		permitted = " (permitted)"
+	default:
+		permitted = " (not permitted)"
+	}
+	return fmt.Sprintf("would call %s %s%s with %s", c.Service, c.Operation, permitted, c.Input)
+}
+
+// A plan records the AWS API calls made using a context returned by
+// withPlan, rather than making them.
+type plan struct {
+	// stop the external client from making further calls by failing the
+	// first planned call. Calls that create or delete an external resource
+	// usually return information that subsequent calls depend upon, so we
+	// cannot pretend they succeeded.
+	stop bool
+
+	mu    sync.Mutex
+	calls []PlannedCall
+}
+
+func (p *plan) record(c PlannedCall) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, c)
+	return len(p.calls) - 1
+}
+
+func (p *plan) permit(i int, permitted bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls[i].Permitted = &permitted
+}
+
+func (p *plan) Calls() []PlannedCall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedCall(nil), p.calls...)
+}
+
+type planKey struct{}
+
+func withPlan(ctx context.Context, p *plan) context.Context {
+	return context.WithValue(ctx, planKey{}, p)
+}
+
+func planFrom(ctx context.Context) *plan {
+	p, _ := ctx.Value(planKey{}).(*plan)
+	return p
+}
+
+// PlanRequests configures the supplied handlers to record, rather than send,
+// AWS API requests that would change an external resource when they are made
+// by an ExternalClient returned by DryRun. EC2 requests are sent with DryRun
+// set in order to check whether they would be permitted. Other requests
+// appear to succeed with an empty response, unless the ExternalClient is
+// creating or deleting an external resource, in which case they fail.
+func PlanRequests(h *aws.Handlers) {
+	h.Validate.SetFrontNamed(aws.NamedHandler{Name: dryRunHandlerName, Fn: planRequest})
+}
+
+func planRequest(r *aws.Request) {
+	p := planFrom(r.Context())
+	if p == nil || isReadOnly(r) {
+		return
+	}
+
+	i := p.record(PlannedCall{Service: r.Metadata.ServiceID, Operation: r.Operation.Name, Input: describeInput(r.Params)})
+
+	if setDryRun(r.Params) {
+		r.Handlers.UnmarshalError.PushBack(func(r *aws.Request) {
+			switch code(r.Error) {
+			case errCodeDryRunOperation:
+				p.permit(i, true)
+				r.Error = nil
+				if p.stop {
+					r.Error = errors.New(errDryRun)
+				}
+			case errCodeUnauthorizedOperation:
+				p.permit(i, false)
+			}
+		})
+		return
+	}
+
+	if p.stop {
+		r.Error = errors.New(errDryRun)
+		return
+	}
+
+	// Skip sending the request and handling its response, leaving its (empty)
+	// output as is.
+	r.Handlers.Sign.Clear()
+	r.Handlers.Send.Clear()
+	r.Handlers.UnmarshalMeta.Clear()
+	r.Handlers.ValidateResponse.Clear()
+	r.Handlers.Unmarshal.Clear()
+	r.Handlers.CompleteAttempt.Clear()
+}
+
+func isReadOnly(r *aws.Request) bool {
+	// Credentials are retrieved from STS using the context of the request
+	// they will sign.
+	if r.Metadata.ServiceID == "STS" {
+		return true
+	}
+	for _, p := range readOnlyOperationPrefixes {
+		if strings.HasPrefix(r.Operation.Name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func code(err error) string {
+	if ae, ok := err.(awserr.Error); ok {
+		return ae.Code()
+	}
+	return ""
+}
+
+// setDryRun sets the DryRun field of the supplied input, if it has one.
+func setDryRun(input interface{}) bool {
+	v := reflect.ValueOf(input)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	f := v.Elem().FieldByName("DryRun")
+	if !f.IsValid() || !f.CanSet() || f.Type() != reflect.TypeOf(aws.Bool(true)) {
+		return false
+	}
+	f.Set(reflect.ValueOf(aws.Bool(true)))
+	return true
+}
+
+// describeInput returns a single line description of the supplied input with
+// the values of any sensitive fields redacted.
+func describeInput(input interface{}) string {
+	v := reflect.ValueOf(input)
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		cp := reflect.New(v.Elem().Type())
+		cp.Elem().Set(v.Elem())
+		t := cp.Elem().Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := cp.Elem().Field(i)
+			if !isSensitive(t.Field(i).Name) || f.Type() != reflect.TypeOf(aws.String("")) || f.IsNil() || !f.CanSet() {
+				continue
+			}
+			f.Set(reflect.ValueOf(aws.String(redacted)))
+		}
+		input = cp.Interface()
+	}
+	return strings.Join(strings.Fields(fmt.Sprintf("%v", input)), " ")
+}
+
+// DryRun wraps the supplied ExternalClient such that the AWS API calls it
+// would make to create, update or delete an external resource are reported by
+// the DryRun condition rather than made. The ExternalClient must use a config
+// whose handlers were configured by PlanRequests.
+func DryRun(e managed.ExternalClient) managed.ExternalClient {
+	return &dryRunExternal{ExternalClient: e}
+}
+
+type dryRunExternal struct {
+	managed.ExternalClient
+}
+
+func (e *dryRunExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err == nil && o.ResourceExists && o.ResourceUpToDate {
+		mg.SetConditions(NoPendingChanges())
+	}
+	return o, err
+}
+
+func (e *dryRunExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	p := &plan{stop: true}
+	_, err := e.ExternalClient.Create(withPlan(ctx, p), mg)
+	return managed.ExternalCreation{}, e.report(mg, p, err)
+}
+
+func (e *dryRunExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	p := &plan{}
+	_, err := e.ExternalClient.Update(withPlan(ctx, p), mg)
+	return managed.ExternalUpdate{}, e.report(mg, p, err)
+}
+
+func (e *dryRunExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	p := &plan{stop: true}
+	err := e.ExternalClient.Delete(withPlan(ctx, p), mg)
+	return e.report(mg, p, err)
+}
+
+// report the calls recorded by the supplied plan, and the supplied error
+// unless it was caused by the plan stopping the ExternalClient.
+func (e *dryRunExternal) report(mg resource.Managed, p *plan, err error) error {
+	if calls := p.Calls(); len(calls) > 0 {
+		mg.SetConditions(PendingChanges(calls))
+	} else {
+		mg.SetConditions(NoPendingChanges())
+	}
+	if err != nil && errors.Cause(err).Error() == errDryRun {
+		return nil
+	}
+	return err
+}
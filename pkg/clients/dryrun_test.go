@@ -0,0 +1,255 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// fakeAPI sends requests using handlers configured by PlanRequests, responding
+// to any request that is actually sent with the supplied error code.
+type fakeAPI struct {
+	code string
+	sent []string
+}
+
+func (a *fakeAPI) send(ctx context.Context, service, operation string, params interface{}) error {
+	h := aws.Handlers{}
+	PlanRequests(&h)
+	h.Send.PushBack(func(r *aws.Request) { a.sent = append(a.sent, r.Operation.Name) })
+	h.ValidateResponse.PushBack(func(r *aws.Request) {
+		if a.code != "" {
+			r.Error = awserr.New(a.code, "", nil)
+		}
+	})
+	cfg := aws.Config{EndpointResolver: aws.ResolveWithEndpointURL("https://example.org")}
+	r := aws.New(cfg, aws.Metadata{ServiceID: service}, h, aws.NoOpRetryer{}, &aws.Operation{Name: operation}, params, nil)
+	r.SetContext(ctx)
+	return r.Send()
+}
+
+func TestPlanRequest(t *testing.T) {
+	type args struct {
+		plan      *plan
+		service   string
+		operation string
+		params    interface{}
+	}
+	type want struct {
+		calls []PlannedCall
+		sent  []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		code   string
+		args   args
+		want   want
+	}{
+		"NotPlanned": {
+			reason: "Requests made without a plan should be sent.",
+			args:   args{service: "RDS", operation: "ModifyDBInstance", params: &rds.ModifyDBInstanceInput{}},
+			want:   want{sent: []string{"ModifyDBInstance"}},
+		},
+		"ReadOnly": {
+			reason: "Read-only requests should be sent even when planned.",
+			args:   args{plan: &plan{stop: true}, service: "RDS", operation: "DescribeDBInstances", params: &rds.DescribeDBInstancesInput{}},
+			want:   want{sent: []string{"DescribeDBInstances"}},
+		},
+		"Update": {
+			reason: "Planned requests should be recorded with their sensitive fields redacted, and appear to succeed without being sent.",
+			args: args{plan: &plan{}, service: "RDS", operation: "ModifyDBInstance", params: &rds.ModifyDBInstanceInput{
+				DBInstanceIdentifier: aws.String("cool"),
+				MasterUserPassword:   aws.String("hunter2"),
+			}},
+			want: want{calls: []PlannedCall{{
+				Service:   "RDS",
+				Operation: "ModifyDBInstance",
+				Input:     `{ DBInstanceIdentifier: "cool", MasterUserPassword: "(redacted)" }`,
+			}}},
+		},
+		"Stop": {
+			reason: "Planned requests should be recorded and fail without being sent when the plan stops at the first request.",
+			args:   args{plan: &plan{stop: true}, service: "RDS", operation: "DeleteDBInstance", params: &rds.DeleteDBInstanceInput{DBInstanceIdentifier: aws.String("cool")}},
+			want: want{
+				calls: []PlannedCall{{Service: "RDS", Operation: "DeleteDBInstance", Input: `{ DBInstanceIdentifier: "cool" }`}},
+				err:   errors.New(errDryRun),
+			},
+		},
+		"EC2Permitted": {
+			reason: "Planned requests that support DryRun should be sent with DryRun set, and succeed if they would be permitted.",
+			code:   errCodeDryRunOperation,
+			args:   args{plan: &plan{}, service: "EC2", operation: "CreateRoute", params: &ec2.CreateRouteInput{RouteTableId: aws.String("rtb-1")}},
+			want: want{
+				calls: []PlannedCall{{Service: "EC2", Operation: "CreateRoute", Input: `{ RouteTableId: "rtb-1" }`, Permitted: aws.Bool(true)}},
+				sent:  []string{"CreateRoute"},
+			},
+		},
+		"EC2PermittedStop": {
+			reason: "Planned requests that would be permitted should fail when the plan stops at the first request.",
+			code:   errCodeDryRunOperation,
+			args:   args{plan: &plan{stop: true}, service: "EC2", operation: "DeleteVpc", params: &ec2.DeleteVpcInput{VpcId: aws.String("vpc-1")}},
+			want: want{
+				calls: []PlannedCall{{Service: "EC2", Operation: "DeleteVpc", Input: `{ VpcId: "vpc-1" }`, Permitted: aws.Bool(true)}},
+				sent:  []string{"DeleteVpc"},
+				err:   errors.New(errDryRun),
+			},
+		},
+		"EC2Unauthorized": {
+			reason: "Planned requests that would not be permitted should be recorded as such, and fail.",
+			code:   errCodeUnauthorizedOperation,
+			args:   args{plan: &plan{}, service: "EC2", operation: "CreateRoute", params: &ec2.CreateRouteInput{RouteTableId: aws.String("rtb-1")}},
+			want: want{
+				calls: []PlannedCall{{Service: "EC2", Operation: "CreateRoute", Input: `{ RouteTableId: "rtb-1" }`, Permitted: aws.Bool(false)}},
+				sent:  []string{"CreateRoute"},
+				err:   awserr.New(errCodeUnauthorizedOperation, "", nil),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := &fakeAPI{code: tc.code}
+			ctx := context.Background()
+			if tc.args.plan != nil {
+				ctx = withPlan(ctx, tc.args.plan)
+			}
+			err := a.send(ctx, tc.args.service, tc.args.operation, tc.args.params)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSend(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, a.sent); diff != "" {
+				t.Errorf("\n%s\nSend(...): -want sent, +got sent:\n%s", tc.reason, diff)
+			}
+			var calls []PlannedCall
+			if tc.args.plan != nil {
+				calls = tc.args.plan.Calls()
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nSend(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	api := &fakeAPI{}
+	modify := func(ctx context.Context) error {
+		return api.send(ctx, "RDS", "ModifyDBInstance", &rds.ModifyDBInstanceInput{DBInstanceIdentifier: aws.String("cool")})
+	}
+	call := PlannedCall{Service: "RDS", Operation: "ModifyDBInstance", Input: `{ DBInstanceIdentifier: "cool" }`}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		op     func(e managed.ExternalClient, mg resource.Managed) error
+		want   error
+		cond   runtimev1alpha1.Condition
+	}{
+		"UpToDate": {
+			reason: "An external resource that is up to date should have no pending changes.",
+			e: &managed.ExternalClientFns{ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+			}},
+			op: func(e managed.ExternalClient, mg resource.Managed) error {
+				_, err := e.Observe(context.Background(), mg)
+				return err
+			},
+			cond: NoPendingChanges(),
+		},
+		"Create": {
+			reason: "The first call made to create an external resource should be reported, and creation should appear to succeed.",
+			e: &managed.ExternalClientFns{CreateFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+				return managed.ExternalCreation{}, modify(ctx)
+			}},
+			op: func(e managed.ExternalClient, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			cond: PendingChanges([]PlannedCall{call}),
+		},
+		"Update": {
+			reason: "Every call made to update an external resource should be reported.",
+			e: &managed.ExternalClientFns{UpdateFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+				if err := modify(ctx); err != nil {
+					return managed.ExternalUpdate{}, err
+				}
+				return managed.ExternalUpdate{}, modify(ctx)
+			}},
+			op: func(e managed.ExternalClient, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+			cond: PendingChanges([]PlannedCall{call, call}),
+		},
+		"UpdateError": {
+			reason: "Errors updating an external resource should be returned.",
+			e: &managed.ExternalClientFns{UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+				return managed.ExternalUpdate{}, errBoom
+			}},
+			op: func(e managed.ExternalClient, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+			want: errBoom,
+			cond: NoPendingChanges(),
+		},
+		"Delete": {
+			reason: "The first call made to delete an external resource should be reported, and deletion should appear to succeed.",
+			e: &managed.ExternalClientFns{DeleteFn: func(ctx context.Context, _ resource.Managed) error {
+				return errors.Wrap(modify(ctx), "cannot delete")
+			}},
+			op: func(e managed.ExternalClient, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+			cond: PendingChanges([]PlannedCall{call}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyDryRun: "true"}}}
+			err := tc.op(DryRun(tc.e), mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDryRun(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			want := runtimev1alpha1.ConditionedStatus{Conditions: []runtimev1alpha1.Condition{tc.cond}}
+			if diff := cmp.Diff(want, mg.ConditionedStatus, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nDryRun(...): -want conditions, +got conditions:\n%s", tc.reason, diff)
+			}
+			if len(api.sent) > 0 {
+				t.Errorf("\n%s\nDryRun(...): want no requests sent, got %v", tc.reason, api.sent)
+			}
+		})
+	}
+}
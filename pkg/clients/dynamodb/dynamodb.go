@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/crossplane/provider-aws/apis/database/v1alpha1"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 )
 
@@ -35,15 +36,14 @@ type Client interface {
 	CreateTableRequest(input *dynamodb.CreateTableInput) dynamodb.CreateTableRequest
 	DeleteTableRequest(input *dynamodb.DeleteTableInput) dynamodb.DeleteTableRequest
 	UpdateTableRequest(input *dynamodb.UpdateTableInput) dynamodb.UpdateTableRequest
+	ListTagsOfResourceRequest(input *dynamodb.ListTagsOfResourceInput) dynamodb.ListTagsOfResourceRequest
+	TagResourceRequest(input *dynamodb.TagResourceInput) dynamodb.TagResourceRequest
+	UntagResourceRequest(input *dynamodb.UntagResourceInput) dynamodb.UntagResourceRequest
 }
 
 // NewClient creates new DynamoDB Client with provided AWS Configurations/Credentials
-func NewClient(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (Client, error) {
-	cfg, err := auth(ctx, credentials, awsclients.DefaultSection, region)
-	if cfg == nil {
-		return nil, err
-	}
-	return dynamodb.New(*cfg), err
+func NewClient(cfg *aws.Config) Client {
+	return dynamodb.New(*cfg)
 }
 
 // LateInitialize fills the empty fields in *v1alpha1.DynamoTableParameters with
@@ -79,12 +79,15 @@ func LateInitialize(in *v1alpha1.DynamoTableParameters, t *dynamodb.TableDescrip
 
 // CreatePatch creates a *v1alpha1.DynamoTableParameters that has only the changed
 // values between the target *v1alpha1.DynamoTableParameters and the current
-// *dynamodb.TableDescription
-func CreatePatch(in *dynamodb.TableDescription, target *v1alpha1.DynamoTableParameters) (*v1alpha1.DynamoTableParameters, error) {
-	currentParams := &v1alpha1.DynamoTableParameters{}
-	LateInitialize(currentParams, in)
+// *dynamodb.TableDescription with the supplied tags. Tags are compared sorted
+// by key.
+func CreatePatch(in *dynamodb.TableDescription, tags []dynamodb.Tag, target *v1alpha1.DynamoTableParameters) (*v1alpha1.DynamoTableParameters, error) {
+	currentParams := observedParameters(in, tags)
+
+	desired := target.DeepCopy()
+	awsclients.SetTagMap(&desired.Tags, awsclients.TagMap(target.Tags))
 
-	jsonPatch, err := awsclients.CreateJSONPatch(currentParams, target)
+	jsonPatch, err := awsclients.CreateJSONPatch(currentParams, desired)
 	if err != nil {
 		return nil, err
 	}
@@ -219,14 +222,61 @@ func GenerateObservation(t dynamodb.TableDescription) v1alpha1.DynamoTableObserv
 	return o
 }
 
-// IsUpToDate checks whether there is a change in any of the modifiable fields.
-func IsUpToDate(p v1alpha1.DynamoTableParameters, t dynamodb.TableDescription) (bool, error) {
+// IsUpToDate checks whether there is a change in any of the modifiable fields
+// of the supplied table, which has the supplied tags.
+func IsUpToDate(p v1alpha1.DynamoTableParameters, t dynamodb.TableDescription, tags []dynamodb.Tag) (bool, error) {
 
-	patch, err := CreatePatch(&t, &p)
+	patch, err := CreatePatch(&t, tags, &p)
 	if err != nil {
 		return false, err
 	}
-	return cmp.Equal(&v1alpha1.DynamoTableParameters{}, patch), nil
+	// A patch doesn't record that every tag was removed, so that is checked
+	// separately.
+	_, remove := awsclients.DiffTags(awsclients.TagMap(p.Tags), awsclients.TagMap(tags))
+	return cmp.Equal(&v1alpha1.DynamoTableParameters{}, patch) && len(remove) == 0, nil
+}
+
+// Diff returns the modifiable fields of the supplied parameters that differ
+// from the supplied table, which has the supplied tags.
+func Diff(p v1alpha1.DynamoTableParameters, t dynamodb.TableDescription, tags []dynamodb.Tag) ([]v1alpha3.FieldDrift, error) {
+	patch, err := CreatePatch(&t, tags, &p)
+	if err != nil {
+		return nil, err
+	}
+	return awsclients.DiffPatch(observedParameters(&t, tags), patch), nil
+}
+
+// observedParameters returns the parameters of the supplied table, which has
+// the supplied tags. Tags are sorted by key, and those reserved by AWS are
+// omitted because they are never managed.
+func observedParameters(t *dynamodb.TableDescription, tags []dynamodb.Tag) *v1alpha1.DynamoTableParameters {
+	p := &v1alpha1.DynamoTableParameters{}
+	LateInitialize(p, t)
+	observed := awsclients.TagMap(tags)
+	for k := range observed {
+		if awsclients.IsReservedTag(k) {
+			delete(observed, k)
+		}
+	}
+	awsclients.SetTagMap(&p.Tags, observed)
+	return p
+}
+
+// ListTags returns the tags of the table with the supplied ARN.
+func ListTags(ctx context.Context, c Client, arn string) ([]dynamodb.Tag, error) {
+	input := &dynamodb.ListTagsOfResourceInput{ResourceArn: aws.String(arn)}
+	var tags []dynamodb.Tag
+	for {
+		rsp, err := c.ListTagsOfResourceRequest(input).Send(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, rsp.Tags...)
+		if rsp.NextToken == nil {
+			return tags, nil
+		}
+		input = &dynamodb.ListTagsOfResourceInput{ResourceArn: aws.String(arn), NextToken: rsp.NextToken}
+	}
 }
 
 // IsErrorNotFound helper function to test for ErrCodeTableNotFoundException error
@@ -82,8 +82,9 @@ func tableObservation(m ...func(*v1alpha1.DynamoTableObservation)) *v1alpha1.Dyn
 
 func TestCreatePatch(t *testing.T) {
 	type args struct {
-		t *dynamodb.TableDescription
-		p *v1alpha1.DynamoTableParameters
+		t    *dynamodb.TableDescription
+		tags []dynamodb.Tag
+		p    *v1alpha1.DynamoTableParameters
 	}
 
 	type want struct {
@@ -137,11 +138,41 @@ func TestCreatePatch(t *testing.T) {
 				},
 			},
 		},
+		"SameTags": {
+			args: args{
+				t: &dynamodb.TableDescription{},
+				tags: []dynamodb.Tag{
+					{Key: aws.String("b"), Value: aws.String("2")},
+					{Key: aws.String("aws:cloudformation:stack-name"), Value: aws.String("cool")},
+					{Key: aws.String("a"), Value: aws.String("1")},
+				},
+				p: &v1alpha1.DynamoTableParameters{
+					Tags: []v1alpha1.Tag{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}},
+				},
+			},
+			want: want{
+				patch: &v1alpha1.DynamoTableParameters{},
+			},
+		},
+		"DifferentTags": {
+			args: args{
+				t:    &dynamodb.TableDescription{},
+				tags: []dynamodb.Tag{{Key: aws.String("a"), Value: aws.String("old")}},
+				p: &v1alpha1.DynamoTableParameters{
+					Tags: []v1alpha1.Tag{{Key: "a", Value: "1"}},
+				},
+			},
+			want: want{
+				patch: &v1alpha1.DynamoTableParameters{
+					Tags: []v1alpha1.Tag{{Key: "a", Value: "1"}},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			result, _ := CreatePatch(tc.args.t, tc.args.p)
+			result, _ := CreatePatch(tc.args.t, tc.args.tags, tc.args.p)
 			if diff := cmp.Diff(tc.want.patch, result); diff != "" {
 				t.Errorf("r: -want, +got:\n%s", diff)
 			}
@@ -151,8 +182,9 @@ func TestCreatePatch(t *testing.T) {
 
 func TestIsUpToDate(t *testing.T) {
 	type args struct {
-		t dynamodb.TableDescription
-		p v1alpha1.DynamoTableParameters
+		t    dynamodb.TableDescription
+		tags []dynamodb.Tag
+		p    v1alpha1.DynamoTableParameters
 	}
 
 	cases := map[string]struct {
@@ -193,11 +225,26 @@ func TestIsUpToDate(t *testing.T) {
 			},
 			want: false,
 		},
+		"DifferentTags": {
+			args: args{
+				tags: []dynamodb.Tag{{Key: aws.String("a"), Value: aws.String("old")}},
+				p: v1alpha1.DynamoTableParameters{
+					Tags: []v1alpha1.Tag{{Key: "a", Value: "1"}},
+				},
+			},
+			want: false,
+		},
+		"RemovedTags": {
+			args: args{
+				tags: []dynamodb.Tag{{Key: aws.String("a"), Value: aws.String("1")}},
+			},
+			want: false,
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got, _ := IsUpToDate(tc.args.p, tc.args.t)
+			got, _ := IsUpToDate(tc.args.p, tc.args.t, tc.args.tags)
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("r: -want, +got:\n%s", diff)
 			}
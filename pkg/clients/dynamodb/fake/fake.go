@@ -26,6 +26,9 @@ type MockDynamoClient struct {
 	MockCreate   func(input *dynamodb.CreateTableInput) dynamodb.CreateTableRequest
 	MockDelete   func(input *dynamodb.DeleteTableInput) dynamodb.DeleteTableRequest
 	MockUpdate   func(input *dynamodb.UpdateTableInput) dynamodb.UpdateTableRequest
+	MockListTags func(input *dynamodb.ListTagsOfResourceInput) dynamodb.ListTagsOfResourceRequest
+	MockTag      func(input *dynamodb.TagResourceInput) dynamodb.TagResourceRequest
+	MockUntag    func(input *dynamodb.UntagResourceInput) dynamodb.UntagResourceRequest
 }
 
 // DescribeTableRequest finds DynamoDB Table by name
@@ -47,3 +50,18 @@ func (m *MockDynamoClient) DeleteTableRequest(i *dynamodb.DeleteTableInput) dyna
 func (m *MockDynamoClient) UpdateTableRequest(i *dynamodb.UpdateTableInput) dynamodb.UpdateTableRequest {
 	return m.MockUpdate(i)
 }
+
+// ListTagsOfResourceRequest lists the tags of a DynamoDB Table
+func (m *MockDynamoClient) ListTagsOfResourceRequest(i *dynamodb.ListTagsOfResourceInput) dynamodb.ListTagsOfResourceRequest {
+	return m.MockListTags(i)
+}
+
+// TagResourceRequest tags a DynamoDB Table
+func (m *MockDynamoClient) TagResourceRequest(i *dynamodb.TagResourceInput) dynamodb.TagResourceRequest {
+	return m.MockTag(i)
+}
+
+// UntagResourceRequest untags a DynamoDB Table
+func (m *MockDynamoClient) UntagResourceRequest(i *dynamodb.UntagResourceInput) dynamodb.UntagResourceRequest {
+	return m.MockUntag(i)
+}
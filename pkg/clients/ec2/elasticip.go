@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+const (
+	// AllocationIDNotFound is the code that is returned by ec2 when the given
+	// allocation ID is invalid
+	AllocationIDNotFound = "InvalidAllocationID.NotFound"
+
+	// AddressNotFound is the code that is returned by ec2 when the given
+	// public IP address is invalid
+	AddressNotFound = "InvalidAddress.NotFound"
+)
+
+// ElasticIPClient is the external client used for ElasticIP Custom Resource
+type ElasticIPClient interface {
+	AllocateAddressRequest(*ec2.AllocateAddressInput) ec2.AllocateAddressRequest
+	ReleaseAddressRequest(*ec2.ReleaseAddressInput) ec2.ReleaseAddressRequest
+	DescribeAddressesRequest(*ec2.DescribeAddressesInput) ec2.DescribeAddressesRequest
+	CreateTagsRequest(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	DeleteTagsRequest(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
+}
+
+// NewElasticIPClient returns a new client using the supplied AWS configuration.
+func NewElasticIPClient(cfg *aws.Config) ElasticIPClient {
+	return ec2.New(*cfg)
+}
+
+// IsAddressNotFoundErr returns true if the error is because the address
+// doesn't exist
+func IsAddressNotFoundErr(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case AllocationIDNotFound, AddressNotFound:
+			return true
+		}
+	}
+	return false
+}
+
+// IsStandardAddress returns true if the supplied parameters describe an
+// address in the standard (EC2-Classic) domain, which is identified by its
+// public IP address rather than an allocation ID.
+func IsStandardAddress(p v1alpha4.ElasticIPParameters) bool {
+	return aws.StringValue(p.Domain) == v1alpha4.ElasticIPDomainStandard
+}
+
+// GenerateDescribeAddressesInput returns the input to describe the address
+// with the supplied ID, which is a public IP address for addresses in the
+// standard domain and an allocation ID otherwise.
+func GenerateDescribeAddressesInput(id string, p v1alpha4.ElasticIPParameters) *ec2.DescribeAddressesInput {
+	if IsStandardAddress(p) {
+		return &ec2.DescribeAddressesInput{PublicIps: []string{id}}
+	}
+	return &ec2.DescribeAddressesInput{AllocationIds: []string{id}}
+}
+
+// GenerateReleaseAddressInput returns the input to release the address with
+// the supplied ID, which is a public IP address for addresses in the standard
+// domain and an allocation ID otherwise.
+func GenerateReleaseAddressInput(id string, p v1alpha4.ElasticIPParameters) *ec2.ReleaseAddressInput {
+	if IsStandardAddress(p) {
+		return &ec2.ReleaseAddressInput{PublicIp: aws.String(id)}
+	}
+	return &ec2.ReleaseAddressInput{AllocationId: aws.String(id), NetworkBorderGroup: p.NetworkBorderGroup}
+}
+
+// GenerateElasticIPObservation is used to produce v1alpha4.ElasticIPObservation
+// from ec2.Address.
+func GenerateElasticIPObservation(a ec2.Address) v1alpha4.ElasticIPObservation {
+	return v1alpha4.ElasticIPObservation{
+		AllocationID:            aws.StringValue(a.AllocationId),
+		PublicIP:                aws.StringValue(a.PublicIp),
+		Domain:                  string(a.Domain),
+		AssociationID:           aws.StringValue(a.AssociationId),
+		InstanceID:              aws.StringValue(a.InstanceId),
+		NetworkInterfaceID:      aws.StringValue(a.NetworkInterfaceId),
+		NetworkInterfaceOwnerID: aws.StringValue(a.NetworkInterfaceOwnerId),
+		PrivateIPAddress:        aws.StringValue(a.PrivateIpAddress),
+		PublicIPv4Pool:          aws.StringValue(a.PublicIpv4Pool),
+		NetworkBorderGroup:      aws.StringValue(a.NetworkBorderGroup),
+		Tags:                    observedTags(a.Tags),
+	}
+}
+
+// LateInitializeElasticIP fills the empty fields in
+// *v1alpha4.ElasticIPParameters with the values seen in ec2.Address.
+func LateInitializeElasticIP(in *v1alpha4.ElasticIPParameters, a *ec2.Address) {
+	if a == nil {
+		return
+	}
+	if a.Domain != "" {
+		in.Domain = awsclients.LateInitializeStringPtr(in.Domain, aws.String(string(a.Domain)))
+	}
+	in.PublicIPv4Pool = awsclients.LateInitializeStringPtr(in.PublicIPv4Pool, a.PublicIpv4Pool)
+	in.NetworkBorderGroup = awsclients.LateInitializeStringPtr(in.NetworkBorderGroup, a.NetworkBorderGroup)
+	if len(in.Tags) == 0 && len(a.Tags) != 0 {
+		in.Tags = v1beta1.BuildFromEC2Tags(a.Tags)
+	}
+}
+
+// IsElasticIPUpToDate checks whether there is a change in any of the
+// modifiable fields. Tags are the only modifiable field of an address, and
+// addresses in the standard domain cannot be tagged at all.
+func IsElasticIPUpToDate(p v1alpha4.ElasticIPParameters, a ec2.Address) bool {
+	if IsStandardAddress(p) {
+		return true
+	}
+	return tagsUpToDate(p.Tags, a.Tags)
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+)
+
+var (
+	eipAllocationID = "eipalloc-1"
+	eipPublicIP     = "198.51.100.1"
+)
+
+func TestGenerateDescribeAddressesInput(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		id     string
+		p      v1alpha4.ElasticIPParameters
+		want   *ec2.DescribeAddressesInput
+	}{
+		"VPC": {
+			reason: "Addresses in the vpc domain should be described by their allocation ID.",
+			id:     eipAllocationID,
+			p:      v1alpha4.ElasticIPParameters{Domain: aws.String(v1alpha4.ElasticIPDomainVPC)},
+			want:   &ec2.DescribeAddressesInput{AllocationIds: []string{eipAllocationID}},
+		},
+		"UnknownDomain": {
+			reason: "Addresses whose domain is not yet known should be described by their allocation ID.",
+			id:     eipAllocationID,
+			want:   &ec2.DescribeAddressesInput{AllocationIds: []string{eipAllocationID}},
+		},
+		"Standard": {
+			reason: "Addresses in the standard domain should be described by their public IP address.",
+			id:     eipPublicIP,
+			p:      v1alpha4.ElasticIPParameters{Domain: aws.String(v1alpha4.ElasticIPDomainStandard)},
+			want:   &ec2.DescribeAddressesInput{PublicIps: []string{eipPublicIP}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateDescribeAddressesInput(tc.id, tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateDescribeAddressesInput(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsElasticIPUpToDate(t *testing.T) {
+	type args struct {
+		p v1alpha4.ElasticIPParameters
+		a ec2.Address
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"SameTags": {
+			reason: "Addresses with the desired tags should be up to date.",
+			args: args{
+				p: v1alpha4.ElasticIPParameters{Tags: []v1beta1.Tag{{Key: "k", Value: "v"}}},
+				a: ec2.Address{Tags: []ec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}}},
+			},
+			want: true,
+		},
+		"DifferentTags": {
+			reason: "Addresses whose tags differ from the desired tags should not be up to date.",
+			args: args{
+				p: v1alpha4.ElasticIPParameters{Tags: []v1beta1.Tag{{Key: "k", Value: "v"}}},
+				a: ec2.Address{Tags: []ec2.Tag{{Key: aws.String("k"), Value: aws.String("other")}}},
+			},
+			want: false,
+		},
+		"StandardAddress": {
+			reason: "Addresses in the standard domain cannot be tagged, so their tags should be ignored.",
+			args: args{
+				p: v1alpha4.ElasticIPParameters{
+					Domain: aws.String(v1alpha4.ElasticIPDomainStandard),
+					Tags:   []v1beta1.Tag{{Key: "k", Value: "v"}},
+				},
+				a: ec2.Address{},
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsElasticIPUpToDate(tc.args.p, tc.args.a)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsElasticIPUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateElasticIPObservation(t *testing.T) {
+	cases := map[string]struct {
+		in  ec2.Address
+		out v1alpha4.ElasticIPObservation
+	}{
+		"AllFilled": {
+			in: ec2.Address{
+				AllocationId:       aws.String(eipAllocationID),
+				PublicIp:           aws.String(eipPublicIP),
+				Domain:             ec2.DomainTypeVpc,
+				AssociationId:      aws.String("eipassoc-1"),
+				NetworkInterfaceId: aws.String("eni-1"),
+				PrivateIpAddress:   aws.String("10.0.0.1"),
+				Tags:               []ec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+			},
+			out: v1alpha4.ElasticIPObservation{
+				AllocationID:       eipAllocationID,
+				PublicIP:           eipPublicIP,
+				Domain:             v1alpha4.ElasticIPDomainVPC,
+				AssociationID:      "eipassoc-1",
+				NetworkInterfaceID: "eni-1",
+				PrivateIPAddress:   "10.0.0.1",
+				Tags:               []v1beta1.Tag{{Key: "k", Value: "v"}},
+			},
+		},
+		"Unassociated": {
+			in: ec2.Address{
+				AllocationId: aws.String(eipAllocationID),
+				PublicIp:     aws.String(eipPublicIP),
+				Domain:       ec2.DomainTypeVpc,
+			},
+			out: v1alpha4.ElasticIPObservation{
+				AllocationID: eipAllocationID,
+				PublicIP:     eipPublicIP,
+				Domain:       v1alpha4.ElasticIPDomainVPC,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := GenerateElasticIPObservation(tc.in)
+			if diff := cmp.Diff(tc.out, r); diff != "" {
+				t.Errorf("GenerateElasticIPObservation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
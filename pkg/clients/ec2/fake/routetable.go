@@ -32,9 +32,11 @@ type MockRouteTableClient struct {
 	MockDescribe     func(*ec2.DescribeRouteTablesInput) ec2.DescribeRouteTablesRequest
 	MockCreateRoute  func(*ec2.CreateRouteInput) ec2.CreateRouteRequest
 	MockDeleteRoute  func(*ec2.DeleteRouteInput) ec2.DeleteRouteRequest
+	MockReplaceRoute func(*ec2.ReplaceRouteInput) ec2.ReplaceRouteRequest
 	MockAssociate    func(*ec2.AssociateRouteTableInput) ec2.AssociateRouteTableRequest
 	MockDisassociate func(*ec2.DisassociateRouteTableInput) ec2.DisassociateRouteTableRequest
 	MockCreateTags   func(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	MockDeleteTags   func(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
 }
 
 // CreateRouteTableRequest mocks CreateRouteTableRequest method
@@ -52,6 +54,11 @@ func (m *MockRouteTableClient) DescribeRouteTablesRequest(input *ec2.DescribeRou
 	return m.MockDescribe(input)
 }
 
+// ReplaceRouteRequest mocks ReplaceRouteRequest method
+func (m *MockRouteTableClient) ReplaceRouteRequest(input *ec2.ReplaceRouteInput) ec2.ReplaceRouteRequest {
+	return m.MockReplaceRoute(input)
+}
+
 // AssociateRouteTableRequest mocks AssociateRouteTableRequest method
 func (m *MockRouteTableClient) AssociateRouteTableRequest(input *ec2.AssociateRouteTableInput) ec2.AssociateRouteTableRequest {
 	return m.MockAssociate(input)
@@ -76,3 +83,8 @@ func (m *MockRouteTableClient) DeleteRouteRequest(input *ec2.DeleteRouteInput) e
 func (m *MockRouteTableClient) CreateTagsRequest(input *ec2.CreateTagsInput) ec2.CreateTagsRequest {
 	return m.MockCreateTags(input)
 }
+
+// DeleteTagsRequest mocks DeleteTagsInput method
+func (m *MockRouteTableClient) DeleteTagsRequest(input *ec2.DeleteTagsInput) ec2.DeleteTagsRequest {
+	return m.MockDeleteTags(input)
+}
@@ -32,7 +32,12 @@ type MockSecurityGroupClient struct {
 	MockDescribe        func(*ec2.DescribeSecurityGroupsInput) ec2.DescribeSecurityGroupsRequest
 	MockAuthorizeIgress func(*ec2.AuthorizeSecurityGroupIngressInput) ec2.AuthorizeSecurityGroupIngressRequest
 	MockAuthorizeEgress func(*ec2.AuthorizeSecurityGroupEgressInput) ec2.AuthorizeSecurityGroupEgressRequest
+	MockRevokeIngress   func(*ec2.RevokeSecurityGroupIngressInput) ec2.RevokeSecurityGroupIngressRequest
+	MockRevokeEgress    func(*ec2.RevokeSecurityGroupEgressInput) ec2.RevokeSecurityGroupEgressRequest
+	MockDescribeIngress func(*ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) ec2.UpdateSecurityGroupRuleDescriptionsIngressRequest
+	MockDescribeEgress  func(*ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) ec2.UpdateSecurityGroupRuleDescriptionsEgressRequest
 	MockCreateTags      func(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	MockDeleteTags      func(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
 }
 
 // CreateSecurityGroupRequest mocks CreateSecurityGroupRequest method
@@ -60,7 +65,32 @@ func (m *MockSecurityGroupClient) AuthorizeSecurityGroupEgressRequest(input *ec2
 	return m.MockAuthorizeEgress(input)
 }
 
+// RevokeSecurityGroupIngressRequest mocks RevokeSecurityGroupIngressRequest method
+func (m *MockSecurityGroupClient) RevokeSecurityGroupIngressRequest(input *ec2.RevokeSecurityGroupIngressInput) ec2.RevokeSecurityGroupIngressRequest {
+	return m.MockRevokeIngress(input)
+}
+
+// RevokeSecurityGroupEgressRequest mocks RevokeSecurityGroupEgressRequest method
+func (m *MockSecurityGroupClient) RevokeSecurityGroupEgressRequest(input *ec2.RevokeSecurityGroupEgressInput) ec2.RevokeSecurityGroupEgressRequest {
+	return m.MockRevokeEgress(input)
+}
+
+// UpdateSecurityGroupRuleDescriptionsIngressRequest mocks UpdateSecurityGroupRuleDescriptionsIngressRequest method
+func (m *MockSecurityGroupClient) UpdateSecurityGroupRuleDescriptionsIngressRequest(input *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) ec2.UpdateSecurityGroupRuleDescriptionsIngressRequest {
+	return m.MockDescribeIngress(input)
+}
+
+// UpdateSecurityGroupRuleDescriptionsEgressRequest mocks UpdateSecurityGroupRuleDescriptionsEgressRequest method
+func (m *MockSecurityGroupClient) UpdateSecurityGroupRuleDescriptionsEgressRequest(input *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) ec2.UpdateSecurityGroupRuleDescriptionsEgressRequest {
+	return m.MockDescribeEgress(input)
+}
+
 // CreateTagsRequest mocks CreateTagsInput method
 func (m *MockSecurityGroupClient) CreateTagsRequest(input *ec2.CreateTagsInput) ec2.CreateTagsRequest {
 	return m.MockCreateTags(input)
 }
+
+// DeleteTagsRequest mocks DeleteTagsInput method
+func (m *MockSecurityGroupClient) DeleteTagsRequest(input *ec2.DeleteTagsInput) ec2.DeleteTagsRequest {
+	return m.MockDeleteTags(input)
+}
@@ -33,7 +33,10 @@ type MockVPCClient struct {
 	MockModifyAttribute             func(*ec2.ModifyVpcAttributeInput) ec2.ModifyVpcAttributeRequest
 	MockModifyTenancy               func(*ec2.ModifyVpcTenancyInput) ec2.ModifyVpcTenancyRequest
 	MockCreateTagsRequest           func(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	MockDeleteTagsRequest           func(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
 	MockDescribeVpcAttributeRequest func(*ec2.DescribeVpcAttributeInput) ec2.DescribeVpcAttributeRequest
+	MockAssociateCIDRBlock          func(*ec2.AssociateVpcCidrBlockInput) ec2.AssociateVpcCidrBlockRequest
+	MockDisassociateCIDRBlock       func(*ec2.DisassociateVpcCidrBlockInput) ec2.DisassociateVpcCidrBlockRequest
 }
 
 // CreateVpcRequest mocks CreateVpcRequest method
@@ -66,7 +69,22 @@ func (m *MockVPCClient) CreateTagsRequest(input *ec2.CreateTagsInput) ec2.Create
 	return m.MockCreateTagsRequest(input)
 }
 
+// DeleteTagsRequest mocks DeleteTagsRequest method
+func (m *MockVPCClient) DeleteTagsRequest(input *ec2.DeleteTagsInput) ec2.DeleteTagsRequest {
+	return m.MockDeleteTagsRequest(input)
+}
+
 // DescribeVpcAttributeRequest mocks DescribeVpcAttributeRequest method
 func (m *MockVPCClient) DescribeVpcAttributeRequest(input *ec2.DescribeVpcAttributeInput) ec2.DescribeVpcAttributeRequest {
 	return m.MockDescribeVpcAttributeRequest(input)
 }
+
+// AssociateVpcCidrBlockRequest mocks AssociateVpcCidrBlockRequest method
+func (m *MockVPCClient) AssociateVpcCidrBlockRequest(input *ec2.AssociateVpcCidrBlockInput) ec2.AssociateVpcCidrBlockRequest {
+	return m.MockAssociateCIDRBlock(input)
+}
+
+// DisassociateVpcCidrBlockRequest mocks DisassociateVpcCidrBlockRequest method
+func (m *MockVPCClient) DisassociateVpcCidrBlockRequest(input *ec2.DisassociateVpcCidrBlockInput) ec2.DisassociateVpcCidrBlockRequest {
+	return m.MockDisassociateCIDRBlock(input)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	clientset "github.com/crossplane/provider-aws/pkg/clients/ec2"
+)
+
+// this ensures that the mock implements the client interface
+var _ clientset.VPCPeeringConnectionClient = (*MockVPCPeeringConnectionClient)(nil)
+
+// MockVPCPeeringConnectionClient is a type that implements all the methods for VPCPeeringConnectionClient interface
+type MockVPCPeeringConnectionClient struct {
+	MockCreate        func(*ec2.CreateVpcPeeringConnectionInput) ec2.CreateVpcPeeringConnectionRequest
+	MockAccept        func(*ec2.AcceptVpcPeeringConnectionInput) ec2.AcceptVpcPeeringConnectionRequest
+	MockDelete        func(*ec2.DeleteVpcPeeringConnectionInput) ec2.DeleteVpcPeeringConnectionRequest
+	MockDescribe      func(*ec2.DescribeVpcPeeringConnectionsInput) ec2.DescribeVpcPeeringConnectionsRequest
+	MockModifyOptions func(*ec2.ModifyVpcPeeringConnectionOptionsInput) ec2.ModifyVpcPeeringConnectionOptionsRequest
+	MockCreateTags    func(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	MockDeleteTags    func(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
+}
+
+// CreateVpcPeeringConnectionRequest mocks CreateVpcPeeringConnectionRequest method
+func (m *MockVPCPeeringConnectionClient) CreateVpcPeeringConnectionRequest(input *ec2.CreateVpcPeeringConnectionInput) ec2.CreateVpcPeeringConnectionRequest {
+	return m.MockCreate(input)
+}
+
+// AcceptVpcPeeringConnectionRequest mocks AcceptVpcPeeringConnectionRequest method
+func (m *MockVPCPeeringConnectionClient) AcceptVpcPeeringConnectionRequest(input *ec2.AcceptVpcPeeringConnectionInput) ec2.AcceptVpcPeeringConnectionRequest {
+	return m.MockAccept(input)
+}
+
+// DeleteVpcPeeringConnectionRequest mocks DeleteVpcPeeringConnectionRequest method
+func (m *MockVPCPeeringConnectionClient) DeleteVpcPeeringConnectionRequest(input *ec2.DeleteVpcPeeringConnectionInput) ec2.DeleteVpcPeeringConnectionRequest {
+	return m.MockDelete(input)
+}
+
+// DescribeVpcPeeringConnectionsRequest mocks DescribeVpcPeeringConnectionsRequest method
+func (m *MockVPCPeeringConnectionClient) DescribeVpcPeeringConnectionsRequest(input *ec2.DescribeVpcPeeringConnectionsInput) ec2.DescribeVpcPeeringConnectionsRequest {
+	return m.MockDescribe(input)
+}
+
+// ModifyVpcPeeringConnectionOptionsRequest mocks ModifyVpcPeeringConnectionOptionsRequest method
+func (m *MockVPCPeeringConnectionClient) ModifyVpcPeeringConnectionOptionsRequest(input *ec2.ModifyVpcPeeringConnectionOptionsInput) ec2.ModifyVpcPeeringConnectionOptionsRequest {
+	return m.MockModifyOptions(input)
+}
+
+// CreateTagsRequest mocks CreateTagsRequest method
+func (m *MockVPCPeeringConnectionClient) CreateTagsRequest(input *ec2.CreateTagsInput) ec2.CreateTagsRequest {
+	return m.MockCreateTags(input)
+}
+
+// DeleteTagsRequest mocks DeleteTagsRequest method
+func (m *MockVPCPeeringConnectionClient) DeleteTagsRequest(input *ec2.DeleteTagsInput) ec2.DeleteTagsRequest {
+	return m.MockDeleteTags(input)
+}
@@ -1,8 +1,6 @@
 package ec2
 
 import (
-	"context"
-
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/awserr"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -27,15 +25,12 @@ type InternetGatewayClient interface {
 	AttachInternetGatewayRequest(input *ec2.AttachInternetGatewayInput) ec2.AttachInternetGatewayRequest
 	DetachInternetGatewayRequest(input *ec2.DetachInternetGatewayInput) ec2.DetachInternetGatewayRequest
 	CreateTagsRequest(input *ec2.CreateTagsInput) ec2.CreateTagsRequest
+	DeleteTagsRequest(input *ec2.DeleteTagsInput) ec2.DeleteTagsRequest
 }
 
-// NewInternetGatewayClient returns a new client using AWS credentials as JSON encoded data.
-func NewInternetGatewayClient(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (InternetGatewayClient, error) {
-	cfg, err := auth(ctx, credentials, awsclients.DefaultSection, region)
-	if cfg == nil {
-		return nil, err
-	}
-	return ec2.New(*cfg), err
+// NewInternetGatewayClient returns a new client using the supplied AWS configuration.
+func NewInternetGatewayClient(cfg *aws.Config) InternetGatewayClient {
+	return ec2.New(*cfg)
 }
 
 // IsInternetGatewayNotFoundErr returns true if the error is because the item doesn't exist
@@ -75,6 +70,7 @@ func GenerateIGObservation(ig ec2.InternetGateway) v1beta1.InternetGatewayObserv
 		InternetGatewayID: aws.StringValue(ig.InternetGatewayId),
 		Attachments:       attachments,
 		OwnerID:           aws.StringValue(ig.OwnerId),
+		Tags:              observedTags(ig.Tags),
 	}
 }
 
@@ -103,7 +99,7 @@ func IsIgUpToDate(p v1beta1.InternetGatewayParameters, ig ec2.InternetGateway) b
 	// if the attachment in spec exists in ig.Attachments, compare the tags and return
 	for _, a := range ig.Attachments {
 		if aws.StringValue(p.VPCID) == aws.StringValue(a.VpcId) {
-			return v1beta1.CompareTags(p.Tags, ig.Tags)
+			return tagsUpToDate(p.Tags, ig.Tags)
 		}
 	}
 
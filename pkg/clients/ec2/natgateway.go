@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+)
+
+const (
+	// NATGatewayNotFound is the code that is returned by ec2 when the given
+	// NAT gateway ID is invalid
+	NATGatewayNotFound = "NatGatewayNotFound"
+
+	// NATGatewayIDMalformed is the code that is returned by ec2 when the
+	// given NAT gateway ID is malformed
+	NATGatewayIDMalformed = "InvalidNatGatewayID.NotFound"
+)
+
+// NATGatewayClient is the external client used for NATGateway Custom Resource
+type NATGatewayClient interface {
+	CreateNatGatewayRequest(*ec2.CreateNatGatewayInput) ec2.CreateNatGatewayRequest
+	DeleteNatGatewayRequest(*ec2.DeleteNatGatewayInput) ec2.DeleteNatGatewayRequest
+	DescribeNatGatewaysRequest(*ec2.DescribeNatGatewaysInput) ec2.DescribeNatGatewaysRequest
+	CreateTagsRequest(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	DeleteTagsRequest(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
+}
+
+// NewNATGatewayClient returns a new client using the supplied AWS configuration.
+func NewNATGatewayClient(cfg *aws.Config) NATGatewayClient {
+	return ec2.New(*cfg)
+}
+
+// IsNATGatewayNotFoundErr returns true if the error is because the NAT
+// gateway doesn't exist
+func IsNATGatewayNotFoundErr(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case NATGatewayNotFound, NATGatewayIDMalformed:
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateNATGatewayObservation is used to produce
+// v1alpha4.NATGatewayObservation from ec2.NatGateway.
+func GenerateNATGatewayObservation(nat ec2.NatGateway) v1alpha4.NATGatewayObservation {
+	o := v1alpha4.NATGatewayObservation{
+		NATGatewayID:   aws.StringValue(nat.NatGatewayId),
+		State:          string(nat.State),
+		FailureCode:    aws.StringValue(nat.FailureCode),
+		FailureMessage: aws.StringValue(nat.FailureMessage),
+		VPCID:          aws.StringValue(nat.VpcId),
+		Tags:           observedTags(nat.Tags),
+	}
+
+	if nat.CreateTime != nil {
+		o.CreateTime = &metav1.Time{Time: *nat.CreateTime}
+	}
+
+	if len(nat.NatGatewayAddresses) > 0 {
+		o.NATGatewayAddresses = make([]v1alpha4.NATGatewayAddress, len(nat.NatGatewayAddresses))
+		for i, a := range nat.NatGatewayAddresses {
+			o.NATGatewayAddresses[i] = v1alpha4.NATGatewayAddress{
+				AllocationID:       aws.StringValue(a.AllocationId),
+				NetworkInterfaceID: aws.StringValue(a.NetworkInterfaceId),
+				PrivateIP:          aws.StringValue(a.PrivateIp),
+				PublicIP:           aws.StringValue(a.PublicIp),
+			}
+		}
+	}
+
+	return o
+}
+
+// LateInitializeNATGateway fills the empty fields in
+// *v1alpha4.NATGatewayParameters with the values seen in ec2.NatGateway.
+func LateInitializeNATGateway(in *v1alpha4.NATGatewayParameters, nat *ec2.NatGateway) {
+	if nat == nil {
+		return
+	}
+	if len(in.Tags) == 0 && len(nat.Tags) != 0 {
+		in.Tags = v1beta1.BuildFromEC2Tags(nat.Tags)
+	}
+}
+
+// IsNATGatewayUpToDate checks whether there is a change in any of the
+// modifiable fields. Tags are the only modifiable field of a NAT gateway.
+func IsNATGatewayUpToDate(p v1alpha4.NATGatewayParameters, nat ec2.NatGateway) bool {
+	return tagsUpToDate(p.Tags, nat.Tags)
+}
+
+// GenerateCreateNATGatewayInput returns the input to create a NAT gateway
+// with the supplied parameters.
+func GenerateCreateNATGatewayInput(p v1alpha4.NATGatewayParameters) *ec2.CreateNatGatewayInput {
+	in := &ec2.CreateNatGatewayInput{
+		AllocationId: p.AllocationID,
+		SubnetId:     p.SubnetID,
+	}
+	if len(p.Tags) != 0 {
+		in.TagSpecifications = []ec2.TagSpecification{{
+			ResourceType: ec2.ResourceTypeNatgateway,
+			Tags:         v1beta1.GenerateEC2Tags(p.Tags),
+		}}
+	}
+	return in
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+)
+
+var (
+	natID       = "nat-1"
+	natSubnetID = "subnet-1"
+)
+
+func TestGenerateCreateNATGatewayInput(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha4.NATGatewayParameters
+		want   *ec2.CreateNatGatewayInput
+	}{
+		"NoTags": {
+			reason: "NAT gateways without tags should be created without tag specifications.",
+			p: v1alpha4.NATGatewayParameters{
+				AllocationID: aws.String(eipAllocationID),
+				SubnetID:     aws.String(natSubnetID),
+			},
+			want: &ec2.CreateNatGatewayInput{
+				AllocationId: aws.String(eipAllocationID),
+				SubnetId:     aws.String(natSubnetID),
+			},
+		},
+		"Tags": {
+			reason: "NAT gateways should be created with their tags.",
+			p: v1alpha4.NATGatewayParameters{
+				AllocationID: aws.String(eipAllocationID),
+				SubnetID:     aws.String(natSubnetID),
+				Tags:         []v1beta1.Tag{{Key: "k", Value: "v"}},
+			},
+			want: &ec2.CreateNatGatewayInput{
+				AllocationId: aws.String(eipAllocationID),
+				SubnetId:     aws.String(natSubnetID),
+				TagSpecifications: []ec2.TagSpecification{{
+					ResourceType: ec2.ResourceTypeNatgateway,
+					Tags:         []ec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+				}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateCreateNATGatewayInput(tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateCreateNATGatewayInput(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsNATGatewayUpToDate(t *testing.T) {
+	type args struct {
+		p   v1alpha4.NATGatewayParameters
+		nat ec2.NatGateway
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"SameTags": {
+			reason: "NAT gateways with the desired tags should be up to date.",
+			args: args{
+				p:   v1alpha4.NATGatewayParameters{Tags: []v1beta1.Tag{{Key: "k", Value: "v"}}},
+				nat: ec2.NatGateway{Tags: []ec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}}},
+			},
+			want: true,
+		},
+		"ExtraTags": {
+			reason: "NAT gateways with tags that are not desired should not be up to date.",
+			args: args{
+				nat: ec2.NatGateway{Tags: []ec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}}},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsNATGatewayUpToDate(tc.args.p, tc.args.nat)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsNATGatewayUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateNATGatewayObservation(t *testing.T) {
+	created := time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		in  ec2.NatGateway
+		out v1alpha4.NATGatewayObservation
+	}{
+		"Available": {
+			in: ec2.NatGateway{
+				NatGatewayId: aws.String(natID),
+				State:        ec2.NatGatewayStateAvailable,
+				VpcId:        aws.String(vpcID),
+				CreateTime:   &created,
+				NatGatewayAddresses: []ec2.NatGatewayAddress{{
+					AllocationId:       aws.String(eipAllocationID),
+					NetworkInterfaceId: aws.String("eni-1"),
+					PrivateIp:          aws.String("10.0.0.1"),
+					PublicIp:           aws.String(eipPublicIP),
+				}},
+			},
+			out: v1alpha4.NATGatewayObservation{
+				NATGatewayID: natID,
+				State:        string(ec2.NatGatewayStateAvailable),
+				VPCID:        vpcID,
+				CreateTime:   &metav1.Time{Time: created},
+				NATGatewayAddresses: []v1alpha4.NATGatewayAddress{{
+					AllocationID:       eipAllocationID,
+					NetworkInterfaceID: "eni-1",
+					PrivateIP:          "10.0.0.1",
+					PublicIP:           eipPublicIP,
+				}},
+			},
+		},
+		"Failed": {
+			in: ec2.NatGateway{
+				NatGatewayId:   aws.String(natID),
+				State:          ec2.NatGatewayStateFailed,
+				FailureCode:    aws.String("Gateway.NotAttached"),
+				FailureMessage: aws.String("Network vpc-1 has no Internet gateway attached"),
+			},
+			out: v1alpha4.NATGatewayObservation{
+				NATGatewayID:   natID,
+				State:          string(ec2.NatGatewayStateFailed),
+				FailureCode:    "Gateway.NotAttached",
+				FailureMessage: "Network vpc-1 has no Internet gateway attached",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := GenerateNATGatewayObservation(tc.in)
+			if diff := cmp.Diff(tc.out, r); diff != "" {
+				t.Errorf("GenerateNATGatewayObservation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
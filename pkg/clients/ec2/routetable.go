@@ -1,7 +1,6 @@
 package ec2
 
 import (
-	"context"
 	"encoding/json"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -37,18 +36,16 @@ type RouteTableClient interface {
 	DescribeRouteTablesRequest(*ec2.DescribeRouteTablesInput) ec2.DescribeRouteTablesRequest
 	CreateRouteRequest(*ec2.CreateRouteInput) ec2.CreateRouteRequest
 	DeleteRouteRequest(*ec2.DeleteRouteInput) ec2.DeleteRouteRequest
+	ReplaceRouteRequest(*ec2.ReplaceRouteInput) ec2.ReplaceRouteRequest
 	AssociateRouteTableRequest(*ec2.AssociateRouteTableInput) ec2.AssociateRouteTableRequest
 	DisassociateRouteTableRequest(*ec2.DisassociateRouteTableInput) ec2.DisassociateRouteTableRequest
 	CreateTagsRequest(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	DeleteTagsRequest(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
 }
 
-// NewRouteTableClient returns a new client using AWS credentials as JSON encoded data.
-func NewRouteTableClient(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (RouteTableClient, error) {
-	cfg, err := auth(ctx, credentials, awsclients.DefaultSection, region)
-	if cfg == nil {
-		return nil, err
-	}
-	return ec2.New(*cfg), nil
+// NewRouteTableClient returns a new client using the supplied AWS configuration.
+func NewRouteTableClient(cfg *aws.Config) RouteTableClient {
+	return ec2.New(*cfg)
 }
 
 // IsRouteTableNotFoundErr returns true if the error is because the route table doesn't exist
@@ -87,20 +84,30 @@ func GenerateRTObservation(rt ec2.RouteTable) v1alpha4.RouteTableObservation {
 	o := v1alpha4.RouteTableObservation{
 		OwnerID:      aws.StringValue(rt.OwnerId),
 		RouteTableID: aws.StringValue(rt.RouteTableId),
+		Tags:         observedTags(rt.Tags),
 	}
 
 	if len(rt.Routes) > 0 {
 		o.Routes = make([]v1alpha4.RouteState, len(rt.Routes))
 		for i, rt := range rt.Routes {
 			o.Routes[i] = v1alpha4.RouteState{
-				State:                string(rt.State),
-				DestinationCIDRBlock: aws.StringValue(rt.DestinationCidrBlock),
-				GatewayID:            aws.StringValue(rt.GatewayId),
+				State:                       string(rt.State),
+				DestinationCIDRBlock:        aws.StringValue(rt.DestinationCidrBlock),
+				DestinationIPv6CIDRBlock:    aws.StringValue(rt.DestinationIpv6CidrBlock),
+				DestinationPrefixListID:     aws.StringValue(rt.DestinationPrefixListId),
+				GatewayID:                   aws.StringValue(rt.GatewayId),
+				NatGatewayID:                aws.StringValue(rt.NatGatewayId),
+				TransitGatewayID:            aws.StringValue(rt.TransitGatewayId),
+				VPCPeeringConnectionID:      aws.StringValue(rt.VpcPeeringConnectionId),
+				NetworkInterfaceID:          aws.StringValue(rt.NetworkInterfaceId),
+				InstanceID:                  aws.StringValue(rt.InstanceId),
+				EgressOnlyInternetGatewayID: aws.StringValue(rt.EgressOnlyInternetGatewayId),
+				Origin:                      string(rt.Origin),
 			}
 		}
 	}
 
-	if len(rt.Routes) > 0 {
+	if len(rt.Associations) > 0 {
 		o.Associations = make([]v1alpha4.AssociationState, len(rt.Associations))
 		for i, asc := range rt.Associations {
 			o.Associations[i] = v1alpha4.AssociationState{
@@ -116,32 +123,15 @@ func GenerateRTObservation(rt ec2.RouteTable) v1alpha4.RouteTableObservation {
 }
 
 // LateInitializeRT fills the empty fields in *v1alpha4.RouteTableParameters with
-// the values seen in ec2.RouteTable.
-func LateInitializeRT(in *v1alpha4.RouteTableParameters, rt *ec2.RouteTable) { // nolint:gocyclo
+// the values seen in ec2.RouteTable. Routes and associations are not late
+// initialized, because routes and associations that are not specified are
+// removed.
+func LateInitializeRT(in *v1alpha4.RouteTableParameters, rt *ec2.RouteTable) {
 	if rt == nil {
 		return
 	}
 	in.VPCID = awsclients.LateInitializeStringPtr(in.VPCID, rt.VpcId)
 
-	if len(in.Routes) == 0 && len(rt.Routes) != 0 {
-		in.Routes = make([]v1alpha4.Route, len(rt.Routes))
-		for i, val := range rt.Routes {
-			in.Routes[i] = v1alpha4.Route{
-				DestinationCIDRBlock: val.DestinationCidrBlock,
-				GatewayID:            val.GatewayId,
-			}
-		}
-	}
-
-	if len(in.Associations) == 0 && len(rt.Associations) != 0 {
-		in.Associations = make([]v1alpha4.Association, len(rt.Associations))
-		for i, val := range rt.Associations {
-			in.Associations[i] = v1alpha4.Association{
-				SubnetID: val.SubnetId,
-			}
-		}
-	}
-
 	if len(in.Tags) == 0 && len(rt.Tags) != 0 {
 		in.Tags = v1beta1.BuildFromEC2Tags(rt.Tags)
 	}
@@ -157,15 +147,20 @@ func CreateRTPatch(in ec2.RouteTable, target v1alpha4.RouteTableParameters) (*v1
 
 	// Add the default route for fair comparison.
 	for _, val := range in.Routes {
-		if *val.GatewayId == LocalGatewayID {
-			target.Routes = append([]v1alpha4.Route{{
-				GatewayID:            val.GatewayId,
-				DestinationCIDRBlock: val.DestinationCidrBlock,
-			}}, target.Routes...)
+		if aws.StringValue(val.GatewayId) == LocalGatewayID {
+			target.Routes = append([]v1alpha4.Route{GenerateRoute(val)}, target.Routes...)
 		}
 	}
 
 	LateInitializeRT(currentParams, &in)
+	for _, val := range in.Routes {
+		currentParams.Routes = append(currentParams.Routes, GenerateRoute(val))
+	}
+	for _, val := range in.Associations {
+		currentParams.Associations = append(currentParams.Associations, v1alpha4.Association{
+			SubnetID: val.SubnetId,
+		})
+	}
 
 	jsonPatch, err := awsclients.CreateJSONPatch(*currentParams, target)
 	if err != nil {
@@ -178,11 +173,226 @@ func CreateRTPatch(in ec2.RouteTable, target v1alpha4.RouteTableParameters) (*v1
 	return patch, nil
 }
 
+// GenerateRoute returns the v1alpha4.Route that describes the destination
+// and target of the supplied ec2.Route.
+func GenerateRoute(rt ec2.Route) v1alpha4.Route {
+	return v1alpha4.Route{
+		DestinationCIDRBlock:        rt.DestinationCidrBlock,
+		DestinationIPv6CIDRBlock:    rt.DestinationIpv6CidrBlock,
+		GatewayID:                   rt.GatewayId,
+		NatGatewayID:                rt.NatGatewayId,
+		TransitGatewayID:            rt.TransitGatewayId,
+		VPCPeeringConnectionID:      rt.VpcPeeringConnectionId,
+		NetworkInterfaceID:          rt.NetworkInterfaceId,
+		InstanceID:                  rt.InstanceId,
+		EgressOnlyInternetGatewayID: rt.EgressOnlyInternetGatewayId,
+	}
+}
+
+// GenerateCreateRouteInput returns the input to create the supplied route in
+// the route table with the supplied ID.
+func GenerateCreateRouteInput(tableID string, rt v1alpha4.Route) *ec2.CreateRouteInput {
+	return &ec2.CreateRouteInput{
+		RouteTableId:                aws.String(tableID),
+		DestinationCidrBlock:        rt.DestinationCIDRBlock,
+		DestinationIpv6CidrBlock:    rt.DestinationIPv6CIDRBlock,
+		GatewayId:                   rt.GatewayID,
+		NatGatewayId:                rt.NatGatewayID,
+		TransitGatewayId:            rt.TransitGatewayID,
+		VpcPeeringConnectionId:      rt.VPCPeeringConnectionID,
+		NetworkInterfaceId:          rt.NetworkInterfaceID,
+		InstanceId:                  rt.InstanceID,
+		EgressOnlyInternetGatewayId: rt.EgressOnlyInternetGatewayID,
+	}
+}
+
+// GenerateReplaceRouteInput returns the input to replace the target of the
+// route to the destination of the supplied route in the route table with the
+// supplied ID with the target of the supplied route.
+func GenerateReplaceRouteInput(tableID string, rt v1alpha4.Route) *ec2.ReplaceRouteInput {
+	return &ec2.ReplaceRouteInput{
+		RouteTableId:                aws.String(tableID),
+		DestinationCidrBlock:        rt.DestinationCIDRBlock,
+		DestinationIpv6CidrBlock:    rt.DestinationIPv6CIDRBlock,
+		GatewayId:                   rt.GatewayID,
+		NatGatewayId:                rt.NatGatewayID,
+		TransitGatewayId:            rt.TransitGatewayID,
+		VpcPeeringConnectionId:      rt.VPCPeeringConnectionID,
+		NetworkInterfaceId:          rt.NetworkInterfaceID,
+		InstanceId:                  rt.InstanceID,
+		EgressOnlyInternetGatewayId: rt.EgressOnlyInternetGatewayID,
+	}
+}
+
 // IsRtUpToDate checks whether there is a change in any of the modifiable fields.
+// Routes and associations are compared by DiffRoutes and DiffAssociations
+// instead, regardless of their order.
 func IsRtUpToDate(p v1alpha4.RouteTableParameters, rt ec2.RouteTable) (bool, error) {
 	patch, err := CreateRTPatch(rt, p)
 	if err != nil {
 		return false, err
 	}
-	return cmp.Equal(&v1alpha4.RouteTableParameters{}, patch, cmpopts.EquateEmpty(), cmpopts.IgnoreTypes(&v1alpha1.Reference{}, &v1alpha1.Selector{})), nil
+	associate, disassociate := DiffAssociations(p.Associations, rt.Associations)
+	return cmp.Equal(&v1alpha4.RouteTableParameters{}, patch, cmpopts.EquateEmpty(),
+		cmpopts.IgnoreTypes(&v1alpha1.Reference{}, &v1alpha1.Selector{}),
+		cmpopts.IgnoreFields(v1alpha4.RouteTableParameters{}, "Routes", "Associations")) &&
+		DiffRoutes(p.Routes, rt.Routes).Empty() && len(associate) == 0 && len(disassociate) == 0, nil
+}
+
+// A RoutesDiff describes the changes that must be made to the routes of a
+// route table for them to match the desired routes.
+type RoutesDiff struct {
+	// Create are the desired routes whose destination has no route.
+	Create []v1alpha4.Route
+
+	// Replace are the desired routes whose destination has a route to a
+	// different target.
+	Replace []v1alpha4.Route
+
+	// Delete are the routes whose destination has no desired route.
+	Delete []ec2.Route
+}
+
+// Empty returns true if no changes need to be made to the routes of the route
+// table.
+func (d RoutesDiff) Empty() bool {
+	return len(d.Create) == 0 && len(d.Replace) == 0 && len(d.Delete) == 0
+}
+
+// DiffRoutes returns the changes that must be made to the supplied observed
+// routes for them to match the supplied desired routes. Routes are matched by
+// their destination. Routes that were not created by CreateRoute, i.e. the
+// local routes of the VPC and routes propagated by a virtual private gateway,
+// and routes to prefix lists, which are created by VPC endpoints, are never
+// changed, and desired routes to their destinations are ignored.
+func DiffRoutes(desired []v1alpha4.Route, observed []ec2.Route) RoutesDiff {
+	managed := map[string]ec2.Route{}
+	unmanaged := map[string]bool{}
+	for _, rt := range observed {
+		if !isManagedRoute(rt) {
+			unmanaged[observedRouteDestination(rt)] = true
+			continue
+		}
+		managed[observedRouteDestination(rt)] = rt
+	}
+
+	d := RoutesDiff{}
+	wanted := map[string]bool{}
+	for _, rt := range desired {
+		dst := routeDestination(rt)
+		wanted[dst] = true
+		if unmanaged[dst] {
+			continue
+		}
+		ob, ok := managed[dst]
+		switch {
+		case !ok:
+			d.Create = append(d.Create, rt)
+		case !isSameRouteTarget(rt, ob):
+			d.Replace = append(d.Replace, rt)
+		}
+	}
+	for _, rt := range observed {
+		dst := observedRouteDestination(rt)
+		if isManagedRoute(rt) && !wanted[dst] {
+			d.Delete = append(d.Delete, rt)
+		}
+	}
+	return d
+}
+
+// isManagedRoute returns true if the supplied route may be changed, i.e. if
+// it is neither a local route of the VPC, nor propagated, nor a route to a
+// prefix list.
+func isManagedRoute(rt ec2.Route) bool {
+	switch {
+	case aws.StringValue(rt.GatewayId) == LocalGatewayID:
+		return false
+	case rt.Origin == ec2.RouteOriginCreateRouteTable, rt.Origin == ec2.RouteOriginEnableVgwRoutePropagation:
+		return false
+	case rt.DestinationPrefixListId != nil:
+		return false
+	}
+	return true
+}
+
+func routeDestination(rt v1alpha4.Route) string {
+	if rt.DestinationIPv6CIDRBlock != nil {
+		return "ipv6:" + aws.StringValue(rt.DestinationIPv6CIDRBlock)
+	}
+	return "ipv4:" + aws.StringValue(rt.DestinationCIDRBlock)
+}
+
+func observedRouteDestination(rt ec2.Route) string {
+	switch {
+	case rt.DestinationIpv6CidrBlock != nil:
+		return "ipv6:" + aws.StringValue(rt.DestinationIpv6CidrBlock)
+	case rt.DestinationPrefixListId != nil:
+		return "pl:" + aws.StringValue(rt.DestinationPrefixListId)
+	}
+	return "ipv4:" + aws.StringValue(rt.DestinationCidrBlock)
+}
+
+// isSameRouteTarget returns true if the supplied observed route has the target
+// of the supplied desired route. Routes to an instance are observed with both
+// the instance and its network interface as targets, so the instance is
+// compared before the network interface.
+func isSameRouteTarget(desired v1alpha4.Route, observed ec2.Route) bool {
+	targets := []struct {
+		desired  *string
+		observed *string
+	}{
+		{desired: desired.GatewayID, observed: observed.GatewayId},
+		{desired: desired.NatGatewayID, observed: observed.NatGatewayId},
+		{desired: desired.TransitGatewayID, observed: observed.TransitGatewayId},
+		{desired: desired.VPCPeeringConnectionID, observed: observed.VpcPeeringConnectionId},
+		{desired: desired.InstanceID, observed: observed.InstanceId},
+		{desired: desired.NetworkInterfaceID, observed: observed.NetworkInterfaceId},
+		{desired: desired.EgressOnlyInternetGatewayID, observed: observed.EgressOnlyInternetGatewayId},
+	}
+	for _, t := range targets {
+		if t.desired != nil {
+			return aws.StringValue(t.desired) == aws.StringValue(t.observed)
+		}
+	}
+	return false
+}
+
+// DiffAssociations returns the IDs of the subnets that must be associated
+// with the route table, and the IDs of the associations that must be
+// disassociated from it, for the supplied observed associations to match the
+// supplied desired associations. The main association of the VPC, and
+// associations with gateways rather than subnets, are never disassociated.
+func DiffAssociations(desired []v1alpha4.Association, observed []ec2.RouteTableAssociation) (associate []string, disassociate []string) {
+	wanted := map[string]bool{}
+	for _, asc := range desired {
+		wanted[aws.StringValue(asc.SubnetID)] = true
+	}
+	associated := map[string]bool{}
+	for _, asc := range observed {
+		if aws.BoolValue(asc.Main) || asc.SubnetId == nil || !isActiveAssociation(asc) {
+			continue
+		}
+		associated[aws.StringValue(asc.SubnetId)] = true
+		if !wanted[aws.StringValue(asc.SubnetId)] {
+			disassociate = append(disassociate, aws.StringValue(asc.RouteTableAssociationId))
+		}
+	}
+	for _, asc := range desired {
+		if !associated[aws.StringValue(asc.SubnetID)] {
+			associate = append(associate, aws.StringValue(asc.SubnetID))
+		}
+	}
+	return associate, disassociate
+}
+
+func isActiveAssociation(asc ec2.RouteTableAssociation) bool {
+	if asc.AssociationState == nil {
+		return true
+	}
+	switch asc.AssociationState.State {
+	case ec2.RouteTableAssociationStateCodeDisassociating, ec2.RouteTableAssociationStateCodeDisassociated:
+		return false
+	}
+	return true
 }
@@ -66,6 +66,34 @@ func TestIsRTUpToDate(t *testing.T) {
 			},
 			want: false,
 		},
+		"ExtraRoute": {
+			args: args{
+				rt: ec2.RouteTable{
+					VpcId: aws.String(rtVPC),
+					Routes: []ec2.Route{{
+						DestinationCidrBlock: aws.String("0.0.0.0/0"),
+						GatewayId:            aws.String("igw-1"),
+						Origin:               ec2.RouteOriginCreateRoute,
+					}},
+				},
+				p: v1alpha4.RouteTableParameters{
+					VPCID: aws.String(rtVPC),
+				},
+			},
+			want: false,
+		},
+		"ExtraAssociation": {
+			args: args{
+				rt: ec2.RouteTable{
+					VpcId:        aws.String(rtVPC),
+					Associations: rtAssociations(),
+				},
+				p: v1alpha4.RouteTableParameters{
+					VPCID: aws.String(rtVPC),
+				},
+			},
+			want: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -100,6 +128,23 @@ func TestGenerateRTObservation(t *testing.T) {
 			out: v1alpha4.RouteTableObservation{
 				RouteTableID: rtID,
 			},
+		}, "Routes": {
+			in: ec2.RouteTable{
+				RouteTableId: aws.String(rtID),
+				Routes: []ec2.Route{
+					{DestinationIpv6CidrBlock: aws.String("::/0"), EgressOnlyInternetGatewayId: aws.String("eigw-1"), State: ec2.RouteStateActive, Origin: ec2.RouteOriginCreateRoute},
+					{DestinationPrefixListId: aws.String("pl-1"), GatewayId: aws.String("vpce-1"), State: ec2.RouteStateActive, Origin: ec2.RouteOriginCreateRoute},
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1"), State: ec2.RouteStateBlackhole, Origin: ec2.RouteOriginCreateRoute},
+				},
+			},
+			out: v1alpha4.RouteTableObservation{
+				RouteTableID: rtID,
+				Routes: []v1alpha4.RouteState{
+					{DestinationIPv6CIDRBlock: "::/0", EgressOnlyInternetGatewayID: "eigw-1", State: "active", Origin: "CreateRoute"},
+					{DestinationPrefixListID: "pl-1", GatewayID: "vpce-1", State: "active", Origin: "CreateRoute"},
+					{DestinationCIDRBlock: "0.0.0.0/0", NatGatewayID: "nat-1", State: "blackhole", Origin: "CreateRoute"},
+				},
+			},
 		},
 	}
 
@@ -170,3 +215,181 @@ func TestCreateRTPatch(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffRoutes(t *testing.T) {
+	local := ec2.Route{
+		DestinationCidrBlock: aws.String("10.0.0.0/16"),
+		GatewayId:            aws.String(LocalGatewayID),
+		Origin:               ec2.RouteOriginCreateRouteTable,
+	}
+	propagated := ec2.Route{
+		DestinationCidrBlock: aws.String("172.16.0.0/16"),
+		GatewayId:            aws.String("vgw-1"),
+		Origin:               ec2.RouteOriginEnableVgwRoutePropagation,
+	}
+
+	type args struct {
+		desired  []v1alpha4.Route
+		observed []ec2.Route
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   RoutesDiff
+	}{
+		"UpToDate": {
+			reason: "No changes should be made to routes that match the desired routes.",
+			args: args{
+				desired: []v1alpha4.Route{{DestinationCIDRBlock: aws.String("0.0.0.0/0"), GatewayID: aws.String("igw-1")}},
+				observed: []ec2.Route{local, {
+					DestinationCidrBlock: aws.String("0.0.0.0/0"),
+					GatewayId:            aws.String("igw-1"),
+					Origin:               ec2.RouteOriginCreateRoute,
+				}},
+			},
+			want: RoutesDiff{},
+		},
+		"Changes": {
+			reason: "Missing routes should be created, routes to a different target replaced, and extra routes deleted.",
+			args: args{
+				desired: []v1alpha4.Route{
+					{DestinationCIDRBlock: aws.String("0.0.0.0/0"), GatewayID: aws.String("igw-2")},
+					{DestinationCIDRBlock: aws.String("192.168.0.0/16"), GatewayID: aws.String("igw-1")},
+				},
+				observed: []ec2.Route{
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1"), Origin: ec2.RouteOriginCreateRoute},
+					{DestinationCidrBlock: aws.String("10.1.0.0/16"), GatewayId: aws.String("igw-1"), Origin: ec2.RouteOriginCreateRoute},
+				},
+			},
+			want: RoutesDiff{
+				Create:  []v1alpha4.Route{{DestinationCIDRBlock: aws.String("192.168.0.0/16"), GatewayID: aws.String("igw-1")}},
+				Replace: []v1alpha4.Route{{DestinationCIDRBlock: aws.String("0.0.0.0/0"), GatewayID: aws.String("igw-2")}},
+				Delete:  []ec2.Route{{DestinationCidrBlock: aws.String("10.1.0.0/16"), GatewayId: aws.String("igw-1"), Origin: ec2.RouteOriginCreateRoute}},
+			},
+		},
+		"UnmanagedRoutes": {
+			reason: "The local route and propagated routes should never be changed, even if they are desired with a different target.",
+			args: args{
+				desired:  []v1alpha4.Route{{DestinationCIDRBlock: aws.String("172.16.0.0/16"), GatewayID: aws.String("igw-1")}},
+				observed: []ec2.Route{local, propagated},
+			},
+			want: RoutesDiff{},
+		},
+		"OtherTargets": {
+			reason: "Routes should be matched by their IPv6 destination, and compared by any kind of target.",
+			args: args{
+				desired: []v1alpha4.Route{
+					{DestinationCIDRBlock: aws.String("0.0.0.0/0"), NatGatewayID: aws.String("nat-1")},
+					{DestinationCIDRBlock: aws.String("10.1.0.0/16"), InstanceID: aws.String("i-1")},
+					{DestinationIPv6CIDRBlock: aws.String("::/0"), EgressOnlyInternetGatewayID: aws.String("eigw-2")},
+				},
+				observed: []ec2.Route{
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1"), Origin: ec2.RouteOriginCreateRoute},
+					{DestinationCidrBlock: aws.String("10.1.0.0/16"), InstanceId: aws.String("i-1"), NetworkInterfaceId: aws.String("eni-1"), Origin: ec2.RouteOriginCreateRoute},
+					{DestinationIpv6CidrBlock: aws.String("::/0"), EgressOnlyInternetGatewayId: aws.String("eigw-1"), Origin: ec2.RouteOriginCreateRoute},
+					{DestinationPrefixListId: aws.String("pl-1"), GatewayId: aws.String("vpce-1"), Origin: ec2.RouteOriginCreateRoute},
+				},
+			},
+			want: RoutesDiff{
+				Replace: []v1alpha4.Route{{DestinationIPv6CIDRBlock: aws.String("::/0"), EgressOnlyInternetGatewayID: aws.String("eigw-2")}},
+			},
+		},
+		"DifferentKindOfTarget": {
+			reason: "Routes to a different kind of target should be replaced.",
+			args: args{
+				desired:  []v1alpha4.Route{{DestinationCIDRBlock: aws.String("0.0.0.0/0"), VPCPeeringConnectionID: aws.String("pcx-1")}},
+				observed: []ec2.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), TransitGatewayId: aws.String("tgw-1"), Origin: ec2.RouteOriginCreateRoute}},
+			},
+			want: RoutesDiff{
+				Replace: []v1alpha4.Route{{DestinationCIDRBlock: aws.String("0.0.0.0/0"), VPCPeeringConnectionID: aws.String("pcx-1")}},
+			},
+		},
+		"ChangedNonGatewayTarget": {
+			reason: "Routes to a different target of a kind other than a gateway should be replaced.",
+			args: args{
+				desired: []v1alpha4.Route{
+					{DestinationCIDRBlock: aws.String("0.0.0.0/0"), NatGatewayID: aws.String("nat-2")},
+					{DestinationCIDRBlock: aws.String("10.1.0.0/16"), TransitGatewayID: aws.String("tgw-2")},
+				},
+				observed: []ec2.Route{
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1"), Origin: ec2.RouteOriginCreateRoute},
+					{DestinationCidrBlock: aws.String("10.1.0.0/16"), TransitGatewayId: aws.String("tgw-1"), Origin: ec2.RouteOriginCreateRoute},
+				},
+			},
+			want: RoutesDiff{
+				Replace: []v1alpha4.Route{
+					{DestinationCIDRBlock: aws.String("0.0.0.0/0"), NatGatewayID: aws.String("nat-2")},
+					{DestinationCIDRBlock: aws.String("10.1.0.0/16"), TransitGatewayID: aws.String("tgw-2")},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DiffRoutes(tc.args.desired, tc.args.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiffRoutes(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDiffAssociations(t *testing.T) {
+	type args struct {
+		desired  []v1alpha4.Association
+		observed []ec2.RouteTableAssociation
+	}
+	type want struct {
+		associate    []string
+		disassociate []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"UpToDate": {
+			reason: "No changes should be made to associations that match the desired associations.",
+			args: args{
+				desired:  specAssociations(),
+				observed: rtAssociations(),
+			},
+			want: want{},
+		},
+		"Changes": {
+			reason: "Missing subnets should be associated, and extra associations disassociated.",
+			args: args{
+				desired: []v1alpha4.Association{{SubnetID: aws.String("subnet-2")}},
+				observed: []ec2.RouteTableAssociation{
+					{SubnetId: aws.String("subnet-1"), RouteTableAssociationId: aws.String("rtbassoc-1")},
+					{Main: aws.Bool(true), RouteTableAssociationId: aws.String("rtbassoc-main")},
+					{GatewayId: aws.String("igw-1"), RouteTableAssociationId: aws.String("rtbassoc-igw")},
+					{
+						SubnetId:                aws.String("subnet-3"),
+						RouteTableAssociationId: aws.String("rtbassoc-3"),
+						AssociationState:        &ec2.RouteTableAssociationState{State: ec2.RouteTableAssociationStateCodeDisassociated},
+					},
+				},
+			},
+			want: want{
+				associate:    []string{"subnet-2"},
+				disassociate: []string{"rtbassoc-1"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			associate, disassociate := DiffAssociations(tc.args.desired, tc.args.observed)
+			if diff := cmp.Diff(tc.want.associate, associate); diff != "" {
+				t.Errorf("\n%s\nDiffAssociations(...): -want associate, +got associate:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.disassociate, disassociate); diff != "" {
+				t.Errorf("\n%s\nDiffAssociations(...): -want disassociate, +got disassociate:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package ec2
 
 import (
-	"context"
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/awserr"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -11,7 +14,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
-	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 )
 
@@ -21,8 +24,30 @@ const (
 
 	// InvalidPermissionDuplicate is returned when you try to Authorize for a rule that already exists.
 	InvalidPermissionDuplicate = "InvalidPermission.Duplicate"
+
+	// InvalidPermissionNotFound is returned when you try to Revoke a rule that does not exist.
+	InvalidPermissionNotFound = "InvalidPermission.NotFound"
 )
 
+const (
+	// allProtocols is the protocol of rules that allow traffic of every
+	// protocol on every port.
+	allProtocols = "-1"
+
+	defaultEgressCIDR   = "0.0.0.0/0"
+	defaultEgressCIDRv6 = "::/0"
+)
+
+// protocols maps the names and numbers by which a protocol may be specified
+// to the name by which AWS reports it.
+var protocols = map[string]string{
+	"all": allProtocols,
+	"1":   "icmp",
+	"6":   "tcp",
+	"17":  "udp",
+	"58":  "icmpv6",
+}
+
 // SecurityGroupClient is the external client used for SecurityGroup Custom Resource
 type SecurityGroupClient interface {
 	CreateSecurityGroupRequest(input *ec2.CreateSecurityGroupInput) ec2.CreateSecurityGroupRequest
@@ -30,16 +55,17 @@ type SecurityGroupClient interface {
 	DescribeSecurityGroupsRequest(input *ec2.DescribeSecurityGroupsInput) ec2.DescribeSecurityGroupsRequest
 	AuthorizeSecurityGroupIngressRequest(input *ec2.AuthorizeSecurityGroupIngressInput) ec2.AuthorizeSecurityGroupIngressRequest
 	AuthorizeSecurityGroupEgressRequest(input *ec2.AuthorizeSecurityGroupEgressInput) ec2.AuthorizeSecurityGroupEgressRequest
+	RevokeSecurityGroupIngressRequest(input *ec2.RevokeSecurityGroupIngressInput) ec2.RevokeSecurityGroupIngressRequest
+	RevokeSecurityGroupEgressRequest(input *ec2.RevokeSecurityGroupEgressInput) ec2.RevokeSecurityGroupEgressRequest
+	UpdateSecurityGroupRuleDescriptionsIngressRequest(input *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) ec2.UpdateSecurityGroupRuleDescriptionsIngressRequest
+	UpdateSecurityGroupRuleDescriptionsEgressRequest(input *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) ec2.UpdateSecurityGroupRuleDescriptionsEgressRequest
 	CreateTagsRequest(input *ec2.CreateTagsInput) ec2.CreateTagsRequest
+	DeleteTagsRequest(input *ec2.DeleteTagsInput) ec2.DeleteTagsRequest
 }
 
 // NewSecurityGroupClient generates client for AWS Security Group API
-func NewSecurityGroupClient(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (SecurityGroupClient, error) {
-	cfg, err := auth(ctx, credentials, awsclients.DefaultSection, region)
-	if cfg == nil {
-		return nil, err
-	}
-	return ec2.New(*cfg), err
+func NewSecurityGroupClient(cfg *aws.Config) SecurityGroupClient {
+	return ec2.New(*cfg)
 }
 
 // IsSecurityGroupNotFoundErr returns true if the error is because the item doesn't exist
@@ -62,6 +88,16 @@ func IsRuleAlreadyExistsErr(err error) bool {
 	return false
 }
 
+// IsRuleNotFoundErr returns true if the error is because the rule doesn't exist.
+func IsRuleNotFoundErr(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		if awsErr.Code() == InvalidPermissionNotFound {
+			return true
+		}
+	}
+	return false
+}
+
 // GenerateEC2Permissions converts object Permissions to ec2 format
 func GenerateEC2Permissions(objectPerms []v1beta1.IPPermission) []ec2.IpPermission {
 	if len(objectPerms) == 0 {
@@ -107,18 +143,260 @@ func GenerateEC2Permissions(objectPerms []v1beta1.IPPermission) []ec2.IpPermissi
 	return permissions
 }
 
+// GenerateIPPermissions converts ec2 Permissions to object format
+func GenerateIPPermissions(ec2Perms []ec2.IpPermission) []v1beta1.IPPermission {
+	if len(ec2Perms) == 0 {
+		return nil
+	}
+	permissions := make([]v1beta1.IPPermission, len(ec2Perms))
+	for i, p := range ec2Perms {
+		perm := v1beta1.IPPermission{
+			FromPort:   p.FromPort,
+			IPProtocol: aws.StringValue(p.IpProtocol),
+			ToPort:     p.ToPort,
+		}
+		for _, c := range p.IpRanges {
+			perm.IPRanges = append(perm.IPRanges, v1beta1.IPRange{
+				CIDRIP:      aws.StringValue(c.CidrIp),
+				Description: c.Description,
+			})
+		}
+		for _, c := range p.Ipv6Ranges {
+			perm.IPv6Ranges = append(perm.IPv6Ranges, v1beta1.IPv6Range{
+				CIDRIPv6:    aws.StringValue(c.CidrIpv6),
+				Description: c.Description,
+			})
+		}
+		for _, c := range p.PrefixListIds {
+			perm.PrefixListIDs = append(perm.PrefixListIDs, v1beta1.PrefixListID{
+				Description:  c.Description,
+				PrefixListID: aws.StringValue(c.PrefixListId),
+			})
+		}
+		for _, c := range p.UserIdGroupPairs {
+			perm.UserIDGroupPairs = append(perm.UserIDGroupPairs, v1beta1.UserIDGroupPair{
+				Description:            c.Description,
+				GroupID:                c.GroupId,
+				GroupName:              c.GroupName,
+				UserID:                 c.UserId,
+				VPCID:                  c.VpcId,
+				VPCPeeringConnectionID: c.VpcPeeringConnectionId,
+			})
+		}
+		permissions[i] = perm
+	}
+	return permissions
+}
+
+// A PermissionsDiff describes the changes that must be made to the rules of
+// a security group for them to match the desired rules.
+type PermissionsDiff struct {
+	// Authorize are the desired rules the security group does not have.
+	Authorize []ec2.IpPermission
+
+	// Revoke are the rules the security group has that are not desired.
+	Revoke []ec2.IpPermission
+
+	// Describe are the desired rules the security group has with a
+	// different description.
+	Describe []ec2.IpPermission
+}
+
+// Empty returns true if no changes need to be made to the rules of the
+// security group.
+func (d PermissionsDiff) Empty() bool {
+	return len(d.Authorize) == 0 && len(d.Revoke) == 0 && len(d.Describe) == 0
+}
+
+// DiffPermissions returns the changes that must be made to the supplied
+// observed rules for them to match the supplied desired rules. Rules are
+// compared as sets of permissions that each allow traffic of a protocol and
+// range of ports from (or to) a single CIDR block, prefix list, or security
+// group, regardless of how they are grouped or ordered.
+func DiffPermissions(desired []v1beta1.IPPermission, observed []ec2.IpPermission) PermissionsDiff {
+	want := splitPermissions(GenerateEC2Permissions(desired))
+	have := splitPermissions(observed)
+
+	d := PermissionsDiff{}
+	for _, k := range sortedRuleKeys(want) {
+		h, ok := have[k]
+		switch {
+		case !ok:
+			d.Authorize = append(d.Authorize, want[k].permission)
+		case h.description != want[k].description:
+			d.Describe = append(d.Describe, want[k].permission)
+		}
+	}
+	for _, k := range sortedRuleKeys(have) {
+		if _, ok := want[k]; !ok {
+			d.Revoke = append(d.Revoke, have[k].permission)
+		}
+	}
+	return d
+}
+
+// HasPeerGroupNames returns true if any of the supplied permissions
+// identifies a peer security group only by its name. AWS reports the rules of
+// security groups in a VPC by the ID of their peer security group, so rules
+// that identify it by name can't be compared with them.
+func HasPeerGroupNames(perms []v1beta1.IPPermission) bool {
+	for _, p := range perms {
+		for _, pair := range p.UserIDGroupPairs {
+			if pair.GroupID == nil && pair.GroupName != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DiffIngress returns the changes that must be made to the ingress rules of
+// the supplied security group for them to match the supplied parameters.
+func DiffIngress(p v1beta1.SecurityGroupParameters, sg ec2.SecurityGroup) PermissionsDiff {
+	d := DiffPermissions(p.Ingress, sg.IpPermissions)
+	if aws.BoolValue(p.IgnoreUnspecifiedRules) {
+		d.Revoke = nil
+	}
+	return d
+}
+
+// DiffEgress returns the changes that must be made to the egress rules of the
+// supplied security group for them to match the supplied parameters. AWS
+// creates every security group in a VPC with a default egress rule that
+// allows all outbound IPv4 traffic, and another that allows all outbound IPv6
+// traffic if the VPC has an IPv6 CIDR block. These default rules are desired
+// when the parameters specify no egress rules, and are otherwise revoked
+// unless the parameters specify them. Security groups that are not in a VPC
+// have no egress rules. No rules are revoked if the parameters ignore
+// unspecified rules.
+func DiffEgress(p v1beta1.SecurityGroupParameters, sg ec2.SecurityGroup) PermissionsDiff {
+	ignore := aws.BoolValue(p.IgnoreUnspecifiedRules)
+	if sg.VpcId == nil || (ignore && len(p.Egress) == 0) {
+		return PermissionsDiff{}
+	}
+	if len(p.Egress) != 0 {
+		d := DiffPermissions(p.Egress, sg.IpPermissionsEgress)
+		if ignore {
+			d.Revoke = nil
+		}
+		return d
+	}
+	def := v1beta1.IPPermission{
+		IPProtocol: allProtocols,
+		IPRanges:   []v1beta1.IPRange{{CIDRIP: defaultEgressCIDR}},
+	}
+	// We can't tell whether the VPC has an IPv6 CIDR block, so we keep the
+	// default IPv6 rule if AWS added it.
+	if hasDefaultEgressIPv6(sg.IpPermissionsEgress) {
+		def.IPv6Ranges = []v1beta1.IPv6Range{{CIDRIPv6: defaultEgressCIDRv6}}
+	}
+	return DiffPermissions([]v1beta1.IPPermission{def}, sg.IpPermissionsEgress)
+}
+
+// A rule is a permission that allows traffic from (or to) a single CIDR
+// block, prefix list, or security group.
+type rule struct {
+	permission  ec2.IpPermission
+	description string
+}
+
+// splitPermissions splits the supplied permissions into rules, keyed by
+// their normalized protocol, ports, and source (or destination).
+func splitPermissions(perms []ec2.IpPermission) map[string]rule {
+	rules := map[string]rule{}
+	for _, p := range perms {
+		base := ec2.IpPermission{IpProtocol: aws.String(normalizeProtocol(aws.StringValue(p.IpProtocol)))}
+		// Ports are ignored by AWS for protocols other than these.
+		switch aws.StringValue(base.IpProtocol) {
+		case "tcp", "udp", "icmp", "icmpv6":
+			base.FromPort, base.ToPort = p.FromPort, p.ToPort
+		}
+		prefix := fmt.Sprintf("%s:%d:%d", aws.StringValue(base.IpProtocol), portValue(base.FromPort), portValue(base.ToPort))
+
+		for _, c := range p.IpRanges {
+			r := base
+			r.IpRanges = []ec2.IpRange{c}
+			rules[prefix+":cidr:"+aws.StringValue(c.CidrIp)] = rule{permission: r, description: aws.StringValue(c.Description)}
+		}
+		for _, c := range p.Ipv6Ranges {
+			r := base
+			r.Ipv6Ranges = []ec2.Ipv6Range{c}
+			rules[prefix+":cidrv6:"+aws.StringValue(c.CidrIpv6)] = rule{permission: r, description: aws.StringValue(c.Description)}
+		}
+		for _, c := range p.PrefixListIds {
+			r := base
+			r.PrefixListIds = []ec2.PrefixListId{c}
+			rules[prefix+":pl:"+aws.StringValue(c.PrefixListId)] = rule{permission: r, description: aws.StringValue(c.Description)}
+		}
+		for _, c := range p.UserIdGroupPairs {
+			r := base
+			r.UserIdGroupPairs = []ec2.UserIdGroupPair{c}
+			group := aws.StringValue(c.GroupId)
+			if group == "" {
+				group = aws.StringValue(c.GroupName)
+			}
+			rules[prefix+":sg:"+group] = rule{permission: r, description: aws.StringValue(c.Description)}
+		}
+	}
+	return rules
+}
+
+// hasDefaultEgressIPv6 returns true if the supplied permissions include the
+// default egress rule that allows all outbound IPv6 traffic.
+func hasDefaultEgressIPv6(perms []ec2.IpPermission) bool {
+	for _, p := range perms {
+		if normalizeProtocol(aws.StringValue(p.IpProtocol)) != allProtocols {
+			continue
+		}
+		for _, c := range p.Ipv6Ranges {
+			if aws.StringValue(c.CidrIpv6) == defaultEgressCIDRv6 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func normalizeProtocol(p string) string {
+	p = strings.ToLower(p)
+	if n, ok := protocols[p]; ok {
+		return n
+	}
+	return p
+}
+
+// portValue returns the supplied port, or -1 (i.e. all ports, or all ICMP
+// types or codes) if it is not set.
+func portValue(p *int64) int64 {
+	if p == nil {
+		return -1
+	}
+	return *p
+}
+
+func sortedRuleKeys(rules map[string]rule) []string {
+	keys := make([]string, 0, len(rules))
+	for k := range rules {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // GenerateSGObservation is used to produce v1beta1.SecurityGroupExternalStatus from
 // ec2.SecurityGroup.
 func GenerateSGObservation(sg ec2.SecurityGroup) v1beta1.SecurityGroupObservation {
 	return v1beta1.SecurityGroupObservation{
 		OwnerID:         aws.StringValue(sg.OwnerId),
 		SecurityGroupID: aws.StringValue(sg.GroupId),
+		Tags:            observedTags(sg.Tags),
 	}
 }
 
 // LateInitializeSG fills the empty fields in *v1beta1.SecurityGroupParameters with
-// the values seen in ec2.SecurityGroup.
-func LateInitializeSG(in *v1beta1.SecurityGroupParameters, sg *ec2.SecurityGroup) { // nolint:gocyclo
+// the values seen in ec2.SecurityGroup. Ingress and egress rules are not late
+// initialized, because rules that are not specified are revoked.
+func LateInitializeSG(in *v1beta1.SecurityGroupParameters, sg *ec2.SecurityGroup) {
 	if sg == nil {
 		return
 	}
@@ -127,14 +405,6 @@ func LateInitializeSG(in *v1beta1.SecurityGroupParameters, sg *ec2.SecurityGroup
 	in.GroupName = awsclients.LateInitializeString(in.GroupName, sg.GroupName)
 	in.VPCID = awsclients.LateInitializeStringPtr(in.VPCID, sg.VpcId)
 
-	if len(in.Egress) == 0 && len(sg.IpPermissionsEgress) != 0 {
-		in.Egress = v1beta1.BuildIPPermissions(sg.IpPermissionsEgress)
-	}
-
-	if len(in.Ingress) == 0 && len(sg.IpPermissions) != 0 {
-		in.Ingress = v1beta1.BuildIPPermissions(sg.IpPermissions)
-	}
-
 	if len(in.Tags) == 0 && len(sg.Tags) != 0 {
 		in.Tags = v1beta1.BuildFromEC2Tags(sg.Tags)
 	}
@@ -148,6 +418,8 @@ func CreateSGPatch(in ec2.SecurityGroup, target v1beta1.SecurityGroupParameters)
 
 	v1beta1.SortTags(target.Tags, in.Tags)
 	LateInitializeSG(currentParams, &in)
+	currentParams.Ingress = GenerateIPPermissions(in.IpPermissions)
+	currentParams.Egress = GenerateIPPermissions(in.IpPermissionsEgress)
 
 	jsonPatch, err := awsclients.CreateJSONPatch(*currentParams, target)
 	if err != nil {
@@ -160,11 +432,40 @@ func CreateSGPatch(in ec2.SecurityGroup, target v1beta1.SecurityGroupParameters)
 	return patch, nil
 }
 
+// sgPatchOptions determine which fields of a SecurityGroupParameters patch
+// must be empty for a security group to be up to date. Ingress and egress
+// rules are compared by DiffIngress and DiffEgress instead, regardless of how
+// they are grouped or ordered.
+var sgPatchOptions = []cmp.Option{
+	cmpopts.IgnoreTypes(&v1alpha1.Reference{}, &v1alpha1.Selector{}),
+	cmpopts.IgnoreFields(v1beta1.SecurityGroupParameters{}, "Ingress", "Egress"),
+}
+
 // IsSGUpToDate checks whether there is a change in any of the modifiable fields.
 func IsSGUpToDate(p v1beta1.SecurityGroupParameters, sg ec2.SecurityGroup) (bool, error) {
 	patch, err := CreateSGPatch(sg, p)
 	if err != nil {
 		return false, err
 	}
-	return cmp.Equal(&v1beta1.SecurityGroupParameters{}, patch, cmpopts.IgnoreTypes(&v1alpha1.Reference{}, &v1alpha1.Selector{})), nil
+	return cmp.Equal(&v1beta1.SecurityGroupParameters{}, patch, sgPatchOptions...) &&
+		DiffIngress(p, sg).Empty() && DiffEgress(p, sg).Empty(), nil
+}
+
+// DiffSG returns the modifiable fields of the supplied parameters that differ
+// from the supplied security group.
+func DiffSG(p v1beta1.SecurityGroupParameters, sg ec2.SecurityGroup) ([]v1alpha3.FieldDrift, error) {
+	patch, err := CreateSGPatch(sg, p)
+	if err != nil {
+		return nil, err
+	}
+	observed := &v1beta1.SecurityGroupParameters{}
+	LateInitializeSG(observed, &sg)
+	drift := awsclients.DiffPatch(observed, patch, sgPatchOptions...)
+	if !DiffIngress(p, sg).Empty() {
+		drift = append(drift, awsclients.NewFieldDrift(awsclients.DriftPathPrefix+".ingress", GenerateIPPermissions(sg.IpPermissions), p.Ingress))
+	}
+	if !DiffEgress(p, sg).Empty() {
+		drift = append(drift, awsclients.NewFieldDrift(awsclients.DriftPathPrefix+".egress", GenerateIPPermissions(sg.IpPermissionsEgress), p.Egress))
+	}
+	return drift, nil
 }
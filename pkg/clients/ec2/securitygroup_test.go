@@ -50,6 +50,19 @@ func sgIPPermission(port int) []ec2.IpPermission {
 	}
 }
 
+func sgDefaultEgress() []ec2.IpPermission {
+	return []ec2.IpPermission{
+		{
+			IpProtocol: aws.String("-1"),
+			IpRanges: []ec2.IpRange{
+				{
+					CidrIp: aws.String("0.0.0.0/0"),
+				},
+			},
+		},
+	}
+}
+
 func TestIsSGUpToDate(t *testing.T) {
 	type args struct {
 		sg ec2.SecurityGroup
@@ -63,10 +76,11 @@ func TestIsSGUpToDate(t *testing.T) {
 		"SameFields": {
 			args: args{
 				sg: ec2.SecurityGroup{
-					Description:   aws.String(sgDesc),
-					GroupName:     aws.String(sgName),
-					VpcId:         aws.String(sgVpc),
-					IpPermissions: sgIPPermission(80),
+					Description:         aws.String(sgDesc),
+					GroupName:           aws.String(sgName),
+					VpcId:               aws.String(sgVpc),
+					IpPermissions:       sgIPPermission(80),
+					IpPermissionsEgress: sgDefaultEgress(),
 				},
 				p: v1beta1.SecurityGroupParameters{
 					Description: sgDesc,
@@ -94,6 +108,38 @@ func TestIsSGUpToDate(t *testing.T) {
 			},
 			want: false,
 		},
+		"DifferentlyGroupedRules": {
+			args: args{
+				sg: ec2.SecurityGroup{
+					VpcId: aws.String(sgVpc),
+					IpPermissions: []ec2.IpPermission{{
+						FromPort:   aws.Int64(80),
+						ToPort:     aws.Int64(80),
+						IpProtocol: aws.String(sgProtocol),
+						IpRanges:   []ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}, {CidrIp: aws.String(sgCidr)}},
+					}},
+					IpPermissionsEgress: sgDefaultEgress(),
+				},
+				p: v1beta1.SecurityGroupParameters{
+					Ingress: append(specIPPermsision(80), v1beta1.IPPermission{
+						FromPort:   aws.Int64(80),
+						ToPort:     aws.Int64(80),
+						IPProtocol: "6",
+						IPRanges:   []v1beta1.IPRange{{CIDRIP: "10.0.0.0/8"}},
+					}),
+				},
+			},
+			want: true,
+		},
+		"ExtraEgressRule": {
+			args: args{
+				sg: ec2.SecurityGroup{
+					VpcId:               aws.String(sgVpc),
+					IpPermissionsEgress: append(sgDefaultEgress(), sgIPPermission(80)...),
+				},
+			},
+			want: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -211,3 +257,238 @@ func TestCreateSGPatch(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffPermissions(t *testing.T) {
+	type args struct {
+		desired  []v1beta1.IPPermission
+		observed []ec2.IpPermission
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   PermissionsDiff
+	}{
+		"UpToDate": {
+			reason: "No changes should be made to rules that match the desired rules.",
+			args: args{
+				desired:  specIPPermsision(80),
+				observed: sgIPPermission(80),
+			},
+			want: PermissionsDiff{},
+		},
+		"AllProtocols": {
+			reason: "Ports should be ignored for rules that allow all protocols.",
+			args: args{
+				desired: []v1beta1.IPPermission{{
+					FromPort:   aws.Int64(0),
+					ToPort:     aws.Int64(65535),
+					IPProtocol: "all",
+					IPRanges:   []v1beta1.IPRange{{CIDRIP: sgCidr}},
+				}},
+				observed: []ec2.IpPermission{{
+					IpProtocol: aws.String("-1"),
+					IpRanges:   []ec2.IpRange{{CidrIp: aws.String(sgCidr)}},
+				}},
+			},
+			want: PermissionsDiff{},
+		},
+		"AuthorizeAndRevoke": {
+			reason: "Missing rules should be authorized, and extra rules revoked.",
+			args: args{
+				desired:  specIPPermsision(80),
+				observed: sgIPPermission(100),
+			},
+			want: PermissionsDiff{
+				Authorize: sgIPPermission(80),
+				Revoke:    sgIPPermission(100),
+			},
+		},
+		"SplitSources": {
+			reason: "Rules should be compared per source, so that only the missing and extra sources are authorized and revoked.",
+			args: args{
+				desired: []v1beta1.IPPermission{{
+					FromPort:         aws.Int64(443),
+					ToPort:           aws.Int64(443),
+					IPProtocol:       sgProtocol,
+					PrefixListIDs:    []v1beta1.PrefixListID{{PrefixListID: "pl-1"}},
+					UserIDGroupPairs: []v1beta1.UserIDGroupPair{{GroupID: aws.String("sg-1")}},
+				}},
+				observed: []ec2.IpPermission{{
+					FromPort:         aws.Int64(443),
+					ToPort:           aws.Int64(443),
+					IpProtocol:       aws.String(sgProtocol),
+					Ipv6Ranges:       []ec2.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+					UserIdGroupPairs: []ec2.UserIdGroupPair{{GroupId: aws.String("sg-1"), UserId: aws.String(sgOwner)}},
+				}},
+			},
+			want: PermissionsDiff{
+				Authorize: []ec2.IpPermission{{
+					FromPort:      aws.Int64(443),
+					ToPort:        aws.Int64(443),
+					IpProtocol:    aws.String(sgProtocol),
+					PrefixListIds: []ec2.PrefixListId{{PrefixListId: aws.String("pl-1")}},
+				}},
+				Revoke: []ec2.IpPermission{{
+					FromPort:   aws.Int64(443),
+					ToPort:     aws.Int64(443),
+					IpProtocol: aws.String(sgProtocol),
+					Ipv6Ranges: []ec2.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+				}},
+			},
+		},
+		"Description": {
+			reason: "Rules whose description differs should have their description updated.",
+			args: args{
+				desired: []v1beta1.IPPermission{{
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					IPProtocol: sgProtocol,
+					IPRanges:   []v1beta1.IPRange{{CIDRIP: sgCidr, Description: aws.String(sgDesc)}},
+				}},
+				observed: sgIPPermission(80),
+			},
+			want: PermissionsDiff{
+				Describe: []ec2.IpPermission{{
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					IpProtocol: aws.String(sgProtocol),
+					IpRanges:   []ec2.IpRange{{CidrIp: aws.String(sgCidr), Description: aws.String(sgDesc)}},
+				}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DiffPermissions(tc.args.desired, tc.args.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiffPermissions(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHasPeerGroupNames(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		perms  []v1beta1.IPPermission
+		want   bool
+	}{
+		"GroupName": {
+			reason: "A peer security group identified only by name should be detected.",
+			perms: []v1beta1.IPPermission{{
+				IPProtocol:       "tcp",
+				UserIDGroupPairs: []v1beta1.UserIDGroupPair{{GroupID: aws.String("sg-1")}, {GroupName: aws.String("cool")}},
+			}},
+			want: true,
+		},
+		"GroupIDAndName": {
+			reason: "A peer security group identified by ID should not be detected, even if it is also named.",
+			perms: []v1beta1.IPPermission{{
+				IPProtocol:       "tcp",
+				UserIDGroupPairs: []v1beta1.UserIDGroupPair{{GroupID: aws.String("sg-1"), GroupName: aws.String("cool")}},
+			}},
+			want: false,
+		},
+		"NoPeers": {
+			reason: "Permissions without peer security groups should not be detected.",
+			perms:  specIPPermsision(80),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := HasPeerGroupNames(tc.perms)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nHasPeerGroupNames(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDiffEgress(t *testing.T) {
+	defaultIPv6 := ec2.IpPermission{
+		IpProtocol: aws.String("-1"),
+		Ipv6Ranges: []ec2.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+	}
+
+	type args struct {
+		p  v1beta1.SecurityGroupParameters
+		sg ec2.SecurityGroup
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   PermissionsDiff
+	}{
+		"NotInVPC": {
+			reason: "Egress rules of security groups that are not in a VPC should not be changed.",
+			args: args{
+				p: v1beta1.SecurityGroupParameters{Egress: specIPPermsision(80)},
+			},
+			want: PermissionsDiff{},
+		},
+		"KeepDefault": {
+			reason: "The default egress rules should be kept when no egress rules are specified.",
+			args: args{
+				sg: ec2.SecurityGroup{
+					VpcId:               aws.String(sgVpc),
+					IpPermissionsEgress: append(sgDefaultEgress(), defaultIPv6),
+				},
+			},
+			want: PermissionsDiff{},
+		},
+		"RestoreDefault": {
+			reason: "The default egress rule should be authorized, and other rules revoked, when no egress rules are specified.",
+			args: args{
+				sg: ec2.SecurityGroup{
+					VpcId:               aws.String(sgVpc),
+					IpPermissionsEgress: sgIPPermission(80),
+				},
+			},
+			want: PermissionsDiff{
+				Authorize: sgDefaultEgress(),
+				Revoke:    sgIPPermission(80),
+			},
+		},
+		"RevokeDefault": {
+			reason: "The default egress rules should be revoked when other egress rules are specified.",
+			args: args{
+				p: v1beta1.SecurityGroupParameters{Egress: specIPPermsision(80)},
+				sg: ec2.SecurityGroup{
+					VpcId:               aws.String(sgVpc),
+					IpPermissionsEgress: append(sgDefaultEgress(), defaultIPv6),
+				},
+			},
+			want: PermissionsDiff{
+				Authorize: sgIPPermission(80),
+				Revoke:    append(sgDefaultEgress(), defaultIPv6),
+			},
+		},
+		"IgnoreUnspecified": {
+			reason: "No egress rules should be revoked when unspecified rules are ignored.",
+			args: args{
+				p: v1beta1.SecurityGroupParameters{Egress: specIPPermsision(80), IgnoreUnspecifiedRules: aws.Bool(true)},
+				sg: ec2.SecurityGroup{
+					VpcId:               aws.String(sgVpc),
+					IpPermissionsEgress: sgDefaultEgress(),
+				},
+			},
+			want: PermissionsDiff{
+				Authorize: sgIPPermission(80),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DiffEgress(tc.args.p, tc.args.sg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiffEgress(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+)
+
+// GenerateSGRulePermission returns the permission that allows the traffic
+// described by the supplied security group rule parameters.
+func GenerateSGRulePermission(p v1alpha4.SecurityGroupRuleParameters) ec2.IpPermission {
+	perm := ec2.IpPermission{
+		FromPort:   p.FromPort,
+		IpProtocol: aws.String(p.IPProtocol),
+		ToPort:     p.ToPort,
+	}
+	switch {
+	case p.CIDRIP != nil:
+		perm.IpRanges = []ec2.IpRange{{CidrIp: p.CIDRIP, Description: p.Description}}
+	case p.CIDRIPv6 != nil:
+		perm.Ipv6Ranges = []ec2.Ipv6Range{{CidrIpv6: p.CIDRIPv6, Description: p.Description}}
+	case p.PrefixListID != nil:
+		perm.PrefixListIds = []ec2.PrefixListId{{PrefixListId: p.PrefixListID, Description: p.Description}}
+	case p.SourceSecurityGroupID != nil:
+		perm.UserIdGroupPairs = []ec2.UserIdGroupPair{{
+			GroupId:     p.SourceSecurityGroupID,
+			UserId:      p.SourceSecurityGroupOwnerID,
+			Description: p.Description,
+		}}
+	}
+	return perm
+}
+
+// FindSGRule returns the rule of the supplied permissions that allows the
+// same traffic as the supplied security group rule parameters, and whether
+// there is such a rule. The returned rule allows traffic from (or to) a single
+// source, as the parameters do.
+func FindSGRule(perms []ec2.IpPermission, p v1alpha4.SecurityGroupRuleParameters) (ec2.IpPermission, bool) {
+	rules := splitPermissions(perms)
+	for k := range splitPermissions([]ec2.IpPermission{GenerateSGRulePermission(p)}) {
+		r, ok := rules[k]
+		return r.permission, ok
+	}
+	return ec2.IpPermission{}, false
+}
+
+// IsSGRuleUpToDate returns true if the description of the supplied rule,
+// which is the only modifiable field of a security group rule, matches the
+// supplied parameters.
+func IsSGRuleUpToDate(p v1alpha4.SecurityGroupRuleParameters, rule ec2.IpPermission) bool {
+	for _, r := range splitPermissions([]ec2.IpPermission{rule}) {
+		return r.description == aws.StringValue(p.Description)
+	}
+	return false
+}
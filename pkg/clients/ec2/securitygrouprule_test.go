@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+)
+
+func TestFindSGRule(t *testing.T) {
+	type args struct {
+		perms []ec2.IpPermission
+		p     v1alpha4.SecurityGroupRuleParameters
+	}
+	type want struct {
+		rule  ec2.IpPermission
+		found bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"CIDR": {
+			reason: "A rule that allows traffic from a CIDR block should be found among the other sources of its permission.",
+			args: args{
+				perms: []ec2.IpPermission{{
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					IpProtocol: aws.String(sgProtocol),
+					IpRanges:   []ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}, {CidrIp: aws.String(sgCidr), Description: aws.String(sgDesc)}},
+				}},
+				p: v1alpha4.SecurityGroupRuleParameters{
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					IPProtocol: "6",
+					CIDRIP:     aws.String(sgCidr),
+				},
+			},
+			want: want{
+				rule: ec2.IpPermission{
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					IpProtocol: aws.String(sgProtocol),
+					IpRanges:   []ec2.IpRange{{CidrIp: aws.String(sgCidr), Description: aws.String(sgDesc)}},
+				},
+				found: true,
+			},
+		},
+		"SourceSecurityGroup": {
+			reason: "A rule that allows traffic from a security group should be found regardless of its owner.",
+			args: args{
+				perms: []ec2.IpPermission{{
+					IpProtocol:       aws.String("-1"),
+					UserIdGroupPairs: []ec2.UserIdGroupPair{{GroupId: aws.String(sgID), UserId: aws.String(sgOwner)}},
+				}},
+				p: v1alpha4.SecurityGroupRuleParameters{
+					IPProtocol:            "-1",
+					SourceSecurityGroupID: aws.String(sgID),
+				},
+			},
+			want: want{
+				rule: ec2.IpPermission{
+					IpProtocol:       aws.String("-1"),
+					UserIdGroupPairs: []ec2.UserIdGroupPair{{GroupId: aws.String(sgID), UserId: aws.String(sgOwner)}},
+				},
+				found: true,
+			},
+		},
+		"NotFound": {
+			reason: "A rule with different ports should not be found.",
+			args: args{
+				perms: sgIPPermission(80),
+				p: v1alpha4.SecurityGroupRuleParameters{
+					FromPort:   aws.Int64(100),
+					ToPort:     aws.Int64(100),
+					IPProtocol: sgProtocol,
+					CIDRIP:     aws.String(sgCidr),
+				},
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rule, found := FindSGRule(tc.args.perms, tc.args.p)
+			if diff := cmp.Diff(tc.want.rule, rule); diff != "" {
+				t.Errorf("\n%s\nFindSGRule(...): -want rule, +got rule:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.found, found); diff != "" {
+				t.Errorf("\n%s\nFindSGRule(...): -want found, +got found:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsSGRuleUpToDate(t *testing.T) {
+	p := v1alpha4.SecurityGroupRuleParameters{
+		FromPort:    aws.Int64(80),
+		ToPort:      aws.Int64(80),
+		IPProtocol:  sgProtocol,
+		CIDRIP:      aws.String(sgCidr),
+		Description: aws.String(sgDesc),
+	}
+
+	cases := map[string]struct {
+		reason string
+		rule   ec2.IpPermission
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A rule with the desired description should be up to date.",
+			rule:   GenerateSGRulePermission(p),
+			want:   true,
+		},
+		"DifferentDescription": {
+			reason: "A rule with a different description should not be up to date.",
+			rule:   sgIPPermission(80)[0],
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsSGRuleUpToDate(p, tc.rule)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsSGRuleUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -1,8 +1,6 @@
 package ec2
 
 import (
-	"context"
-
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/awserr"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -23,15 +21,12 @@ type SubnetClient interface {
 	DeleteSubnetRequest(input *ec2.DeleteSubnetInput) ec2.DeleteSubnetRequest
 	ModifySubnetAttributeRequest(input *ec2.ModifySubnetAttributeInput) ec2.ModifySubnetAttributeRequest
 	CreateTagsRequest(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	DeleteTagsRequest(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
 }
 
-// NewSubnetClient returns a new client using AWS credentials as JSON encoded data.
-func NewSubnetClient(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (SubnetClient, error) {
-	cfg, err := auth(ctx, credentials, awsclients.DefaultSection, region)
-	if cfg == nil {
-		return nil, err
-	}
-	return ec2.New(*cfg), nil
+// NewSubnetClient returns a new client using the supplied AWS configuration.
+func NewSubnetClient(cfg *aws.Config) SubnetClient {
+	return ec2.New(*cfg)
 }
 
 // IsSubnetNotFoundErr returns true if the error is because the item doesn't exist
@@ -53,6 +48,7 @@ func GenerateSubnetObservation(subnet ec2.Subnet) v1beta1.SubnetObservation {
 		DefaultForAZ:            aws.BoolValue(subnet.DefaultForAz),
 		SubnetID:                aws.StringValue(subnet.SubnetId),
 		SubnetState:             string(subnet.State),
+		Tags:                    observedTags(subnet.Tags),
 	}
 
 	v, err := subnet.State.MarshalValue()
@@ -96,5 +92,5 @@ func IsSubnetUpToDate(p v1beta1.SubnetParameters, s ec2.Subnet) bool {
 		return false
 	}
 
-	return v1beta1.CompareTags(p.Tags, s.Tags)
+	return tagsUpToDate(p.Tags, s.Tags)
 }
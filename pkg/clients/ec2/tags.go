@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+const (
+	errCreateTags = "cannot create tags"
+	errDeleteTags = "cannot delete tags"
+)
+
+// A TagClient can create and delete the tags of EC2 resources.
+type TagClient interface {
+	CreateTagsRequest(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	DeleteTagsRequest(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
+}
+
+// observedTags returns the supplied EC2 tags sorted by key, or nil if there
+// are none.
+func observedTags(tags []ec2.Tag) []v1beta1.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+	var o []v1beta1.Tag
+	awsclients.SetTagMap(&o, awsclients.TagMap(v1beta1.BuildFromEC2Tags(tags)))
+	return o
+}
+
+// DiffTags returns the tags that must be created, because they were added or
+// their values were changed, and the tags that must be deleted, because they
+// were removed, in order for the observed tags to match the desired tags.
+// Both are sorted by key. Tags that are reserved by AWS are never deleted.
+func DiffTags(desired, observed []v1beta1.Tag) (create []ec2.Tag, remove []ec2.Tag) {
+	add, keys := awsclients.DiffTags(awsclients.TagMap(desired), awsclients.TagMap(observed))
+	if len(add) != 0 {
+		awsclients.SetTagMap(&create, add)
+	}
+	for _, k := range keys {
+		remove = append(remove, ec2.Tag{Key: aws.String(k)})
+	}
+	return create, remove
+}
+
+// tagsUpToDate returns true if the observed EC2 tags match the desired tags,
+// i.e. if no tags must be created or deleted.
+func tagsUpToDate(desired []v1beta1.Tag, observed []ec2.Tag) bool {
+	add, remove := awsclients.DiffTags(awsclients.TagMap(desired), awsclients.TagMap(observed))
+	return len(add) == 0 && len(remove) == 0
+}
+
+// UpdateTags creates and deletes the tags of the EC2 resource with the
+// supplied ID such that its observed tags match the desired tags.
+func UpdateTags(ctx context.Context, c TagClient, id string, desired, observed []v1beta1.Tag) error {
+	create, remove := DiffTags(desired, observed)
+	if len(remove) != 0 {
+		if _, err := c.DeleteTagsRequest(&ec2.DeleteTagsInput{
+			Resources: []string{id},
+			Tags:      remove,
+		}).Send(ctx); err != nil {
+			return errors.Wrap(err, errDeleteTags)
+		}
+	}
+	if len(create) != 0 {
+		if _, err := c.CreateTagsRequest(&ec2.CreateTagsInput{
+			Resources: []string{id},
+			Tags:      create,
+		}).Send(ctx); err != nil {
+			return errors.Wrap(err, errCreateTags)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+)
+
+func TestDiffTags(t *testing.T) {
+	type args struct {
+		desired  []v1beta1.Tag
+		observed []v1beta1.Tag
+	}
+	type want struct {
+		create []ec2.Tag
+		remove []ec2.Tag
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"UpToDate": {
+			reason: "No tags should be created or deleted when the observed tags match the desired tags.",
+			args: args{
+				desired:  []v1beta1.Tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+				observed: []v1beta1.Tag{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}},
+			},
+		},
+		"Added": {
+			reason: "Tags that were added should be created.",
+			args: args{
+				desired:  []v1beta1.Tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+				observed: []v1beta1.Tag{{Key: "a", Value: "1"}},
+			},
+			want: want{
+				create: []ec2.Tag{{Key: aws.String("b"), Value: aws.String("2")}},
+			},
+		},
+		"Updated": {
+			reason: "Tags whose values were changed should be created.",
+			args: args{
+				desired:  []v1beta1.Tag{{Key: "a", Value: "2"}},
+				observed: []v1beta1.Tag{{Key: "a", Value: "1"}},
+			},
+			want: want{
+				create: []ec2.Tag{{Key: aws.String("a"), Value: aws.String("2")}},
+			},
+		},
+		"Removed": {
+			reason: "Tags that were removed should be deleted regardless of their values.",
+			args: args{
+				desired:  []v1beta1.Tag{{Key: "a", Value: "1"}},
+				observed: []v1beta1.Tag{{Key: "c", Value: "3"}, {Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+			},
+			want: want{
+				remove: []ec2.Tag{{Key: aws.String("b")}, {Key: aws.String("c")}},
+			},
+		},
+		"Reserved": {
+			reason: "Tags reserved by AWS should never be deleted.",
+			args: args{
+				desired:  []v1beta1.Tag{{Key: "a", Value: "1"}},
+				observed: []v1beta1.Tag{{Key: "a", Value: "1"}, {Key: "aws:cloudformation:stack-name", Value: "cool"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			create, remove := DiffTags(tc.args.desired, tc.args.observed)
+			if diff := cmp.Diff(tc.want.create, create); diff != "" {
+				t.Errorf("\n%s\nDiffTags(...): -want create, +got create:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.remove, remove); diff != "" {
+				t.Errorf("\n%s\nDiffTags(...): -want remove, +got remove:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTagsUpToDate(t *testing.T) {
+	type args struct {
+		desired  []v1beta1.Tag
+		observed []ec2.Tag
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "Tags should be up to date when the observed tags match the desired tags.",
+			args: args{
+				desired:  []v1beta1.Tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+				observed: []ec2.Tag{{Key: aws.String("b"), Value: aws.String("2")}, {Key: aws.String("a"), Value: aws.String("1")}},
+			},
+			want: true,
+		},
+		"Changed": {
+			reason: "Tags should not be up to date when an observed tag has a different value.",
+			args: args{
+				desired:  []v1beta1.Tag{{Key: "a", Value: "2"}},
+				observed: []ec2.Tag{{Key: aws.String("a"), Value: aws.String("1")}},
+			},
+			want: false,
+		},
+		"Removed": {
+			reason: "Tags should not be up to date when a tag must be deleted.",
+			args: args{
+				observed: []ec2.Tag{{Key: aws.String("a"), Value: aws.String("1")}},
+			},
+			want: false,
+		},
+		"Reserved": {
+			reason: "Tags reserved by AWS should not make tags out of date, since they are never deleted.",
+			args: args{
+				desired:  []v1beta1.Tag{{Key: "a", Value: "1"}},
+				observed: []ec2.Tag{{Key: aws.String("a"), Value: aws.String("1")}, {Key: aws.String("aws:cloudformation:stack-name"), Value: aws.String("cool")}},
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tagsUpToDate(tc.args.desired, tc.args.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ntagsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type mockTagClient struct {
+	MockCreateTagsRequest func(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	MockDeleteTagsRequest func(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
+}
+
+func (m *mockTagClient) CreateTagsRequest(input *ec2.CreateTagsInput) ec2.CreateTagsRequest {
+	return m.MockCreateTagsRequest(input)
+}
+
+func (m *mockTagClient) DeleteTagsRequest(input *ec2.DeleteTagsInput) ec2.DeleteTagsRequest {
+	return m.MockDeleteTagsRequest(input)
+}
+
+func TestUpdateTags(t *testing.T) {
+	errBoom := errors.New("boom")
+	createTags := func(err error) func(*ec2.CreateTagsInput) ec2.CreateTagsRequest {
+		return func(*ec2.CreateTagsInput) ec2.CreateTagsRequest {
+			return ec2.CreateTagsRequest{
+				Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &ec2.CreateTagsOutput{}, Error: err},
+			}
+		}
+	}
+	deleteTags := func(err error) func(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest {
+		return func(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest {
+			return ec2.DeleteTagsRequest{
+				Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &ec2.DeleteTagsOutput{}, Error: err},
+			}
+		}
+	}
+	desired := []v1beta1.Tag{{Key: "a", Value: "1"}}
+	observed := []v1beta1.Tag{{Key: "b", Value: "2"}}
+
+	cases := map[string]struct {
+		reason string
+		c      TagClient
+		want   error
+	}{
+		"Successful": {
+			reason: "Tags should be created and deleted.",
+			c:      &mockTagClient{MockCreateTagsRequest: createTags(nil), MockDeleteTagsRequest: deleteTags(nil)},
+		},
+		"CreateTagsError": {
+			reason: "Errors creating tags should be returned.",
+			c:      &mockTagClient{MockCreateTagsRequest: createTags(errBoom), MockDeleteTagsRequest: deleteTags(nil)},
+			want:   errors.Wrap(errBoom, errCreateTags),
+		},
+		"DeleteTagsError": {
+			reason: "Errors deleting tags should be returned.",
+			c:      &mockTagClient{MockDeleteTagsRequest: deleteTags(errBoom)},
+			want:   errors.Wrap(errBoom, errDeleteTags),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := UpdateTags(context.Background(), tc.c, "vpc-1", desired, observed)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateTags(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -1,8 +1,6 @@
 package ec2
 
 import (
-	"context"
-
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/awserr"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -24,16 +22,15 @@ type VPCClient interface {
 	DescribeVpcAttributeRequest(*ec2.DescribeVpcAttributeInput) ec2.DescribeVpcAttributeRequest
 	ModifyVpcAttributeRequest(*ec2.ModifyVpcAttributeInput) ec2.ModifyVpcAttributeRequest
 	CreateTagsRequest(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	DeleteTagsRequest(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
 	ModifyVpcTenancyRequest(*ec2.ModifyVpcTenancyInput) ec2.ModifyVpcTenancyRequest
+	AssociateVpcCidrBlockRequest(*ec2.AssociateVpcCidrBlockInput) ec2.AssociateVpcCidrBlockRequest
+	DisassociateVpcCidrBlockRequest(*ec2.DisassociateVpcCidrBlockInput) ec2.DisassociateVpcCidrBlockRequest
 }
 
-// NewVpcClient returns a new client using AWS credentials as JSON encoded data.
-func NewVpcClient(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (VPCClient, error) {
-	cfg, err := auth(ctx, credentials, awsclients.DefaultSection, region)
-	if cfg == nil {
-		return nil, err
-	}
-	return ec2.New(*cfg), nil
+// NewVpcClient returns a new client using the supplied AWS configuration.
+func NewVpcClient(cfg *aws.Config) VPCClient {
+	return ec2.New(*cfg)
 }
 
 // IsVPCNotFoundErr returns true if the error is because the item doesn't exist
@@ -59,7 +56,11 @@ func IsVpcUpToDate(spec v1beta1.VPCParameters, vpc ec2.Vpc, attributes ec2.Descr
 		return false
 	}
 
-	return v1beta1.CompareTags(spec.Tags, vpc.Tags)
+	if !DiffCIDRBlocks(spec, GenerateVpcObservation(vpc)).Empty() {
+		return false
+	}
+
+	return tagsUpToDate(spec.Tags, vpc.Tags)
 }
 
 // GenerateVpcObservation is used to produce v1beta1.VPCObservation from
@@ -70,6 +71,7 @@ func GenerateVpcObservation(vpc ec2.Vpc) v1beta1.VPCObservation {
 		DHCPOptionsID: aws.StringValue(vpc.DhcpOptionsId),
 		OwnerID:       aws.StringValue(vpc.OwnerId),
 		VPCState:      string(vpc.State),
+		Tags:          observedTags(vpc.Tags),
 	}
 
 	if len(vpc.CidrBlockAssociationSet) > 0 {
@@ -114,4 +116,105 @@ func LateInitializeVPC(in *v1beta1.VPCParameters, v *ec2.Vpc) { // nolint:gocycl
 
 	in.CIDRBlock = awsclients.LateInitializeString(in.CIDRBlock, v.CidrBlock)
 	in.InstanceTenancy = awsclients.LateInitializeStringPtr(in.InstanceTenancy, aws.String(string(v.InstanceTenancy)))
+
+	if in.SecondaryCIDRBlocks == nil {
+		for _, a := range v.CidrBlockAssociationSet {
+			if aws.StringValue(a.CidrBlock) == in.CIDRBlock || a.CidrBlockState == nil || !isActiveCIDRBlockState(string(a.CidrBlockState.State)) {
+				continue
+			}
+			in.SecondaryCIDRBlocks = append(in.SecondaryCIDRBlocks, aws.StringValue(a.CidrBlock))
+		}
+	}
+
+	for _, a := range v.Ipv6CidrBlockAssociationSet {
+		if a.Ipv6CidrBlockState != nil && isActiveCIDRBlockState(string(a.Ipv6CidrBlockState.State)) {
+			in.AmazonProvidedIPv6CIDRBlock = awsclients.LateInitializeBoolPtr(in.AmazonProvidedIPv6CIDRBlock, aws.Bool(true))
+		}
+	}
+}
+
+// A CIDRBlocksDiff describes the changes that must be made to the CIDR blocks
+// associated with a VPC for them to match the desired CIDR blocks.
+type CIDRBlocksDiff struct {
+	// Associate are the secondary IPv4 CIDR blocks that must be associated.
+	Associate []string
+
+	// AssociateIPv6 is true if an Amazon-provided IPv6 CIDR block must be
+	// associated.
+	AssociateIPv6 bool
+
+	// Disassociate are the IDs of the IPv4 and IPv6 CIDR block associations
+	// that must be disassociated.
+	Disassociate []string
+}
+
+// Empty returns true if no changes need to be made to the CIDR blocks of the
+// VPC.
+func (d CIDRBlocksDiff) Empty() bool {
+	return len(d.Associate) == 0 && !d.AssociateIPv6 && len(d.Disassociate) == 0
+}
+
+// DiffCIDRBlocks returns the changes that must be made to the observed CIDR
+// block associations of a VPC for them to match the supplied parameters. The
+// primary CIDR block of the VPC is never disassociated, the secondary IPv4
+// CIDR blocks are left unchanged unless SecondaryCIDRBlocks is set, and the
+// IPv6 CIDR blocks are left unchanged unless AmazonProvidedIPv6CIDRBlock is
+// set.
+func DiffCIDRBlocks(p v1beta1.VPCParameters, o v1beta1.VPCObservation) CIDRBlocksDiff {
+	d := CIDRBlocksDiff{}
+	if p.SecondaryCIDRBlocks != nil {
+		d = diffSecondaryCIDRBlocks(p, o)
+	}
+
+	if p.AmazonProvidedIPv6CIDRBlock == nil {
+		return d
+	}
+	hasIPv6 := false
+	for _, a := range o.IPv6CIDRBlockAssociationSet {
+		if !isActiveCIDRBlockState(a.IPv6CIDRBlockState.State) {
+			continue
+		}
+		hasIPv6 = true
+		if !aws.BoolValue(p.AmazonProvidedIPv6CIDRBlock) {
+			d.Disassociate = append(d.Disassociate, a.AssociationID)
+		}
+	}
+	d.AssociateIPv6 = aws.BoolValue(p.AmazonProvidedIPv6CIDRBlock) && !hasIPv6
+	return d
+}
+
+// diffSecondaryCIDRBlocks returns the changes that must be made to the
+// observed secondary IPv4 CIDR block associations of a VPC.
+func diffSecondaryCIDRBlocks(p v1beta1.VPCParameters, o v1beta1.VPCObservation) CIDRBlocksDiff {
+	d := CIDRBlocksDiff{}
+	wanted := map[string]bool{}
+	for _, b := range p.SecondaryCIDRBlocks {
+		wanted[b] = true
+	}
+	associated := map[string]bool{}
+	for _, a := range o.CIDRBlockAssociationSet {
+		if a.CIDRBlock == p.CIDRBlock || !isActiveCIDRBlockState(a.CIDRBlockState.State) {
+			continue
+		}
+		associated[a.CIDRBlock] = true
+		if !wanted[a.CIDRBlock] {
+			d.Disassociate = append(d.Disassociate, a.AssociationID)
+		}
+	}
+	for _, b := range p.SecondaryCIDRBlocks {
+		if !associated[b] {
+			d.Associate = append(d.Associate, b)
+		}
+	}
+	return d
+}
+
+// isActiveCIDRBlockState returns true if a CIDR block in the supplied state
+// is, or is being, associated with a VPC.
+func isActiveCIDRBlockState(s string) bool {
+	switch ec2.VpcCidrBlockStateCode(s) {
+	case ec2.VpcCidrBlockStateCodeAssociating, ec2.VpcCidrBlockStateCodeAssociated:
+		return true
+	}
+	return false
 }
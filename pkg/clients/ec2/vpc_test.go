@@ -1,6 +1,7 @@
 package ec2
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -56,3 +57,221 @@ func TestGenerateVPCObservation(t *testing.T) {
 		})
 	}
 }
+
+func TestLateInitializeVPC(t *testing.T) {
+	primary := "10.0.0.0/16"
+	associated := &ec2.VpcCidrBlockState{State: ec2.VpcCidrBlockStateCodeAssociated}
+	disassociated := &ec2.VpcCidrBlockState{State: ec2.VpcCidrBlockStateCodeDisassociated}
+
+	type args struct {
+		p v1beta1.VPCParameters
+		v *ec2.Vpc
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   v1beta1.VPCParameters
+	}{
+		"SecondaryCIDRBlocks": {
+			reason: "Unset secondary CIDR blocks should be initialized with the associated secondary CIDR blocks.",
+			args: args{
+				p: v1beta1.VPCParameters{CIDRBlock: primary},
+				v: &ec2.Vpc{
+					CidrBlock:       aws.String(primary),
+					InstanceTenancy: ec2.TenancyDefault,
+					CidrBlockAssociationSet: []ec2.VpcCidrBlockAssociation{
+						{CidrBlock: aws.String(primary), CidrBlockState: associated},
+						{CidrBlock: aws.String("100.64.0.0/16"), CidrBlockState: associated},
+						{CidrBlock: aws.String("100.65.0.0/16"), CidrBlockState: disassociated},
+					},
+				},
+			},
+			want: v1beta1.VPCParameters{CIDRBlock: primary, InstanceTenancy: aws.String("default"), SecondaryCIDRBlocks: []string{"100.64.0.0/16"}},
+		},
+		"EmptySecondaryCIDRBlocks": {
+			reason: "Empty secondary CIDR blocks should not be initialized with the associated secondary CIDR blocks.",
+			args: args{
+				p: v1beta1.VPCParameters{CIDRBlock: primary, SecondaryCIDRBlocks: []string{}},
+				v: &ec2.Vpc{
+					CidrBlock:       aws.String(primary),
+					InstanceTenancy: ec2.TenancyDefault,
+					CidrBlockAssociationSet: []ec2.VpcCidrBlockAssociation{
+						{CidrBlock: aws.String("100.64.0.0/16"), CidrBlockState: associated},
+					},
+				},
+			},
+			want: v1beta1.VPCParameters{CIDRBlock: primary, InstanceTenancy: aws.String("default"), SecondaryCIDRBlocks: []string{}},
+		},
+		"SetSecondaryCIDRBlocks": {
+			reason: "Set secondary CIDR blocks should not be changed.",
+			args: args{
+				p: v1beta1.VPCParameters{CIDRBlock: primary, SecondaryCIDRBlocks: []string{"100.65.0.0/16"}},
+				v: &ec2.Vpc{
+					CidrBlock:       aws.String(primary),
+					InstanceTenancy: ec2.TenancyDefault,
+					CidrBlockAssociationSet: []ec2.VpcCidrBlockAssociation{
+						{CidrBlock: aws.String("100.64.0.0/16"), CidrBlockState: associated},
+					},
+				},
+			},
+			want: v1beta1.VPCParameters{CIDRBlock: primary, InstanceTenancy: aws.String("default"), SecondaryCIDRBlocks: []string{"100.65.0.0/16"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeVPC(&tc.args.p, tc.args.v)
+			if diff := cmp.Diff(tc.want, tc.args.p); diff != "" {
+				t.Errorf("\n%s\nLateInitializeVPC(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDiffCIDRBlocks(t *testing.T) {
+	primary := "10.0.0.0/16"
+	associated := v1beta1.VPCCIDRBlockState{State: string(ec2.VpcCidrBlockStateCodeAssociated)}
+	disassociated := v1beta1.VPCCIDRBlockState{State: string(ec2.VpcCidrBlockStateCodeDisassociated)}
+
+	type args struct {
+		p v1beta1.VPCParameters
+		o v1beta1.VPCObservation
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   CIDRBlocksDiff
+	}{
+		"UpToDate": {
+			reason: "No changes should be made to CIDR blocks that match the desired CIDR blocks.",
+			args: args{
+				p: v1beta1.VPCParameters{CIDRBlock: primary, SecondaryCIDRBlocks: []string{"100.64.0.0/16"}, AmazonProvidedIPv6CIDRBlock: aws.Bool(true)},
+				o: v1beta1.VPCObservation{
+					CIDRBlockAssociationSet: []v1beta1.VPCCIDRBlockAssociation{
+						{AssociationID: "a-1", CIDRBlock: primary, CIDRBlockState: associated},
+						{AssociationID: "a-2", CIDRBlock: "100.64.0.0/16", CIDRBlockState: associated},
+					},
+					IPv6CIDRBlockAssociationSet: []v1beta1.VPCIPv6CidrBlockAssociation{
+						{AssociationID: "a-3", IPv6CIDRBlock: "2600:1f16::/56", IPv6CIDRBlockState: associated},
+					},
+				},
+			},
+			want: CIDRBlocksDiff{},
+		},
+		"Changes": {
+			reason: "Missing CIDR blocks should be associated, and extra CIDR blocks disassociated, but never the primary CIDR block.",
+			args: args{
+				p: v1beta1.VPCParameters{CIDRBlock: primary, SecondaryCIDRBlocks: []string{"100.64.0.0/16", "100.65.0.0/16"}, AmazonProvidedIPv6CIDRBlock: aws.Bool(true)},
+				o: v1beta1.VPCObservation{
+					CIDRBlockAssociationSet: []v1beta1.VPCCIDRBlockAssociation{
+						{AssociationID: "a-1", CIDRBlock: primary, CIDRBlockState: associated},
+						{AssociationID: "a-2", CIDRBlock: "100.64.0.0/16", CIDRBlockState: associated},
+						{AssociationID: "a-3", CIDRBlock: "100.65.0.0/16", CIDRBlockState: disassociated},
+						{AssociationID: "a-4", CIDRBlock: "100.66.0.0/16", CIDRBlockState: associated},
+					},
+					IPv6CIDRBlockAssociationSet: []v1beta1.VPCIPv6CidrBlockAssociation{
+						{AssociationID: "a-5", IPv6CIDRBlock: "2600:1f16::/56", IPv6CIDRBlockState: disassociated},
+					},
+				},
+			},
+			want: CIDRBlocksDiff{
+				Associate:     []string{"100.65.0.0/16"},
+				AssociateIPv6: true,
+				Disassociate:  []string{"a-4"},
+			},
+		},
+		"DisassociateIPv6": {
+			reason: "IPv6 CIDR blocks should be disassociated if an Amazon-provided IPv6 CIDR block is not desired.",
+			args: args{
+				p: v1beta1.VPCParameters{CIDRBlock: primary, AmazonProvidedIPv6CIDRBlock: &boolFalse},
+				o: v1beta1.VPCObservation{
+					IPv6CIDRBlockAssociationSet: []v1beta1.VPCIPv6CidrBlockAssociation{
+						{AssociationID: "a-1", IPv6CIDRBlock: "2600:1f16::/56", IPv6CIDRBlockState: associated},
+					},
+				},
+			},
+			want: CIDRBlocksDiff{Disassociate: []string{"a-1"}},
+		},
+		"UnmanagedIPv6": {
+			reason: "IPv6 CIDR blocks should be left unchanged if AmazonProvidedIPv6CIDRBlock is not set.",
+			args: args{
+				p: v1beta1.VPCParameters{CIDRBlock: primary},
+				o: v1beta1.VPCObservation{
+					IPv6CIDRBlockAssociationSet: []v1beta1.VPCIPv6CidrBlockAssociation{
+						{AssociationID: "a-1", IPv6CIDRBlock: "2600:1f16::/56", IPv6CIDRBlockState: associated},
+					},
+				},
+			},
+			want: CIDRBlocksDiff{},
+		},
+		"RemoveLastSecondaryCIDRBlock": {
+			reason: "The last secondary IPv4 CIDR block should be disassociated if SecondaryCIDRBlocks is empty.",
+			args: args{
+				p: v1beta1.VPCParameters{CIDRBlock: primary, SecondaryCIDRBlocks: []string{}},
+				o: v1beta1.VPCObservation{
+					CIDRBlockAssociationSet: []v1beta1.VPCCIDRBlockAssociation{
+						{AssociationID: "a-1", CIDRBlock: primary, CIDRBlockState: associated},
+						{AssociationID: "a-2", CIDRBlock: "100.64.0.0/16", CIDRBlockState: associated},
+					},
+				},
+			},
+			want: CIDRBlocksDiff{Disassociate: []string{"a-2"}},
+		},
+		"UnmanagedSecondaryCIDRBlocks": {
+			reason: "Secondary IPv4 CIDR blocks should be left unchanged if SecondaryCIDRBlocks is not set.",
+			args: args{
+				p: v1beta1.VPCParameters{CIDRBlock: primary},
+				o: v1beta1.VPCObservation{
+					CIDRBlockAssociationSet: []v1beta1.VPCCIDRBlockAssociation{
+						{AssociationID: "a-1", CIDRBlock: primary, CIDRBlockState: associated},
+						{AssociationID: "a-2", CIDRBlock: "100.64.0.0/16", CIDRBlockState: associated},
+					},
+				},
+			},
+			want: CIDRBlocksDiff{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DiffCIDRBlocks(tc.args.p, tc.args.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiffCIDRBlocks(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRemoveLastSecondaryCIDRBlock(t *testing.T) {
+	primary := "10.0.0.0/16"
+	associated := ec2.VpcCidrBlockState{State: ec2.VpcCidrBlockStateCodeAssociated}
+	v := &ec2.Vpc{
+		CidrBlock:       aws.String(primary),
+		InstanceTenancy: ec2.TenancyDefault,
+		CidrBlockAssociationSet: []ec2.VpcCidrBlockAssociation{
+			{AssociationId: aws.String("a-1"), CidrBlock: aws.String(primary), CidrBlockState: &associated},
+			{AssociationId: aws.String("a-2"), CidrBlock: aws.String("100.64.0.0/16"), CidrBlockState: &associated},
+		},
+	}
+
+	// An empty list of secondary CIDR blocks must survive being written to and
+	// read back from the API server, or the secondary CIDR blocks would be
+	// adopted again by late initialization instead of being disassociated.
+	b, err := json.Marshal(v1beta1.VPCParameters{CIDRBlock: primary, SecondaryCIDRBlocks: []string{}})
+	if err != nil {
+		t.Fatalf("json.Marshal(...): %s", err)
+	}
+	p := v1beta1.VPCParameters{}
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatalf("json.Unmarshal(...): %s", err)
+	}
+
+	LateInitializeVPC(&p, v)
+	got := DiffCIDRBlocks(p, GenerateVpcObservation(*v))
+	want := CIDRBlocksDiff{Disassociate: []string{"a-2"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DiffCIDRBlocks(...): -want, +got:\n%s", diff)
+	}
+}
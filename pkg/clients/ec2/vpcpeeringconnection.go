@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+const (
+	// VPCPeeringConnectionIDNotFound is the code that is returned by ec2 when
+	// the given VPC peering connection ID is invalid
+	VPCPeeringConnectionIDNotFound = "InvalidVpcPeeringConnectionID.NotFound"
+
+	// VPCPeeringConnectionIDMalformed is the code that is returned by ec2
+	// when the given VPC peering connection ID is malformed
+	VPCPeeringConnectionIDMalformed = "InvalidVpcPeeringConnectionId.Malformed"
+)
+
+// VPCPeeringConnectionClient is the external client used for
+// VPCPeeringConnection Custom Resource
+type VPCPeeringConnectionClient interface {
+	CreateVpcPeeringConnectionRequest(*ec2.CreateVpcPeeringConnectionInput) ec2.CreateVpcPeeringConnectionRequest
+	AcceptVpcPeeringConnectionRequest(*ec2.AcceptVpcPeeringConnectionInput) ec2.AcceptVpcPeeringConnectionRequest
+	DeleteVpcPeeringConnectionRequest(*ec2.DeleteVpcPeeringConnectionInput) ec2.DeleteVpcPeeringConnectionRequest
+	DescribeVpcPeeringConnectionsRequest(*ec2.DescribeVpcPeeringConnectionsInput) ec2.DescribeVpcPeeringConnectionsRequest
+	ModifyVpcPeeringConnectionOptionsRequest(*ec2.ModifyVpcPeeringConnectionOptionsInput) ec2.ModifyVpcPeeringConnectionOptionsRequest
+	CreateTagsRequest(*ec2.CreateTagsInput) ec2.CreateTagsRequest
+	DeleteTagsRequest(*ec2.DeleteTagsInput) ec2.DeleteTagsRequest
+}
+
+// NewVPCPeeringConnectionClient returns a new client using the supplied AWS
+// configuration.
+func NewVPCPeeringConnectionClient(cfg *aws.Config) VPCPeeringConnectionClient {
+	return ec2.New(*cfg)
+}
+
+// IsVPCPeeringConnectionNotFoundErr returns true if the error is because the
+// VPC peering connection doesn't exist
+func IsVPCPeeringConnectionNotFoundErr(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case VPCPeeringConnectionIDNotFound, VPCPeeringConnectionIDMalformed:
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateVPCPeeringConnectionObservation is used to produce
+// v1alpha4.VPCPeeringConnectionObservation from ec2.VpcPeeringConnection.
+func GenerateVPCPeeringConnectionObservation(pcx ec2.VpcPeeringConnection) v1alpha4.VPCPeeringConnectionObservation {
+	o := v1alpha4.VPCPeeringConnectionObservation{
+		VPCPeeringConnectionID: aws.StringValue(pcx.VpcPeeringConnectionId),
+		RequesterVPCInfo:       generateVPCPeeringConnectionVPCInfo(pcx.RequesterVpcInfo),
+		AccepterVPCInfo:        generateVPCPeeringConnectionVPCInfo(pcx.AccepterVpcInfo),
+		Tags:                   observedTags(pcx.Tags),
+	}
+
+	if pcx.Status != nil {
+		o.Status = string(pcx.Status.Code)
+		o.StatusMessage = aws.StringValue(pcx.Status.Message)
+	}
+
+	if pcx.ExpirationTime != nil {
+		o.ExpirationTime = &metav1.Time{Time: *pcx.ExpirationTime}
+	}
+
+	return o
+}
+
+func generateVPCPeeringConnectionVPCInfo(i *ec2.VpcPeeringConnectionVpcInfo) v1alpha4.VPCPeeringConnectionVPCInfo {
+	if i == nil {
+		return v1alpha4.VPCPeeringConnectionVPCInfo{}
+	}
+
+	o := v1alpha4.VPCPeeringConnectionVPCInfo{
+		VPCID:   aws.StringValue(i.VpcId),
+		OwnerID: aws.StringValue(i.OwnerId),
+		Region:  aws.StringValue(i.Region),
+	}
+	for _, b := range i.CidrBlockSet {
+		o.CIDRBlocks = append(o.CIDRBlocks, aws.StringValue(b.CidrBlock))
+	}
+	for _, b := range i.Ipv6CidrBlockSet {
+		o.IPv6CIDRBlocks = append(o.IPv6CIDRBlocks, aws.StringValue(b.Ipv6CidrBlock))
+	}
+	if i.PeeringOptions != nil {
+		o.AllowDNSResolutionFromRemoteVPC = aws.BoolValue(i.PeeringOptions.AllowDnsResolutionFromRemoteVpc)
+	}
+
+	return o
+}
+
+// LateInitializeVPCPeeringConnection fills the empty fields in
+// *v1alpha4.VPCPeeringConnectionParameters with the values seen in
+// ec2.VpcPeeringConnection.
+func LateInitializeVPCPeeringConnection(in *v1alpha4.VPCPeeringConnectionParameters, pcx *ec2.VpcPeeringConnection) {
+	if pcx == nil {
+		return
+	}
+	if pcx.AccepterVpcInfo != nil {
+		in.PeerOwnerID = awsclients.LateInitializeStringPtr(in.PeerOwnerID, pcx.AccepterVpcInfo.OwnerId)
+		in.PeerRegion = awsclients.LateInitializeStringPtr(in.PeerRegion, pcx.AccepterVpcInfo.Region)
+	}
+	if len(in.Tags) == 0 && len(pcx.Tags) != 0 {
+		in.Tags = v1beta1.BuildFromEC2Tags(pcx.Tags)
+	}
+}
+
+// IsVPCPeeringConnectionUpToDate checks whether there is a change in any of
+// the modifiable fields. The peering options can only be modified once the
+// VPC peering connection is active, and are otherwise considered up to date.
+func IsVPCPeeringConnectionUpToDate(p v1alpha4.VPCPeeringConnectionParameters, pcx ec2.VpcPeeringConnection) bool {
+	if !tagsUpToDate(p.Tags, pcx.Tags) {
+		return false
+	}
+	if pcx.Status == nil || pcx.Status.Code != ec2.VpcPeeringConnectionStateReasonCodeActive {
+		return true
+	}
+	return IsPeeringOptionsUpToDate(p.RequesterPeeringOptions, generateVPCPeeringConnectionVPCInfo(pcx.RequesterVpcInfo)) &&
+		IsPeeringOptionsUpToDate(p.AccepterPeeringOptions, generateVPCPeeringConnectionVPCInfo(pcx.AccepterVpcInfo))
+}
+
+// IsPeeringOptionsUpToDate returns true if the supplied options of one side
+// of a VPC peering connection match those observed for the VPC of that side.
+// Options that are not specified are always up to date.
+func IsPeeringOptionsUpToDate(o *v1alpha4.VPCPeeringConnectionOptions, i v1alpha4.VPCPeeringConnectionVPCInfo) bool {
+	if o == nil || o.AllowDNSResolutionFromRemoteVPC == nil {
+		return true
+	}
+	return aws.BoolValue(o.AllowDNSResolutionFromRemoteVPC) == i.AllowDNSResolutionFromRemoteVPC
+}
+
+// GeneratePeeringConnectionOptionsRequest returns the request to set the
+// supplied options of one side of a VPC peering connection.
+func GeneratePeeringConnectionOptionsRequest(o *v1alpha4.VPCPeeringConnectionOptions) *ec2.PeeringConnectionOptionsRequest {
+	if o == nil {
+		return nil
+	}
+	return &ec2.PeeringConnectionOptionsRequest{
+		AllowDnsResolutionFromRemoteVpc: o.AllowDNSResolutionFromRemoteVPC,
+	}
+}
+
+// GenerateCreateVPCPeeringConnectionInput returns the input to create a VPC
+// peering connection with the supplied parameters.
+func GenerateCreateVPCPeeringConnectionInput(p v1alpha4.VPCPeeringConnectionParameters) *ec2.CreateVpcPeeringConnectionInput {
+	return &ec2.CreateVpcPeeringConnectionInput{
+		VpcId:       p.VPCID,
+		PeerVpcId:   p.PeerVPCID,
+		PeerOwnerId: p.PeerOwnerID,
+		PeerRegion:  p.PeerRegion,
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+)
+
+var (
+	pcxID        = "pcx-1"
+	pcxPeerVPCID = "vpc-2"
+	pcxPeerOwner = "123456789012"
+	pcxPeerRgn   = "eu-west-1"
+)
+
+func TestIsVPCPeeringConnectionUpToDate(t *testing.T) {
+	dns := &v1alpha4.VPCPeeringConnectionOptions{AllowDNSResolutionFromRemoteVPC: aws.Bool(true)}
+	enabled := &ec2.VpcPeeringConnectionVpcInfo{
+		PeeringOptions: &ec2.VpcPeeringConnectionOptionsDescription{AllowDnsResolutionFromRemoteVpc: aws.Bool(true)},
+	}
+	active := &ec2.VpcPeeringConnectionStateReason{Code: ec2.VpcPeeringConnectionStateReasonCodeActive}
+	pending := &ec2.VpcPeeringConnectionStateReason{Code: ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance}
+
+	type args struct {
+		p   v1alpha4.VPCPeeringConnectionParameters
+		pcx ec2.VpcPeeringConnection
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "VPC peering connections with the desired tags and options should be up to date.",
+			args: args{
+				p: v1alpha4.VPCPeeringConnectionParameters{
+					RequesterPeeringOptions: dns,
+					AccepterPeeringOptions:  dns,
+					Tags:                    []v1beta1.Tag{{Key: "k", Value: "v"}},
+				},
+				pcx: ec2.VpcPeeringConnection{
+					Status:           active,
+					RequesterVpcInfo: enabled,
+					AccepterVpcInfo:  enabled,
+					Tags:             []ec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+				},
+			},
+			want: true,
+		},
+		"ExtraTags": {
+			reason: "VPC peering connections with tags that are not desired should not be up to date.",
+			args: args{
+				pcx: ec2.VpcPeeringConnection{Tags: []ec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}}},
+			},
+			want: false,
+		},
+		"OutdatedAccepterOptions": {
+			reason: "Active VPC peering connections without the desired accepter options should not be up to date.",
+			args: args{
+				p: v1alpha4.VPCPeeringConnectionParameters{AccepterPeeringOptions: dns},
+				pcx: ec2.VpcPeeringConnection{
+					Status:           active,
+					RequesterVpcInfo: enabled,
+					AccepterVpcInfo:  &ec2.VpcPeeringConnectionVpcInfo{},
+				},
+			},
+			want: false,
+		},
+		"NotActive": {
+			reason: "The options of VPC peering connections that are not active should be considered up to date.",
+			args: args{
+				p:   v1alpha4.VPCPeeringConnectionParameters{RequesterPeeringOptions: dns},
+				pcx: ec2.VpcPeeringConnection{Status: pending},
+			},
+			want: true,
+		},
+		"UnspecifiedOptions": {
+			reason: "Options that are not specified should be considered up to date.",
+			args: args{
+				p:   v1alpha4.VPCPeeringConnectionParameters{RequesterPeeringOptions: &v1alpha4.VPCPeeringConnectionOptions{}},
+				pcx: ec2.VpcPeeringConnection{Status: active, RequesterVpcInfo: enabled},
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsVPCPeeringConnectionUpToDate(tc.args.p, tc.args.pcx)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsVPCPeeringConnectionUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeVPCPeeringConnection(t *testing.T) {
+	type args struct {
+		p   *v1alpha4.VPCPeeringConnectionParameters
+		pcx *ec2.VpcPeeringConnection
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha4.VPCPeeringConnectionParameters
+	}{
+		"AllFilled": {
+			reason: "Empty fields should be filled with the observed values.",
+			args: args{
+				p: &v1alpha4.VPCPeeringConnectionParameters{},
+				pcx: &ec2.VpcPeeringConnection{
+					AccepterVpcInfo: &ec2.VpcPeeringConnectionVpcInfo{
+						OwnerId: aws.String(pcxPeerOwner),
+						Region:  aws.String(pcxPeerRgn),
+					},
+					Tags: []ec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+				},
+			},
+			want: &v1alpha4.VPCPeeringConnectionParameters{
+				PeerOwnerID: aws.String(pcxPeerOwner),
+				PeerRegion:  aws.String(pcxPeerRgn),
+				Tags:        []v1beta1.Tag{{Key: "k", Value: "v"}},
+			},
+		},
+		"PartialFilled": {
+			reason: "Fields that are already set should not be overwritten.",
+			args: args{
+				p: &v1alpha4.VPCPeeringConnectionParameters{PeerRegion: aws.String("us-east-1")},
+				pcx: &ec2.VpcPeeringConnection{
+					AccepterVpcInfo: &ec2.VpcPeeringConnectionVpcInfo{
+						OwnerId: aws.String(pcxPeerOwner),
+						Region:  aws.String(pcxPeerRgn),
+					},
+				},
+			},
+			want: &v1alpha4.VPCPeeringConnectionParameters{
+				PeerOwnerID: aws.String(pcxPeerOwner),
+				PeerRegion:  aws.String("us-east-1"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeVPCPeeringConnection(tc.args.p, tc.args.pcx)
+			if diff := cmp.Diff(tc.want, tc.args.p); diff != "" {
+				t.Errorf("\n%s\nLateInitializeVPCPeeringConnection(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateVPCPeeringConnectionObservation(t *testing.T) {
+	cases := map[string]struct {
+		in  ec2.VpcPeeringConnection
+		out v1alpha4.VPCPeeringConnectionObservation
+	}{
+		"Active": {
+			in: ec2.VpcPeeringConnection{
+				VpcPeeringConnectionId: aws.String(pcxID),
+				Status: &ec2.VpcPeeringConnectionStateReason{
+					Code:    ec2.VpcPeeringConnectionStateReasonCodeActive,
+					Message: aws.String("Active"),
+				},
+				RequesterVpcInfo: &ec2.VpcPeeringConnectionVpcInfo{
+					VpcId:            aws.String(vpcID),
+					OwnerId:          aws.String(vpcOwner),
+					Region:           aws.String("us-east-1"),
+					CidrBlockSet:     []ec2.CidrBlock{{CidrBlock: aws.String("10.0.0.0/16")}},
+					Ipv6CidrBlockSet: []ec2.Ipv6CidrBlock{{Ipv6CidrBlock: aws.String("2600:1f16::/56")}},
+					PeeringOptions: &ec2.VpcPeeringConnectionOptionsDescription{
+						AllowDnsResolutionFromRemoteVpc: aws.Bool(true),
+					},
+				},
+				AccepterVpcInfo: &ec2.VpcPeeringConnectionVpcInfo{
+					VpcId:        aws.String(pcxPeerVPCID),
+					OwnerId:      aws.String(pcxPeerOwner),
+					Region:       aws.String(pcxPeerRgn),
+					CidrBlockSet: []ec2.CidrBlock{{CidrBlock: aws.String("10.1.0.0/16")}},
+				},
+			},
+			out: v1alpha4.VPCPeeringConnectionObservation{
+				VPCPeeringConnectionID: pcxID,
+				Status:                 string(ec2.VpcPeeringConnectionStateReasonCodeActive),
+				StatusMessage:          "Active",
+				RequesterVPCInfo: v1alpha4.VPCPeeringConnectionVPCInfo{
+					VPCID:                           vpcID,
+					OwnerID:                         vpcOwner,
+					Region:                          "us-east-1",
+					CIDRBlocks:                      []string{"10.0.0.0/16"},
+					IPv6CIDRBlocks:                  []string{"2600:1f16::/56"},
+					AllowDNSResolutionFromRemoteVPC: true,
+				},
+				AccepterVPCInfo: v1alpha4.VPCPeeringConnectionVPCInfo{
+					VPCID:      pcxPeerVPCID,
+					OwnerID:    pcxPeerOwner,
+					Region:     pcxPeerRgn,
+					CIDRBlocks: []string{"10.1.0.0/16"},
+				},
+			},
+		},
+		"PendingAcceptance": {
+			in: ec2.VpcPeeringConnection{
+				VpcPeeringConnectionId: aws.String(pcxID),
+				Status: &ec2.VpcPeeringConnectionStateReason{
+					Code: ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance,
+				},
+			},
+			out: v1alpha4.VPCPeeringConnectionObservation{
+				VPCPeeringConnectionID: pcxID,
+				Status:                 string(ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := GenerateVPCPeeringConnectionObservation(tc.in)
+			if diff := cmp.Diff(tc.out, r); diff != "" {
+				t.Errorf("GenerateVPCPeeringConnectionObservation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
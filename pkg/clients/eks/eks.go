@@ -17,13 +17,13 @@ limitations under the License.
 package eks
 
 import (
-	"context"
 	"encoding/base64"
 	"encoding/json"
 	"net"
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 	"github.com/aws/aws-sdk-go-v2/service/eks/eksiface"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
@@ -38,6 +38,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 
 	"github.com/crossplane/provider-aws/apis/eks/v1beta1"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 )
 
@@ -53,12 +54,8 @@ type Client eksiface.ClientAPI
 type STSClient stsiface.ClientAPI
 
 // NewClient creates new EKS Client with provided AWS Configurations/Credentials.
-func NewClient(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (Client, STSClient, error) {
-	cfg, err := auth(ctx, credentials, awsclients.DefaultSection, region)
-	if cfg == nil {
-		return nil, nil, err
-	}
-	return eks.New(*cfg), sts.New(*cfg), err
+func NewClient(cfg *aws.Config) (Client, STSClient) {
+	return eks.New(*cfg), sts.New(*cfg)
 }
 
 // IsErrorNotFound helper function to test for ErrCodeResourceNotFoundException error.
@@ -268,43 +265,86 @@ func LateInitialize(in *v1beta1.ClusterParameters, cluster *eks.Cluster) { // no
 	}
 }
 
+// patchOptions determine which fields of a ClusterParameters patch must be
+// empty for a cluster to be up to date.
+var patchOptions = []cmp.Option{
+	cmpopts.EquateEmpty(),
+	cmpopts.IgnoreTypes(&v1alpha1.Reference{}, &v1alpha1.Selector{}),
+	cmpopts.IgnoreFields(v1beta1.VpcConfigRequest{}, "SecurityGroupIDRefs", "SubnetIDRefs", "PublicAccessCidrs"),
+}
+
 // IsUpToDate checks whether there is a change in any of the modifiable fields.
 func IsUpToDate(p *v1beta1.ClusterParameters, cluster *eks.Cluster) (bool, error) {
 	patch, err := CreatePatch(cluster, p)
 	if err != nil {
 		return false, err
 	}
+	upToDate, err := arePublicAccessCidrsUpToDate(p, cluster)
+	if err != nil || !upToDate {
+		return false, err
+	}
+	return cmp.Equal(&v1beta1.ClusterParameters{}, patch, patchOptions...), nil
+}
+
+// Diff returns the modifiable fields of the supplied parameters that differ
+// from the supplied cluster.
+func Diff(p *v1beta1.ClusterParameters, cluster *eks.Cluster) ([]v1alpha3.FieldDrift, error) {
+	patch, err := CreatePatch(cluster, p)
+	if err != nil {
+		return nil, err
+	}
+	observed := &v1beta1.ClusterParameters{}
+	LateInitialize(observed, cluster)
+	drift := awsclients.DiffPatch(observed, patch, patchOptions...)
+
+	upToDate, err := arePublicAccessCidrsUpToDate(p, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if !upToDate {
+		drift = append(drift, awsclients.NewFieldDrift(awsclients.DriftPathPrefix+".resourcesVpcConfig.publicAccessCidrs",
+			observed.ResourcesVpcConfig.PublicAccessCidrs, p.ResourcesVpcConfig.PublicAccessCidrs))
+	}
+	return drift, nil
+}
+
+// arePublicAccessCidrsUpToDate checks whether the public access CIDR blocks of
+// the supplied cluster match those of the supplied parameters.
+func arePublicAccessCidrsUpToDate(p *v1beta1.ClusterParameters, cluster *eks.Cluster) (bool, error) {
+	var observed []string
+	if cluster.ResourcesVpcConfig != nil {
+		observed = cluster.ResourcesVpcConfig.PublicAccessCidrs
+	}
 
 	// NOTE(hasheddan): AWS removes insignificant bits from CIDRs, so we must
 	// compare by converting user-supplied CIDRs to network blocks. We only skip
 	// comparison if both external and local have no CIDR blocks defined.
-	if (cluster.ResourcesVpcConfig != nil && len(cluster.ResourcesVpcConfig.PublicAccessCidrs) > 0) || len(p.ResourcesVpcConfig.PublicAccessCidrs) > 0 {
-		// Convert user-supplied slice of CIDRs to map of networks.
-		netMap := map[string]bool{}
-		for _, c := range p.ResourcesVpcConfig.PublicAccessCidrs {
-			_, ipNet, err := net.ParseCIDR(c)
-			if err != nil {
-				return false, err
-			}
-			netMap[ipNet.String()] = true
+	if len(observed) == 0 && len(p.ResourcesVpcConfig.PublicAccessCidrs) == 0 {
+		return true, nil
+	}
+
+	// Convert user-supplied slice of CIDRs to map of networks.
+	netMap := map[string]bool{}
+	for _, c := range p.ResourcesVpcConfig.PublicAccessCidrs {
+		_, ipNet, err := net.ParseCIDR(c)
+		if err != nil {
+			return false, err
 		}
-		// If length of networks does not match the length of CIDR blocks
-		// returned by AWS then we need update.
-		if len(netMap) != len(cluster.ResourcesVpcConfig.PublicAccessCidrs) {
+		netMap[ipNet.String()] = true
+	}
+	// If length of networks does not match the length of CIDR blocks
+	// returned by AWS then we need update.
+	if len(netMap) != len(observed) {
+		return false, nil
+	}
+	// If AWS returns a CIDR block that is not in the map, then we need
+	// update.
+	for _, pc := range observed {
+		if !netMap[pc] {
 			return false, nil
 		}
-		// If AWS returns a CIDR block that is not in the map, then we need
-		// update.
-		for _, pc := range cluster.ResourcesVpcConfig.PublicAccessCidrs {
-			if !netMap[pc] {
-				return false, nil
-			}
-		}
 	}
-
-	return cmp.Equal(&v1beta1.ClusterParameters{}, patch, cmpopts.EquateEmpty(),
-		cmpopts.IgnoreTypes(&v1alpha1.Reference{}, &v1alpha1.Selector{}),
-		cmpopts.IgnoreFields(v1beta1.VpcConfigRequest{}, "SecurityGroupIDRefs", "SubnetIDRefs", "PublicAccessCidrs")), nil
+	return true, nil
 }
 
 // GetConnectionDetails extracts managed.ConnectionDetails out of eks.Cluster.
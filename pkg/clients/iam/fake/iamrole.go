@@ -32,6 +32,7 @@ type MockRoleClient struct {
 	MockDeleteRoleRequest             func(*iam.DeleteRoleInput) iam.DeleteRoleRequest
 	MockUpdateRoleRequest             func(*iam.UpdateRoleInput) iam.UpdateRoleRequest
 	MockUpdateAssumeRolePolicyRequest func(*iam.UpdateAssumeRolePolicyInput) iam.UpdateAssumeRolePolicyRequest
+	MockTagRoleRequest                func(*iam.TagRoleInput) iam.TagRoleRequest
 }
 
 // GetRoleRequest mocks GetRoleRequest method
@@ -58,3 +59,8 @@ func (m *MockRoleClient) UpdateRoleRequest(input *iam.UpdateRoleInput) iam.Updat
 func (m *MockRoleClient) UpdateAssumeRolePolicyRequest(input *iam.UpdateAssumeRolePolicyInput) iam.UpdateAssumeRolePolicyRequest {
 	return m.MockUpdateAssumeRolePolicyRequest(input)
 }
+
+// TagRoleRequest mocks TagRoleRequest method
+func (m *MockRoleClient) TagRoleRequest(input *iam.TagRoleInput) iam.TagRoleRequest {
+	return m.MockTagRoleRequest(input)
+}
@@ -31,6 +31,9 @@ type MockUserClient struct {
 	MockCreateUser func(*iam.CreateUserInput) iam.CreateUserRequest
 	MockDeleteUser func(*iam.DeleteUserInput) iam.DeleteUserRequest
 	MockUpdateUser func(*iam.UpdateUserInput) iam.UpdateUserRequest
+	MockListTags   func(*iam.ListUserTagsInput) iam.ListUserTagsRequest
+	MockTagUser    func(*iam.TagUserInput) iam.TagUserRequest
+	MockUntagUser  func(*iam.UntagUserInput) iam.UntagUserRequest
 }
 
 // GetUserRequest mocks GetUserRequest method
@@ -52,3 +55,18 @@ func (m *MockUserClient) DeleteUserRequest(input *iam.DeleteUserInput) iam.Delet
 func (m *MockUserClient) UpdateUserRequest(input *iam.UpdateUserInput) iam.UpdateUserRequest {
 	return m.MockUpdateUser(input)
 }
+
+// ListUserTagsRequest mocks ListUserTagsRequest method
+func (m *MockUserClient) ListUserTagsRequest(input *iam.ListUserTagsInput) iam.ListUserTagsRequest {
+	return m.MockListTags(input)
+}
+
+// TagUserRequest mocks TagUserRequest method
+func (m *MockUserClient) TagUserRequest(input *iam.TagUserInput) iam.TagUserRequest {
+	return m.MockTagUser(input)
+}
+
+// UntagUserRequest mocks UntagUserRequest method
+func (m *MockUserClient) UntagUserRequest(input *iam.UntagUserInput) iam.UntagUserRequest {
+	return m.MockUntagUser(input)
+}
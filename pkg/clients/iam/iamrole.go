@@ -27,11 +27,12 @@ type RoleClient interface {
 	DeleteRoleRequest(*iam.DeleteRoleInput) iam.DeleteRoleRequest
 	UpdateRoleRequest(*iam.UpdateRoleInput) iam.UpdateRoleRequest
 	UpdateAssumeRolePolicyRequest(*iam.UpdateAssumeRolePolicyInput) iam.UpdateAssumeRolePolicyRequest
+	TagRoleRequest(*iam.TagRoleInput) iam.TagRoleRequest
 }
 
-// NewRoleClient returns a new client using AWS credentials as JSON encoded data.
-func NewRoleClient(conf *aws.Config) (RoleClient, error) {
-	return iam.New(*conf), nil
+// NewRoleClient returns a new client using the supplied AWS configuration.
+func NewRoleClient(cfg *aws.Config) RoleClient {
+	return iam.New(*cfg)
 }
 
 // GenerateCreateRoleInput from IAMRoleSpec
@@ -46,18 +47,22 @@ func GenerateCreateRoleInput(name string, p *v1beta1.IAMRoleParameters) *iam.Cre
 	}
 
 	if len(p.Tags) != 0 {
-		m.Tags = make([]iam.Tag, len(p.Tags))
-		for i, val := range p.Tags {
-			m.Tags[i] = iam.Tag{
-				Key:   &val.Key,
-				Value: &val.Value,
-			}
-		}
+		m.Tags = GenerateRoleTags(p.Tags)
 	}
 
 	return m
 }
 
+// GenerateRoleTags returns the supplied role tags in the format expected by
+// IAM.
+func GenerateRoleTags(tags []v1beta1.Tag) []iam.Tag {
+	res := make([]iam.Tag, len(tags))
+	for i, t := range tags {
+		res[i] = iam.Tag{Key: aws.String(t.Key), Value: aws.String(t.Value)}
+	}
+	return res
+}
+
 // GenerateRoleObservation is used to produce IAMRoleExternalStatus from iam.Role
 func GenerateRoleObservation(role iam.Role) v1beta1.IAMRoleExternalStatus {
 	return v1beta1.IAMRoleExternalStatus{
@@ -82,13 +87,7 @@ func GenerateIAMRole(in v1beta1.IAMRoleParameters, role *iam.Role) error {
 	role.Path = in.Path
 
 	if len(in.Tags) != 0 {
-		role.Tags = make([]iam.Tag, len(in.Tags))
-		for i, val := range in.Tags {
-			role.Tags[i] = iam.Tag{
-				Key:   &val.Key,
-				Value: &val.Value,
-			}
-		}
+		role.Tags = GenerateRoleTags(in.Tags)
 	}
 	return nil
 }
@@ -148,5 +147,7 @@ func IsRoleUpToDate(in v1beta1.IAMRoleParameters, observed iam.Role) (bool, erro
 		return false, err
 	}
 
-	return cmp.Equal(desired, &observed, cmpopts.IgnoreInterfaces(struct{ resource.AttributeReferencer }{})), nil
+	return cmp.Equal(desired, &observed,
+		cmpopts.IgnoreInterfaces(struct{ resource.AttributeReferencer }{}),
+		cmpopts.SortSlices(func(a, b iam.Tag) bool { return aws.StringValue(a.Key) < aws.StringValue(b.Key) })), nil
 }
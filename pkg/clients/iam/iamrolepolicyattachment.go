@@ -15,9 +15,9 @@ type RolePolicyAttachmentClient interface {
 	DetachRolePolicyRequest(*iam.DetachRolePolicyInput) iam.DetachRolePolicyRequest
 }
 
-// NewRolePolicyAttachmentClient returns a new client given an aws config
-func NewRolePolicyAttachmentClient(conf *aws.Config) (RolePolicyAttachmentClient, error) {
-	return iam.New(*conf), nil
+// NewRolePolicyAttachmentClient returns a new client using the supplied AWS configuration.
+func NewRolePolicyAttachmentClient(cfg *aws.Config) RolePolicyAttachmentClient {
+	return iam.New(*cfg)
 }
 
 // GenerateRolePolicyObservation is used to produce IAMRolePolicyAttachmentExternalStatus from iam.AttachedPolicy
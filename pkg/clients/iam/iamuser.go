@@ -1,8 +1,7 @@
 package iam
 
 import (
-	"context"
-
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 
 	"github.com/crossplane/provider-aws/apis/identity/v1alpha1"
@@ -15,15 +14,14 @@ type UserClient interface {
 	CreateUserRequest(*iam.CreateUserInput) iam.CreateUserRequest
 	UpdateUserRequest(*iam.UpdateUserInput) iam.UpdateUserRequest
 	DeleteUserRequest(*iam.DeleteUserInput) iam.DeleteUserRequest
+	ListUserTagsRequest(*iam.ListUserTagsInput) iam.ListUserTagsRequest
+	TagUserRequest(*iam.TagUserInput) iam.TagUserRequest
+	UntagUserRequest(*iam.UntagUserInput) iam.UntagUserRequest
 }
 
-// NewUserClient returns a new client using AWS credentials as JSON encoded data.
-func NewUserClient(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (UserClient, error) {
-	cfg, err := auth(ctx, credentials, awsclients.DefaultSection, region)
-	if cfg == nil {
-		return nil, err
-	}
-	return iam.New(*cfg), nil
+// NewUserClient returns a new client using the supplied AWS configuration.
+func NewUserClient(cfg *aws.Config) UserClient {
+	return iam.New(*cfg)
 }
 
 // LateInitializeUser fills the empty fields in *v1alpha1.User with
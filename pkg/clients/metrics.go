@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsHandlerName = "crossplane.provider-aws.Metrics"
+
+// Metric labels.
+const (
+	labelService    = "service"
+	labelOperation  = "operation"
+	labelStatusCode = "status_code"
+	labelErrorCode  = "error_code"
+)
+
+var (
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "provider_aws_api_requests_total",
+		Help: "Total number of attempted AWS API requests. Each retry counts as a request.",
+	}, []string{labelService, labelOperation, labelStatusCode, labelErrorCode})
+
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "provider_aws_api_request_duration_seconds",
+		Help:    "Duration of each attempted AWS API request in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{labelService, labelOperation, labelStatusCode, labelErrorCode})
+)
+
+func init() {
+	// The controller manager serves the metrics in this registry.
+	metrics.Registry.MustRegister(apiRequests, apiRequestDuration)
+}
+
+// InstrumentHandlers adds handlers that record Prometheus metrics for each
+// attempt of each AWS API request to the supplied handlers. Instrumenting
+// handlers that are already instrumented has no effect.
+func InstrumentHandlers(h *aws.Handlers) {
+	h.CompleteAttempt.SetBackNamed(aws.NamedHandler{Name: metricsHandlerName, Fn: recordAPIRequest})
+}
+
+// recordAPIRequest records the outcome of an attempt of an AWS API request.
+func recordAPIRequest(r *aws.Request) {
+	status := ""
+	if r.HTTPResponse != nil {
+		status = strconv.Itoa(r.HTTPResponse.StatusCode)
+	}
+	code := ""
+	if err, ok := r.Error.(awserr.Error); ok {
+		code = err.Code()
+	}
+	operation := ""
+	if r.Operation != nil {
+		operation = r.Operation.Name
+	}
+
+	l := prometheus.Labels{
+		labelService:    r.Metadata.ServiceID,
+		labelOperation:  operation,
+		labelStatusCode: status,
+		labelErrorCode:  code,
+	}
+	apiRequests.With(l).Inc()
+	if !r.AttemptTime.IsZero() {
+		apiRequestDuration.With(l).Observe(time.Since(r.AttemptTime).Seconds())
+	}
+}
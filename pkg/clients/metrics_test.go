@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestInstrumentHandlers(t *testing.T) {
+	h := aws.Handlers{}
+	InstrumentHandlers(&h)
+	InstrumentHandlers(&h)
+	if got := h.CompleteAttempt.Len(); got != 1 {
+		t.Errorf("InstrumentHandlers(...): want 1 CompleteAttempt handler, got %d", got)
+	}
+}
+
+func TestRecordAPIRequest(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		r      *aws.Request
+		want   prometheus.Labels
+	}{
+		"Successful": {
+			reason: "A successful request should be recorded with its HTTP status code and no error code.",
+			r: &aws.Request{
+				Metadata:     aws.Metadata{ServiceID: "EC2"},
+				Operation:    &aws.Operation{Name: "DescribeVpcs"},
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				AttemptTime:  time.Now(),
+			},
+			want: prometheus.Labels{labelService: "EC2", labelOperation: "DescribeVpcs", labelStatusCode: "200", labelErrorCode: ""},
+		},
+		"Throttled": {
+			reason: "A failed request should be recorded with its HTTP status code and AWS error code.",
+			r: &aws.Request{
+				Metadata:     aws.Metadata{ServiceID: "EC2"},
+				Operation:    &aws.Operation{Name: "DescribeSubnets"},
+				HTTPResponse: &http.Response{StatusCode: http.StatusServiceUnavailable},
+				Error:        awserr.NewRequestFailure(awserr.New("RequestLimitExceeded", "slow down", nil), http.StatusServiceUnavailable, "cool"),
+				AttemptTime:  time.Now(),
+			},
+			want: prometheus.Labels{labelService: "EC2", labelOperation: "DescribeSubnets", labelStatusCode: "503", labelErrorCode: "RequestLimitExceeded"},
+		},
+		"NoResponse": {
+			reason: "A request that received no response should be recorded without an HTTP status code.",
+			r: &aws.Request{
+				Metadata:    aws.Metadata{ServiceID: "S3"},
+				Operation:   &aws.Operation{Name: "ListBuckets"},
+				Error:       errors.New("boom"),
+				AttemptTime: time.Now(),
+			},
+			want: prometheus.Labels{labelService: "S3", labelOperation: "ListBuckets", labelStatusCode: "", labelErrorCode: ""},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			before := testutil.ToFloat64(apiRequests.With(tc.want))
+			recordAPIRequest(tc.r)
+			if got := testutil.ToFloat64(apiRequests.With(tc.want)) - before; got != 1 {
+				t.Errorf("\n%s\nrecordAPIRequest(...): want 1 request recorded, got %f", tc.reason, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyPaused is the key of the annotation that pauses the
+// reconciliation of a managed resource when its value is "true". The external
+// resource of a paused managed resource is neither observed nor changed.
+const AnnotationKeyPaused = "aws.crossplane.io/paused"
+
+// TypePaused resources are not reconciled.
+const TypePaused runtimev1alpha1.ConditionType = "Paused"
+
+// Reasons a resource is or is not paused.
+const (
+	ReasonPaused  runtimev1alpha1.ConditionReason = "Reconciliation is paused"
+	ReasonResumed runtimev1alpha1.ConditionReason = "Reconciliation is resumed"
+)
+
+// Paused returns a condition indicating that a managed resource is paused, and
+// thus its external resource is neither observed nor changed.
+func Paused() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPaused,
+	}
+}
+
+// Resumed returns a condition indicating that a previously paused managed
+// resource is being reconciled again.
+func Resumed() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResumed,
+	}
+}
+
+// IsPaused returns true if the supplied managed resource is paused.
+func IsPaused(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyPaused] == "true"
+}
+
+// Resume the supplied managed resource by setting its Paused condition to
+// False, if it was previously paused.
+func Resume(o resource.Conditioned) {
+	if o.GetCondition(TypePaused).Status == corev1.ConditionTrue {
+		o.SetConditions(Resumed())
+	}
+}
+
+// NewPausedExternal returns an ExternalClient that never observes or changes
+// the external resource of a managed resource. The external resource is always
+// reported to exist and be up to date, so that it is neither created nor
+// updated, and a deleted managed resource is not finalized until it is
+// resumed.
+func NewPausedExternal() managed.ExternalClient {
+	return &pausedExternal{}
+}
+
+type pausedExternal struct{}
+
+func (e *pausedExternal) Observe(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	mg.SetConditions(Paused())
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (e *pausedExternal) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *pausedExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *pausedExternal) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
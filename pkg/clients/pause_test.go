@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestResume(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		conditions []runtimev1alpha1.Condition
+		want       []runtimev1alpha1.Condition
+	}{
+		"Paused": {
+			reason:     "A paused resource should be resumed.",
+			conditions: []runtimev1alpha1.Condition{Paused()},
+			want:       []runtimev1alpha1.Condition{Resumed()},
+		},
+		"NeverPaused": {
+			reason: "A resource that was never paused should not be marked as resumed.",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{ConditionedStatus: runtimev1alpha1.ConditionedStatus{Conditions: tc.conditions}}
+			Resume(mg)
+			want := runtimev1alpha1.ConditionedStatus{Conditions: tc.want}
+			if diff := cmp.Diff(want, mg.ConditionedStatus, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nResume(...): -want conditions, +got conditions:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConnectorPaused(t *testing.T) {
+	mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyPaused: "true"}}}
+
+	// The Provider of a paused managed resource should never be resolved.
+	e, err := NewConnector(testKube(nil, nil), nil).Connect(context.Background(), mg)
+	if err != nil {
+		t.Fatalf("c.Connect(...): %s", err)
+	}
+
+	o, err := e.Observe(context.Background(), mg)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %s", err)
+	}
+	want := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+	if diff := cmp.Diff(want, o); diff != "" {
+		t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+	}
+	wantStatus := runtimev1alpha1.ConditionedStatus{Conditions: []runtimev1alpha1.Condition{Paused()}}
+	if diff := cmp.Diff(wantStatus, mg.ConditionedStatus, test.EquateConditions()); diff != "" {
+		t.Errorf("e.Observe(...): -want conditions, +got conditions:\n%s", diff)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyManagementPolicy is the key of the annotation that determines
+// how the provider manages the external resource of a managed resource.
+const AnnotationKeyManagementPolicy = "aws.crossplane.io/management-policy"
+
+// ManagementPolicyObserveOnly is the management policy of managed resources
+// whose external resources are observed, but never created, updated or
+// deleted.
+const ManagementPolicyObserveOnly = "ObserveOnly"
+
+const (
+	errObserveOnlyNotFound = "external resource does not exist, and will not be created because the managed resource is observe-only"
+	errObserveOnlyCreate   = "cannot create the external resource of an observe-only managed resource"
+)
+
+// TypeDrifted resources have external resources that differ from the desired
+// state described by their spec.
+const TypeDrifted runtimev1alpha1.ConditionType = "Drifted"
+
+// Reasons a resource has or has not drifted.
+const (
+	ReasonDrifted    runtimev1alpha1.ConditionReason = "External resource differs from the desired state"
+	ReasonNotDrifted runtimev1alpha1.ConditionReason = "External resource matches the desired state"
+)
+
+// Drifted returns a condition indicating that the external resource of an
+// observe-only managed resource differs from the desired state described by
+// its spec.
+func Drifted() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeDrifted,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDrifted,
+	}
+}
+
+// NotDrifted returns a condition indicating that the external resource of an
+// observe-only managed resource matches the desired state described by its
+// spec.
+func NotDrifted() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeDrifted,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotDrifted,
+	}
+}
+
+// IsObserveOnly returns true if the supplied managed resource's external
+// resource should be observed, but never created, updated or deleted.
+func IsObserveOnly(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyManagementPolicy] == ManagementPolicyObserveOnly
+}
+
+// ObserveOnly wraps the supplied ExternalClient such that it observes external
+// resources, but never creates, updates or deletes them. Drift between the
+// external resource and the managed resource's spec is reported by the
+// Drifted condition rather than corrected, and deleting the managed resource
+// orphans its external resource.
+func ObserveOnly(e managed.ExternalClient) managed.ExternalClient {
+	return &observeOnlyExternal{ExternalClient: e}
+}
+
+type observeOnlyExternal struct {
+	managed.ExternalClient
+}
+
+func (e *observeOnlyExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil {
+		return o, err
+	}
+
+	// We report that the external resource no longer exists when the managed
+	// resource is deleted in order to orphan it without trying to delete it.
+	if meta.WasDeleted(mg) {
+		o.ResourceExists = false
+		return o, nil
+	}
+
+	if !o.ResourceExists {
+		return o, errors.New(errObserveOnlyNotFound)
+	}
+
+	if o.ResourceUpToDate {
+		mg.SetConditions(NotDrifted())
+	} else {
+		mg.SetConditions(Drifted())
+	}
+
+	// We always report that the external resource is up to date in order to
+	// prevent it from being updated.
+	o.ResourceUpToDate = true
+	return o, nil
+}
+
+func (e *observeOnlyExternal) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, errors.New(errObserveOnlyCreate)
+}
+
+func (e *observeOnlyExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *observeOnlyExternal) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestIsObserveOnly(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		annotations map[string]string
+		want        bool
+	}{
+		"ObserveOnly": {
+			reason:      "A resource annotated with the observe-only management policy should be observe-only.",
+			annotations: map[string]string{AnnotationKeyManagementPolicy: ManagementPolicyObserveOnly},
+			want:        true,
+		},
+		"OtherPolicy": {
+			reason:      "A resource annotated with any other management policy should not be observe-only.",
+			annotations: map[string]string{AnnotationKeyManagementPolicy: "Coolest"},
+			want:        false,
+		},
+		"NotAnnotated": {
+			reason: "A resource without a management policy should not be observe-only.",
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if got := IsObserveOnly(mg); got != tc.want {
+				t.Errorf("\n%s\nIsObserveOnly(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestObserveOnlyObserve(t *testing.T) {
+	now := metav1.Now()
+
+	type want struct {
+		o          managed.ExternalObservation
+		err        error
+		conditions []runtimev1alpha1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *fake.Managed
+		o      managed.ExternalObservation
+		err    error
+		want   want
+	}{
+		"ObserveError": {
+			reason: "Errors observing the external resource should be returned.",
+			mg:     &fake.Managed{},
+			err:    errBoom,
+			want:   want{err: errBoom},
+		},
+		"NotFound": {
+			reason: "An error should be returned if the external resource does not exist, since it will not be created.",
+			mg:     &fake.Managed{},
+			o:      managed.ExternalObservation{ResourceExists: false},
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: false},
+				err: errors.New(errObserveOnlyNotFound),
+			},
+		},
+		"Deleted": {
+			reason: "The external resource should be reported as not existing when the managed resource is deleted, so that it is orphaned.",
+			mg:     &fake.Managed{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now}},
+			o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false, ResourceUpToDate: true},
+			},
+		},
+		"Drifted": {
+			reason: "An external resource that is not up to date should be reported as drifted, but never updated.",
+			mg:     &fake.Managed{},
+			o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				conditions: []runtimev1alpha1.Condition{Drifted()},
+			},
+		},
+		"NotDrifted": {
+			reason: "An external resource that is up to date should be reported as not drifted.",
+			mg:     &fake.Managed{},
+			o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				conditions: []runtimev1alpha1.Condition{NotDrifted()},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := ObserveOnly(&managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					return tc.o, tc.err
+				},
+			})
+			o, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			want := runtimev1alpha1.ConditionedStatus{Conditions: tc.want.conditions}
+			if diff := cmp.Diff(want, tc.mg.ConditionedStatus, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want conditions, +got conditions:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveOnlyChanges(t *testing.T) {
+	called := false
+	e := ObserveOnly(&managed.ExternalClientFns{
+		CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+			called = true
+			return managed.ExternalCreation{}, nil
+		},
+		UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+			called = true
+			return managed.ExternalUpdate{}, nil
+		},
+		DeleteFn: func(_ context.Context, _ resource.Managed) error {
+			called = true
+			return nil
+		},
+	})
+
+	mg := &fake.Managed{}
+	if _, err := e.Create(context.Background(), mg); err == nil {
+		t.Errorf("e.Create(...): want error, got nil")
+	}
+	if _, err := e.Update(context.Background(), mg); err != nil {
+		t.Errorf("e.Update(...): %s", err)
+	}
+	if err := e.Delete(context.Background(), mg); err != nil {
+		t.Errorf("e.Delete(...): %s", err)
+	}
+	if called {
+		t.Errorf("ObserveOnly(...): the wrapped ExternalClient should never create, update or delete")
+	}
+}
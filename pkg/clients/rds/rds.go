@@ -17,7 +17,6 @@ limitations under the License.
 package rds
 
 import (
-	"context"
 	"encoding/json"
 	"strconv"
 	"strings"
@@ -32,6 +31,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 
 	"github.com/crossplane/provider-aws/apis/database/v1beta1"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 )
 
@@ -45,12 +45,8 @@ type Client interface {
 }
 
 // NewClient creates new RDS RDSClient with provided AWS Configurations/Credentials
-func NewClient(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (Client, error) {
-	cfg, err := auth(ctx, credentials, awsclients.DefaultSection, region)
-	if cfg == nil {
-		return nil, err
-	}
-	return rds.New(*cfg), err
+func NewClient(cfg *aws.Config) Client {
+	return rds.New(*cfg)
 }
 
 // IsErrorAlreadyExists returns true if the supplied error indicates an instance
@@ -433,6 +429,15 @@ func LateInitialize(in *v1beta1.RDSInstanceParameters, db *rds.DBInstance) { //
 	}
 }
 
+// patchOptions determine which fields of an RDSInstanceParameters patch must
+// be empty for a DB instance to be up to date.
+var patchOptions = []cmp.Option{
+	cmpopts.EquateEmpty(),
+	cmpopts.IgnoreTypes(&v1alpha1.Reference{}, &v1alpha1.Selector{}),
+	cmpopts.IgnoreFields(v1beta1.RDSInstanceParameters{}, "Tags"),
+	cmpopts.IgnoreFields(v1beta1.RDSInstanceParameters{}, "SkipFinalSnapshotBeforeDeletion"),
+}
+
 // IsUpToDate checks whether there is a change in any of the modifiable fields.
 func IsUpToDate(p v1beta1.RDSInstanceParameters, db rds.DBInstance) (bool, error) {
 	// TODO(muvaf): ApplyImmediately and other configurations that exist in
@@ -443,10 +448,19 @@ func IsUpToDate(p v1beta1.RDSInstanceParameters, db rds.DBInstance) (bool, error
 	if err != nil {
 		return false, err
 	}
-	return cmp.Equal(&v1beta1.RDSInstanceParameters{}, patch, cmpopts.EquateEmpty(),
-		cmpopts.IgnoreTypes(&v1alpha1.Reference{}, &v1alpha1.Selector{}),
-		cmpopts.IgnoreFields(v1beta1.RDSInstanceParameters{}, "Tags"),
-		cmpopts.IgnoreFields(v1beta1.RDSInstanceParameters{}, "SkipFinalSnapshotBeforeDeletion")), nil
+	return cmp.Equal(&v1beta1.RDSInstanceParameters{}, patch, patchOptions...), nil
+}
+
+// Diff returns the modifiable fields of the supplied parameters that differ
+// from the supplied DB instance.
+func Diff(p v1beta1.RDSInstanceParameters, db rds.DBInstance) ([]v1alpha3.FieldDrift, error) {
+	patch, err := CreatePatch(&db, &p)
+	if err != nil {
+		return nil, err
+	}
+	observed := &v1beta1.RDSInstanceParameters{}
+	LateInitialize(observed, &db)
+	return awsclients.DiffPatch(observed, patch, patchOptions...), nil
 }
 
 // GetConnectionDetails extracts managed.ConnectionDetails out of v1alpha3.RDSInstance.
@@ -25,6 +25,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 
 	"github.com/crossplane/provider-aws/apis/database/v1beta1"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
 )
 
@@ -162,3 +163,77 @@ func TestIsUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestDiff(t *testing.T) {
+	dbSubnetGroupName := "example-subnet"
+
+	type args struct {
+		db rds.DBInstance
+		p  v1beta1.RDSInstanceParameters
+	}
+
+	cases := map[string]struct {
+		args args
+		want []v1alpha3.FieldDrift
+	}{
+		"SameFields": {
+			args: args{
+				db: rds.DBInstance{
+					AllocatedStorage: aws.Int64(20),
+					DBName:           &dbName,
+				},
+				p: v1beta1.RDSInstanceParameters{
+					AllocatedStorage: aws.IntAddress(aws.Int64(20)),
+					DBName:           &dbName,
+				},
+			},
+			want: nil,
+		},
+		"DifferentFields": {
+			args: args{
+				db: rds.DBInstance{
+					AllocatedStorage: aws.Int64(20),
+					DBName:           &dbName,
+					VpcSecurityGroups: []rds.VpcSecurityGroupMembership{
+						{VpcSecurityGroupId: aws.String("sg-1")},
+					},
+				},
+				p: v1beta1.RDSInstanceParameters{
+					AllocatedStorage:    aws.IntAddress(aws.Int64(30)),
+					DBName:              &dbName,
+					VPCSecurityGroupIDs: []string{"sg-1", "sg-2"},
+				},
+			},
+			want: []v1alpha3.FieldDrift{
+				{Path: "spec.forProvider.allocatedStorage", Observed: "20", Desired: "30"},
+				{Path: "spec.forProvider.vpcSecurityGroupIds", Observed: `["sg-1"]`, Desired: `["sg-1","sg-2"]`},
+			},
+		},
+		"IgnoresRefs": {
+			args: args{
+				db: rds.DBInstance{
+					DBName:        &dbName,
+					DBSubnetGroup: &rds.DBSubnetGroup{DBSubnetGroupName: &dbSubnetGroupName},
+				},
+				p: v1beta1.RDSInstanceParameters{
+					DBName:               &dbName,
+					DBSubnetGroupName:    &dbSubnetGroupName,
+					DBSubnetGroupNameRef: &v1alpha1.Reference{Name: "coolgroup"},
+				},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Diff(tc.args.p, tc.args.db)
+			if err != nil {
+				t.Fatalf("Diff(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Diff(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
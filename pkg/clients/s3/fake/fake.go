@@ -30,6 +30,7 @@ type MockS3Client struct {
 	MockCreateUser           func(username string, bucket *v1alpha3.S3Bucket) (*iam.AccessKey, string, error)
 	MockUpdateBucketACL      func(bucket *v1alpha3.S3Bucket) error
 	MockUpdateVersioning     func(bucket *v1alpha3.S3Bucket) error
+	MockUpdateTagging        func(bucket *v1alpha3.S3Bucket) error
 	MockUpdatePolicyDocument func(username string, bucket *v1alpha3.S3Bucket) (string, error)
 	MockDelete               func(bucket *v1alpha3.S3Bucket) error
 }
@@ -59,6 +60,11 @@ func (m *MockS3Client) UpdateVersioning(bucket *v1alpha3.S3Bucket) error {
 	return m.MockUpdateVersioning(bucket)
 }
 
+// UpdateTagging calls the underlying MockUpdateTagging method.
+func (m *MockS3Client) UpdateTagging(bucket *v1alpha3.S3Bucket) error {
+	return m.MockUpdateTagging(bucket)
+}
+
 // UpdatePolicyDocument calls the underlying MockUpdatePolicyDocument method.
 func (m *MockS3Client) UpdatePolicyDocument(username string, bucket *v1alpha3.S3Bucket) (string, error) {
 	return m.MockUpdatePolicyDocument(username, bucket)
@@ -25,5 +25,6 @@ type Operations interface {
 	GetBucketVersioningRequest(*s3.GetBucketVersioningInput) GetBucketVersioningRequest
 	PutBucketACLRequest(*s3.PutBucketAclInput) PutBucketACLRequest
 	PutBucketVersioningRequest(*s3.PutBucketVersioningInput) PutBucketVersioningRequest
+	PutBucketTaggingRequest(*s3.PutBucketTaggingInput) PutBucketTaggingRequest
 	DeleteBucketRequest(*s3.DeleteBucketInput) DeleteBucketRequest
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/awserr"
@@ -32,6 +33,7 @@ import (
 	storage "github.com/crossplane/crossplane/apis/storage/v1alpha1"
 
 	"github.com/crossplane/provider-aws/apis/storage/v1alpha3"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	iamc "github.com/crossplane/provider-aws/pkg/clients/iam"
 	"github.com/crossplane/provider-aws/pkg/clients/s3/operations"
 )
@@ -51,6 +53,7 @@ type Service interface {
 	CreateUser(username string, bucket *v1alpha3.S3Bucket) (*iam.AccessKey, string, error)
 	UpdateBucketACL(bucket *v1alpha3.S3Bucket) error
 	UpdateVersioning(bucket *v1alpha3.S3Bucket) error
+	UpdateTagging(bucket *v1alpha3.S3Bucket) error
 	UpdatePolicyDocument(username string, bucket *v1alpha3.S3Bucket) (string, error)
 	DeleteBucket(bucket *v1alpha3.S3Bucket) error
 }
@@ -63,7 +66,9 @@ type Client struct {
 
 // NewClient creates new S3 Client with provided AWS Configurations/Credentials
 func NewClient(config *aws.Config) Service {
-	ops := operations.NewS3Operations(s3.New(*config))
+	s3client := s3.New(*config)
+	s3client.ForcePathStyle = awsclients.S3ForcePathStyle(*config)
+	ops := operations.NewS3Operations(s3client)
 	return &Client{s3: ops, iamClient: iamc.NewClient(config)}
 }
 
@@ -149,6 +154,16 @@ func (c *Client) UpdateVersioning(bucket *v1alpha3.S3Bucket) error {
 	return nil
 }
 
+// UpdateTagging of Bucket
+func (c *Client) UpdateTagging(bucket *v1alpha3.S3Bucket) error {
+	if len(bucket.Spec.Tags) == 0 {
+		return nil
+	}
+	input := &s3.PutBucketTaggingInput{Bucket: aws.String(meta.GetExternalName(bucket)), Tagging: &s3.Tagging{TagSet: GenerateTags(bucket.Spec.Tags)}}
+	_, err := c.s3.PutBucketTaggingRequest(input).Send(context.TODO())
+	return err
+}
+
 // UpdatePolicyDocument based on localPermissions
 func (c *Client) UpdatePolicyDocument(username string, bucket *v1alpha3.S3Bucket) (string, error) {
 	policyDocument, err := newPolicyDocument(bucket)
@@ -218,6 +233,17 @@ func CreateBucketInput(bucket *v1alpha3.S3Bucket) *s3.CreateBucketInput {
 	return bucketInput
 }
 
+// GenerateTags returns the supplied map of keys to values as S3 tags, sorted
+// by key.
+func GenerateTags(m map[string]string) []s3.Tag {
+	tags := make([]s3.Tag, 0, len(m))
+	for k, v := range m {
+		tags = append(tags, s3.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	sort.Slice(tags, func(i, j int) bool { return aws.StringValue(tags[i].Key) < aws.StringValue(tags[j].Key) })
+	return tags
+}
+
 // GenerateBucketUsername generates a username that is within AWS size
 // specifications, and adds a random suffix.
 func GenerateBucketUsername(bucket *v1alpha3.S3Bucket) string {
@@ -326,6 +326,66 @@ func TestClient_UpdateVersioning(t *testing.T) {
 	}
 }
 
+func TestClient_UpdateTagging(t *testing.T) {
+	boom := errors.New("boom")
+	// Define test cases
+	tests := map[string]struct {
+		bucket  *awsstorage.S3Bucket
+		sendRet []interface{}
+		ret     []types.GomegaMatcher
+	}{
+		"HappyPath": {
+			bucket: &awsstorage.S3Bucket{
+				Spec: awsstorage.S3BucketSpec{
+					S3BucketParameters: awsstorage.S3BucketParameters{
+						Tags: map[string]string{"team": "cool"},
+					},
+				},
+			},
+			sendRet: []interface{}{&s3.PutBucketTaggingResponse{}, nil},
+			ret:     []types.GomegaMatcher{gomega.BeNil()},
+		},
+		"NoTags": {
+			bucket:  &awsstorage.S3Bucket{},
+			sendRet: []interface{}{&s3.PutBucketTaggingResponse{}, boom},
+			ret:     []types.GomegaMatcher{gomega.BeNil()},
+		},
+		"SendError": {
+			bucket: &awsstorage.S3Bucket{
+				Spec: awsstorage.S3BucketSpec{
+					S3BucketParameters: awsstorage.S3BucketParameters{
+						Tags: map[string]string{"team": "cool"},
+					},
+				},
+			},
+			sendRet: []interface{}{&s3.PutBucketTaggingResponse{}, boom},
+			ret:     []types.GomegaMatcher{gomega.Equal(boom)},
+		},
+	}
+
+	for testName, vals := range tests {
+		t.Run(testName, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			// Set up mocks
+			putBucketTagging := new(fakeops.PutBucketTaggingRequest)
+			putBucketTagging.On("Send", context.TODO()).Return(vals.sendRet...)
+
+			ops := new(fakeops.Operations)
+			ops.On("PutBucketTaggingRequest", mock.Anything).Return(putBucketTagging)
+
+			// Create thing we are testing
+			c := Client{s3: ops}
+
+			// Call the method under test
+			err := c.UpdateTagging(vals.bucket)
+
+			// Make assertions
+			g.Expect(err).To(vals.ret[0])
+		})
+	}
+}
+
 func TestClient_UpdatePolicyDocument(t *testing.T) {
 	boom := errors.New("boom")
 	user := "han"
@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/processcreds"
+	"github.com/aws/aws-sdk-go-v2/aws/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/go-ini/ini"
+	"github.com/pkg/errors"
+)
+
+// Shared config keys.
+const (
+	keyAccessKeyID       = "aws_access_key_id"
+	keySecretAccessKey   = "aws_secret_access_key"
+	keySessionToken      = "aws_session_token"
+	keyRoleARN           = "role_arn"
+	keySourceProfile     = "source_profile"
+	keyExternalID        = "external_id"
+	keyRoleSessionName   = "role_session_name"
+	keyDurationSeconds   = "duration_seconds"
+	keyCredentialProcess = "credential_process"
+
+	profilePrefix = "profile "
+)
+
+const (
+	errFmtNoProfile       = "cannot find profile %q"
+	errFmtProfileLoop     = "source_profile of profile %q forms a loop"
+	errFmtNoSourceProfile = "profile %q specifies a role_arn but no source_profile"
+	errFmtNoCredentials   = "profile %q does not contain credentials"
+	errFmtDurationSeconds = "cannot parse duration_seconds of profile %q"
+	errFmtSourceProfile   = "cannot resolve source_profile of profile %q"
+	errFmtProcessDisabled = "profile %q specifies a credential_process but running credential processes is not allowed"
+)
+
+// AllowCredentialProcess allows profiles to obtain credentials by running
+// their credential_process. The process runs inside the provider, so anyone
+// who can write a Provider's credentials Secret could run any command with the
+// provider's permissions. It is therefore disallowed by default.
+var AllowCredentialProcess = false
+
+// SharedConfigCredentials returns a provider of the credentials described by
+// the supplied profile of the supplied AWS shared config or credentials file
+// data. Credentials are resolved like the AWS CLI resolves them:
+//
+//   - A profile that specifies a role_arn assumes that role using the
+//     credentials of its source_profile, which may itself assume a role.
+//   - A profile that specifies an aws_access_key_id and aws_secret_access_key,
+//     and optionally an aws_session_token, uses those static credentials.
+//   - A profile that specifies a credential_process runs that process to
+//     obtain credentials, if AllowCredentialProcess is true.
+//
+// Profiles may be named either 'name' or 'profile name'. Roles are assumed
+// using the supplied config.
+func SharedConfigCredentials(cfg aws.Config, data []byte, profile string) (aws.CredentialsProvider, error) {
+	f, err := ini.InsensitiveLoad(data)
+	if err != nil {
+		return nil, err
+	}
+	return profileCredentials(cfg, f, profile, map[string]bool{})
+}
+
+func profileCredentials(cfg aws.Config, f *ini.File, profile string, visited map[string]bool) (aws.CredentialsProvider, error) {
+	// Profile names are case insensitive, like the rest of the file.
+	profile = strings.ToLower(profile)
+	if visited[profile] {
+		return nil, errors.Errorf(errFmtProfileLoop, profile)
+	}
+	visited[profile] = true
+
+	s, err := profileSection(f, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	roleARN := s.Key(keyRoleARN).String()
+	source := strings.ToLower(s.Key(keySourceProfile).String())
+	if roleARN != "" && source == "" {
+		return nil, errors.Errorf(errFmtNoSourceProfile, profile)
+	}
+
+	var creds aws.CredentialsProvider
+	switch {
+	// A profile may use its own static credentials as the source of the role
+	// it assumes by naming itself as its source_profile.
+	case roleARN != "" && source != profile:
+		creds, err = profileCredentials(cfg, f, source, visited)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtSourceProfile, profile)
+		}
+	case s.HasKey(keyAccessKeyID) && s.HasKey(keySecretAccessKey):
+		creds = aws.StaticCredentialsProvider{Value: aws.Credentials{
+			AccessKeyID:     s.Key(keyAccessKeyID).String(),
+			SecretAccessKey: s.Key(keySecretAccessKey).String(),
+			SessionToken:    s.Key(keySessionToken).String(),
+			Source:          aws.StaticCredentialsProviderName,
+		}}
+	case s.HasKey(keyCredentialProcess):
+		if !AllowCredentialProcess {
+			return nil, errors.Errorf(errFmtProcessDisabled, profile)
+		}
+		creds = processcreds.NewProvider(s.Key(keyCredentialProcess).String())
+	default:
+		return nil, errors.Errorf(errFmtNoCredentials, profile)
+	}
+
+	if roleARN == "" {
+		return creds, nil
+	}
+
+	var d time.Duration
+	if s.HasKey(keyDurationSeconds) {
+		secs, err := strconv.Atoi(s.Key(keyDurationSeconds).String())
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtDurationSeconds, profile)
+		}
+		d = time.Duration(secs) * time.Second
+	}
+
+	c := cfg.Copy()
+	c.Credentials = creds
+	return stscreds.NewAssumeRoleProvider(sts.New(c), roleARN, func(o *stscreds.AssumeRoleProviderOptions) {
+		if s.HasKey(keyExternalID) {
+			o.ExternalID = aws.String(s.Key(keyExternalID).String())
+		}
+		o.RoleSessionName = s.Key(keyRoleSessionName).String()
+		if d > 0 {
+			o.Duration = d
+		}
+	}), nil
+}
+
+// profileSection returns the section of the supplied file that describes the
+// supplied profile. Credentials files name their sections after profiles,
+// while config files prefix all but the default profile with 'profile '.
+func profileSection(f *ini.File, profile string) (*ini.Section, error) {
+	for _, name := range []string{profile, profilePrefix + profile} {
+		if s, err := f.GetSection(name); err == nil {
+			return s, nil
+		}
+	}
+	return nil, errors.Errorf(errFmtNoProfile, profile)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/processcreds"
+	"github.com/aws/aws-sdk-go-v2/aws/stscreds"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestSharedConfigCredentials(t *testing.T) {
+	type args struct {
+		data                   string
+		profile                string
+		allowCredentialProcess bool
+	}
+	type want struct {
+		creds      aws.Credentials
+		assumeRole bool
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"StaticCredentials": {
+			reason: "Static credentials should be read from the profile.",
+			args: args{
+				data:    "[default]\naws_access_key_id = id\naws_secret_access_key = secret",
+				profile: DefaultSection,
+			},
+			want: want{creds: aws.Credentials{AccessKeyID: "id", SecretAccessKey: "secret", Source: aws.StaticCredentialsProviderName}},
+		},
+		"SessionToken": {
+			reason: "A session token should be read along with static credentials.",
+			args: args{
+				data:    "[default]\naws_access_key_id = id\naws_secret_access_key = secret\naws_session_token = token",
+				profile: DefaultSection,
+			},
+			want: want{creds: aws.Credentials{AccessKeyID: "id", SecretAccessKey: "secret", SessionToken: "token", Source: aws.StaticCredentialsProviderName}},
+		},
+		"NamedProfile": {
+			reason: "Credentials should be read from the supplied profile.",
+			args: args{
+				data:    "[default]\naws_access_key_id = id\naws_secret_access_key = secret\n[cool]\naws_access_key_id = coolid\naws_secret_access_key = coolsecret",
+				profile: "cool",
+			},
+			want: want{creds: aws.Credentials{AccessKeyID: "coolid", SecretAccessKey: "coolsecret", Source: aws.StaticCredentialsProviderName}},
+		},
+		"ConfigFileProfile": {
+			reason: "Profiles named like those of an AWS config file should be found.",
+			args: args{
+				data:    "[profile cool]\naws_access_key_id = coolid\naws_secret_access_key = coolsecret",
+				profile: "cool",
+			},
+			want: want{creds: aws.Credentials{AccessKeyID: "coolid", SecretAccessKey: "coolsecret", Source: aws.StaticCredentialsProviderName}},
+		},
+		"CredentialProcess": {
+			reason: "Credentials should be obtained by running the profile's credential_process.",
+			args: args{
+				data:                   `[default]` + "\n" + `credential_process = echo '{"Version": 1, "AccessKeyId": "id", "SecretAccessKey": "secret", "SessionToken": "token"}'`,
+				profile:                DefaultSection,
+				allowCredentialProcess: true,
+			},
+			want: want{creds: aws.Credentials{AccessKeyID: "id", SecretAccessKey: "secret", SessionToken: "token", Source: processcreds.ProviderName}},
+		},
+		"CredentialProcessNotAllowed": {
+			reason: "An error should be returned if the profile specifies a credential_process but running it is not allowed.",
+			args: args{
+				data:    `[default]` + "\n" + `credential_process = echo '{"Version": 1, "AccessKeyId": "id", "SecretAccessKey": "secret"}'`,
+				profile: DefaultSection,
+			},
+			want: want{err: errors.Errorf(errFmtProcessDisabled, "default")},
+		},
+		"SourceProfile": {
+			reason: "A profile with a role_arn should assume that role.",
+			args: args{
+				data:    "[default]\naws_access_key_id = id\naws_secret_access_key = secret\n[cool]\nrole_arn = arn:aws:iam::123456789012:role/cool\nsource_profile = default\nduration_seconds = 900",
+				profile: "cool",
+			},
+			want: want{assumeRole: true},
+		},
+		"SelfSourceProfile": {
+			reason: "A profile may use its own static credentials to assume a role.",
+			args: args{
+				data:    "[cool]\naws_access_key_id = id\naws_secret_access_key = secret\nrole_arn = arn:aws:iam::123456789012:role/cool\nsource_profile = cool",
+				profile: "cool",
+			},
+			want: want{assumeRole: true},
+		},
+		"NoProfile": {
+			reason: "An error should be returned if the profile does not exist.",
+			args: args{
+				data:    "[default]\naws_access_key_id = id\naws_secret_access_key = secret",
+				profile: "cool",
+			},
+			want: want{err: errors.Errorf(errFmtNoProfile, "cool")},
+		},
+		"NoCredentials": {
+			reason: "An error should be returned if the profile does not contain credentials.",
+			args: args{
+				data:    "[default]\nregion = us-east-1",
+				profile: DefaultSection,
+			},
+			want: want{err: errors.Errorf(errFmtNoCredentials, "default")},
+		},
+		"NoSourceProfile": {
+			reason: "An error should be returned if a profile with a role_arn has no source_profile.",
+			args: args{
+				data:    "[default]\nrole_arn = arn:aws:iam::123456789012:role/cool",
+				profile: DefaultSection,
+			},
+			want: want{err: errors.Errorf(errFmtNoSourceProfile, "default")},
+		},
+		"SourceProfileLoop": {
+			reason: "An error should be returned if source profiles form a loop.",
+			args: args{
+				data:    "[a]\nrole_arn = arn:aws:iam::123456789012:role/a\nsource_profile = b\n[b]\nrole_arn = arn:aws:iam::123456789012:role/b\nsource_profile = a",
+				profile: "a",
+			},
+			want: want{err: errors.Wrapf(errors.Wrapf(errors.Errorf(errFmtProfileLoop, "a"), errFmtSourceProfile, "b"), errFmtSourceProfile, "a")},
+		},
+		"InvalidDuration": {
+			reason: "An error should be returned if duration_seconds is not a number.",
+			args: args{
+				data:    "[default]\naws_access_key_id = id\naws_secret_access_key = secret\nrole_arn = arn:aws:iam::123456789012:role/cool\nsource_profile = default\nduration_seconds = soon",
+				profile: DefaultSection,
+			},
+			want: want{err: errors.Wrapf(&strconv.NumError{Func: "Atoi", Num: "soon", Err: strconv.ErrSyntax}, errFmtDurationSeconds, "default")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			AllowCredentialProcess = tc.args.allowCredentialProcess
+			defer func() { AllowCredentialProcess = false }()
+			creds, err := SharedConfigCredentials(aws.Config{}, []byte(tc.args.data), tc.args.profile)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSharedConfigCredentials(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if _, ok := creds.(*stscreds.AssumeRoleProvider); ok != tc.want.assumeRole {
+				t.Errorf("\n%s\nSharedConfigCredentials(...): want assume role %t, got %t", tc.reason, tc.want.assumeRole, ok)
+			}
+			if tc.want.assumeRole {
+				return
+			}
+			got, err := creds.Retrieve(context.Background())
+			if err != nil {
+				t.Fatalf("\n%s\ncreds.Retrieve(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.creds, got, cmpopts.IgnoreFields(aws.Credentials{}, "CanExpire", "Expires")); diff != "" {
+				t.Errorf("\n%s\nSharedConfigCredentials(...): -want credentials, +got credentials:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
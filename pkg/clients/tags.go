@@ -0,0 +1,240 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
+)
+
+// AnnotationKeyDefaultTags is the key of the annotation that records which of
+// the tags of a managed resource's spec were added from the default tags of
+// its Provider, as a JSON object.
+const AnnotationKeyDefaultTags = "aws.crossplane.io/default-tags"
+
+// reservedTagPrefix prefixes the keys of tags that are set by AWS, and that
+// can't be deleted.
+const reservedTagPrefix = "aws:"
+
+const (
+	errGetTags        = "cannot get tags of managed resource"
+	errUpdateTags     = "cannot update tags of managed resource"
+	errGetDefaultTags = "cannot get default tags of provider"
+)
+
+// A TagsFn returns a pointer to the tags of the supplied managed resource's
+// spec. The tags must be either a map[string]string, or a slice of structs with
+// Key and Value string fields, like the Tag types of this provider's APIs.
+type TagsFn func(mg resource.Managed) (interface{}, error)
+
+// A Tagger is a managed.Initializer that adds the default tags of a managed
+// resource's Provider and the tags that identify it as a Crossplane managed
+// resource to the tags of its spec.
+type Tagger struct {
+	kube client.Client
+	tags TagsFn
+}
+
+// NewTagger returns a Tagger that uses the supplied function to find the tags
+// of a managed resource's spec.
+func NewTagger(kube client.Client, fn TagsFn) *Tagger {
+	return &Tagger{kube: kube, tags: fn}
+}
+
+// Initialize the tags of the supplied managed resource. Tags specified by the
+// managed resource override its Provider's default tags, and are in turn
+// overridden by the tags that identify it as a Crossplane managed resource.
+// Tags previously added from the Provider's default tags are updated or
+// removed when the default tags change.
+func (t *Tagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	field, err := t.tags(mg)
+	if err != nil {
+		return errors.Wrap(err, errGetTags)
+	}
+	tags := TagMap(field)
+	defaults, err := GetDefaultTags(ctx, t.kube, mg)
+	if err != nil {
+		return err
+	}
+
+	previous := getAppliedDefaultTags(mg)
+	merged, applied := MergeTags(defaults, tags, previous)
+	for k, v := range resource.GetExternalTags(mg) {
+		merged[k] = v
+	}
+	if reflect.DeepEqual(merged, tags) && reflect.DeepEqual(applied, previous) {
+		return nil
+	}
+
+	SetTagMap(field, merged)
+	setAppliedDefaultTags(mg, applied)
+	return errors.Wrap(t.kube.Update(ctx, mg), errUpdateTags)
+}
+
+// GetDefaultTags returns the default tags of the supplied managed resource's
+// Provider.
+func GetDefaultTags(ctx context.Context, c client.Reader, mg resource.Managed) (map[string]string, error) {
+	if mg.GetProviderReference() == nil {
+		return nil, nil
+	}
+	p := &v1alpha3.Provider{}
+	if err := c.Get(ctx, meta.NamespacedNameOf(mg.GetProviderReference()), p); err != nil {
+		return nil, errors.Wrap(err, errGetDefaultTags)
+	}
+	return p.Spec.DefaultTags, nil
+}
+
+// MergeTags merges the supplied default tags into the supplied tags, which
+// override them. The supplied previously applied default tags are those that
+// were added to the tags by a previous merge, and are thus replaced by the
+// supplied default tags rather than overriding them. MergeTags returns the
+// merged tags, and the default tags that were applied to them.
+func MergeTags(defaults, tags, previous map[string]string) (map[string]string, map[string]string) {
+	merged := make(map[string]string, len(defaults)+len(tags))
+	applied := map[string]string{}
+	for k, v := range defaults {
+		merged[k] = v
+		applied[k] = v
+	}
+	for k, v := range tags {
+		if pv, ok := previous[k]; ok && pv == v {
+			continue
+		}
+		merged[k] = v
+		delete(applied, k)
+	}
+	return merged, applied
+}
+
+// TagMap returns the supplied tags as a map of keys to values. The tags, or
+// the value they point to, must be either a map[string]string or a slice of
+// structs with string or *string Key and Value fields, like the Tag types of
+// this provider's APIs and of the AWS SDK.
+func TagMap(tags interface{}) map[string]string {
+	v := reflect.Indirect(reflect.ValueOf(tags))
+	m := make(map[string]string, v.Len())
+	if v.Kind() == reflect.Map {
+		for _, k := range v.MapKeys() {
+			m[k.String()] = v.MapIndex(k).String()
+		}
+		return m
+	}
+	for i := 0; i < v.Len(); i++ {
+		t := v.Index(i)
+		m[stringValue(t.FieldByName("Key"))] = stringValue(t.FieldByName("Value"))
+	}
+	return m
+}
+
+// SetTagMap sets the tags pointed to by the supplied pointer to the supplied
+// map of keys to values. The tags must be either a map[string]string or a
+// slice of structs with string or *string Key and Value fields, which is
+// sorted by key.
+func SetTagMap(tags interface{}, m map[string]string) {
+	v := reflect.ValueOf(tags).Elem()
+	if v.Kind() == reflect.Map {
+		v.Set(reflect.ValueOf(m))
+		return
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	s := reflect.MakeSlice(v.Type(), len(keys), len(keys))
+	for i, k := range keys {
+		setStringValue(s.Index(i).FieldByName("Key"), k)
+		setStringValue(s.Index(i).FieldByName("Value"), m[k])
+	}
+	v.Set(s)
+}
+
+// DiffTags returns the tags that must be added, because they are missing or
+// their values differ, and the keys of the tags that must be removed, sorted,
+// in order for the observed tags to match the desired tags. Tags that are
+// reserved by AWS are never removed.
+func DiffTags(desired, observed map[string]string) (add map[string]string, remove []string) {
+	add = map[string]string{}
+	for k, v := range desired {
+		if ov, ok := observed[k]; !ok || ov != v {
+			add[k] = v
+		}
+	}
+	for k := range observed {
+		if _, ok := desired[k]; !ok && !IsReservedTag(k) {
+			remove = append(remove, k)
+		}
+	}
+	sort.Strings(remove)
+	return add, remove
+}
+
+// IsReservedTag returns true if the supplied tag key is reserved by AWS. Such
+// tags are set by AWS, and can't be changed or deleted.
+func IsReservedTag(key string) bool {
+	return strings.HasPrefix(key, reservedTagPrefix)
+}
+
+func stringValue(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	return v.String()
+}
+
+func setStringValue(v reflect.Value, s string) {
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.ValueOf(&s))
+		return
+	}
+	v.SetString(s)
+}
+
+func getAppliedDefaultTags(o resource.Object) map[string]string {
+	tags := map[string]string{}
+	a, ok := o.GetAnnotations()[AnnotationKeyDefaultTags]
+	if !ok {
+		return tags
+	}
+	// An annotation that cannot be decoded is treated as if no default tags
+	// were previously applied.
+	_ = json.Unmarshal([]byte(a), &tags)
+	return tags
+}
+
+func setAppliedDefaultTags(o resource.Object, tags map[string]string) {
+	if len(tags) == 0 {
+		meta.RemoveAnnotations(o, AnnotationKeyDefaultTags)
+		return
+	}
+	b, _ := json.Marshal(tags)
+	meta.AddAnnotations(o, map[string]string{AnnotationKeyDefaultTags: string(b)})
+}
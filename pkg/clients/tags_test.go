@@ -0,0 +1,340 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
+)
+
+func TestMergeTags(t *testing.T) {
+	type args struct {
+		defaults map[string]string
+		tags     map[string]string
+		previous map[string]string
+	}
+	type want struct {
+		merged  map[string]string
+		applied map[string]string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoDefaults": {
+			reason: "Tags should be unchanged when there are no default tags.",
+			args:   args{tags: map[string]string{"team": "cool"}},
+			want: want{
+				merged:  map[string]string{"team": "cool"},
+				applied: map[string]string{},
+			},
+		},
+		"Defaults": {
+			reason: "Default tags should be added to the tags, which override them.",
+			args: args{
+				defaults: map[string]string{"team": "default", "env": "prod"},
+				tags:     map[string]string{"team": "cool"},
+			},
+			want: want{
+				merged:  map[string]string{"team": "cool", "env": "prod"},
+				applied: map[string]string{"env": "prod"},
+			},
+		},
+		"ChangedDefaults": {
+			reason: "Previously applied default tags should be replaced by the current default tags.",
+			args: args{
+				defaults: map[string]string{"env": "dev"},
+				tags:     map[string]string{"team": "cool", "env": "prod"},
+				previous: map[string]string{"env": "prod"},
+			},
+			want: want{
+				merged:  map[string]string{"team": "cool", "env": "dev"},
+				applied: map[string]string{"env": "dev"},
+			},
+		},
+		"RemovedDefaults": {
+			reason: "Previously applied default tags should be removed when they are no longer defaults.",
+			args: args{
+				tags:     map[string]string{"team": "cool", "env": "prod"},
+				previous: map[string]string{"env": "prod"},
+			},
+			want: want{
+				merged:  map[string]string{"team": "cool"},
+				applied: map[string]string{},
+			},
+		},
+		"OverriddenDefaults": {
+			reason: "Previously applied default tags whose values were since changed should override the default tags.",
+			args: args{
+				defaults: map[string]string{"env": "prod"},
+				tags:     map[string]string{"env": "dev"},
+				previous: map[string]string{"env": "prod"},
+			},
+			want: want{
+				merged:  map[string]string{"env": "dev"},
+				applied: map[string]string{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			merged, applied := MergeTags(tc.args.defaults, tc.args.tags, tc.args.previous)
+			if diff := cmp.Diff(tc.want.merged, merged); diff != "" {
+				t.Errorf("\n%s\nMergeTags(...): -want merged, +got merged:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.applied, applied); diff != "" {
+				t.Errorf("\n%s\nMergeTags(...): -want applied, +got applied:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// tag is a tag of a managed resource's spec.
+type tag struct {
+	Key   string
+	Value string
+}
+
+func TestTagMap(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		tags   interface{}
+		want   map[string]string
+	}{
+		"Map": {
+			reason: "Tags that are a map should be copied.",
+			tags:   map[string]string{"a": "1"},
+			want:   map[string]string{"a": "1"},
+		},
+		"Slice": {
+			reason: "Tags that are a slice should be converted to a map.",
+			tags:   []tag{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}},
+			want:   map[string]string{"a": "1", "b": "2"},
+		},
+		"SDKSlice": {
+			reason: "Tags of the AWS SDK, whose keys and values are pointers, should be converted to a map.",
+			tags:   []iam.Tag{{Key: aws.String("a"), Value: aws.String("1")}, {Key: aws.String("b")}},
+			want:   map[string]string{"a": "1", "b": ""},
+		},
+		"Pointer": {
+			reason: "Tags that are pointed to should be converted to a map.",
+			tags:   &[]tag{{Key: "a", Value: "1"}},
+			want:   map[string]string{"a": "1"},
+		},
+		"Nil": {
+			reason: "Tags that are nil should be converted to an empty map.",
+			tags:   []tag(nil),
+			want:   map[string]string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := TagMap(tc.tags)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nTagMap(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetTagMap(t *testing.T) {
+	m := map[string]string{"b": "2", "a": "1"}
+
+	gotMap := map[string]string{"c": "3"}
+	SetTagMap(&gotMap, m)
+	if diff := cmp.Diff(m, gotMap); diff != "" {
+		t.Errorf("SetTagMap(...): -want map, +got map:\n%s", diff)
+	}
+
+	gotSlice := []tag{{Key: "c", Value: "3"}}
+	SetTagMap(&gotSlice, m)
+	if diff := cmp.Diff([]tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}, gotSlice); diff != "" {
+		t.Errorf("SetTagMap(...): -want slice sorted by key, +got slice:\n%s", diff)
+	}
+
+	var gotSDK []iam.Tag
+	SetTagMap(&gotSDK, m)
+	wantSDK := []iam.Tag{{Key: aws.String("a"), Value: aws.String("1")}, {Key: aws.String("b"), Value: aws.String("2")}}
+	if diff := cmp.Diff(wantSDK, gotSDK); diff != "" {
+		t.Errorf("SetTagMap(...): -want SDK tags sorted by key, +got SDK tags:\n%s", diff)
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+	type want struct {
+		add    map[string]string
+		remove []string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		desired  map[string]string
+		observed map[string]string
+		want     want
+	}{
+		"UpToDate": {
+			reason:   "Nothing should be added or removed when the tags are up to date.",
+			desired:  map[string]string{"a": "1"},
+			observed: map[string]string{"a": "1"},
+			want:     want{add: map[string]string{}},
+		},
+		"Changed": {
+			reason:   "Missing and changed tags should be added, and unwanted tags removed.",
+			desired:  map[string]string{"a": "1", "b": "2"},
+			observed: map[string]string{"b": "1", "d": "4", "c": "3"},
+			want: want{
+				add:    map[string]string{"a": "1", "b": "2"},
+				remove: []string{"c", "d"},
+			},
+		},
+		"Reserved": {
+			reason:   "Tags reserved by AWS should never be removed.",
+			observed: map[string]string{"aws:cloudformation:stack-name": "cool"},
+			want:     want{add: map[string]string{}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			add, remove := DiffTags(tc.desired, tc.observed)
+			if diff := cmp.Diff(tc.want.add, add); diff != "" {
+				t.Errorf("\n%s\nDiffTags(...): -want add, +got add:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.remove, remove); diff != "" {
+				t.Errorf("\n%s\nDiffTags(...): -want remove, +got remove:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// taggable is a managed resource whose spec has tags.
+type taggable struct {
+	fake.Managed
+	tags map[string]string
+}
+
+func taggableTags(mg resource.Managed) (interface{}, error) {
+	return &mg.(*taggable).tags, nil
+}
+
+func TestTaggerInitialize(t *testing.T) {
+	p := &v1alpha3.Provider{Spec: v1alpha3.ProviderSpec{DefaultTags: map[string]string{"env": "prod"}}}
+	ref := fake.ProviderReferencer{Ref: &corev1.ObjectReference{Name: testProviderName}}
+	meta := metav1.ObjectMeta{Name: "cool"}
+	withExternalTags := func(tags map[string]string) map[string]string {
+		for k, v := range resource.GetExternalTags(&fake.Managed{ObjectMeta: meta, ProviderReferencer: ref}) {
+			tags[k] = v
+		}
+		return tags
+	}
+
+	type want struct {
+		tags        map[string]string
+		annotations map[string]string
+		err         error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		mg     *taggable
+		want   want
+	}{
+		"GetDefaultTagsError": {
+			reason: "Errors getting the default tags of the Provider should be returned.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     &taggable{Managed: fake.Managed{ProviderReferencer: ref}},
+			want:   want{err: errors.Wrap(errBoom, errGetDefaultTags)},
+		},
+		"UpdateError": {
+			reason: "Errors updating the managed resource should be returned.",
+			kube: &test.MockClient{
+				MockGet:    testKube(p, nil).(*test.MockClient).MockGet,
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			mg: &taggable{Managed: fake.Managed{ObjectMeta: meta, ProviderReferencer: ref}},
+			want: want{
+				tags:        withExternalTags(map[string]string{"env": "prod"}),
+				annotations: map[string]string{AnnotationKeyDefaultTags: `{"env":"prod"}`},
+				err:         errors.Wrap(errBoom, errUpdateTags),
+			},
+		},
+		"Successful": {
+			reason: "Default tags and external tags should be added to the tags of the managed resource.",
+			kube: &test.MockClient{
+				MockGet:    testKube(p, nil).(*test.MockClient).MockGet,
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			mg: &taggable{Managed: fake.Managed{ObjectMeta: meta, ProviderReferencer: ref}, tags: map[string]string{"team": "cool"}},
+			want: want{
+				tags:        withExternalTags(map[string]string{"env": "prod", "team": "cool"}),
+				annotations: map[string]string{AnnotationKeyDefaultTags: `{"env":"prod"}`},
+			},
+		},
+		"NoChanges": {
+			reason: "The managed resource should not be updated if its tags are unchanged.",
+			kube:   &test.MockClient{MockGet: testKube(p, nil).(*test.MockClient).MockGet},
+			mg: &taggable{
+				Managed: fake.Managed{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cool",
+						Annotations: map[string]string{AnnotationKeyDefaultTags: `{"env":"prod"}`},
+					},
+					ProviderReferencer: ref,
+				},
+				tags: withExternalTags(map[string]string{"env": "prod"}),
+			},
+			want: want{
+				tags:        withExternalTags(map[string]string{"env": "prod"}),
+				annotations: map[string]string{AnnotationKeyDefaultTags: `{"env":"prod"}`},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewTagger(tc.kube, taggableTags).Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.tags, tc.mg.tags); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want tags, +got tags:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.annotations, tc.mg.GetAnnotations()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want annotations, +got annotations:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
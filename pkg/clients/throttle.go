@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+const (
+	rateLimitHandlerName = "crossplane.provider-aws.RateLimit"
+
+	errWaitRateLimit = "cannot wait for AWS API rate limiter"
+)
+
+// Defaults for rate limiting and retrying AWS API requests.
+const (
+	// DefaultRequestsPerSecond is the default rate at which AWS API requests
+	// may be made to each service in each region.
+	DefaultRequestsPerSecond = 10.0
+
+	// DefaultRequestBurst is the default maximum number of AWS API requests
+	// that may be made to each service in each region in a burst.
+	DefaultRequestBurst = 20
+
+	// DefaultMaxAttempts is the default number of times an AWS API request is
+	// attempted before it fails.
+	DefaultMaxAttempts = 5
+
+	// DefaultMaxBackoff is the default maximum delay before retrying an AWS
+	// API request that failed for a reason other than throttling.
+	DefaultMaxBackoff = retry.DefaultMaxBackoff
+
+	// DefaultMaxThrottleBackoff is the default maximum delay before retrying
+	// an AWS API request that was throttled.
+	DefaultMaxThrottleBackoff = 30 * time.Second
+)
+
+// DefaultRateLimiter limits the rate of the AWS API requests made using the
+// configs produced by UseProviderSecret and UsePodServiceAccount.
+var DefaultRateLimiter = NewRateLimiter(DefaultRequestsPerSecond, DefaultRequestBurst)
+
+// DefaultRetryOptions configure how the AWS API requests made using the
+// configs produced by UseProviderSecret and UsePodServiceAccount are retried.
+var DefaultRetryOptions = RetryOptions{
+	MaxAttempts:        DefaultMaxAttempts,
+	MaxBackoff:         DefaultMaxBackoff,
+	MaxThrottleBackoff: DefaultMaxThrottleBackoff,
+}
+
+// ThrottlingErrorCodes are the AWS API error codes that indicate a request was
+// throttled.
+var ThrottlingErrorCodes = map[string]struct{}{
+	"Throttling":                             {},
+	"ThrottlingException":                    {},
+	"ThrottledException":                     {},
+	"RequestThrottledException":              {},
+	"TooManyRequestsException":               {},
+	"ProvisionedThroughputExceededException": {},
+	"RequestLimitExceeded":                   {},
+	"BandwidthLimitExceeded":                 {},
+	"RequestThrottled":                       {},
+	"SlowDown":                               {},
+	"EC2ThrottledException":                  {},
+}
+
+// IsThrottlingError returns true if the supplied error indicates that an AWS
+// API request was throttled.
+func IsThrottlingError(err error) bool {
+	return retry.RetryableErrorCode{Codes: ThrottlingErrorCodes}.IsErrorRetryable(err).Bool()
+}
+
+// RetryOptions configure how AWS API requests are retried.
+type RetryOptions struct {
+	// MaxAttempts is the number of times a request is attempted before it
+	// fails.
+	MaxAttempts int
+
+	// MaxBackoff is the maximum delay before retrying a request that failed
+	// for a reason other than throttling.
+	MaxBackoff time.Duration
+
+	// MaxThrottleBackoff is the maximum delay before retrying a request that
+	// was throttled.
+	MaxThrottleBackoff time.Duration
+}
+
+// NewRetryer returns an aws.Retryer that retries requests using the SDK's
+// standard retryer, except that throttled requests are retried with their own
+// jittered exponential backoff and do not consume the retry quota.
+func NewRetryer(o RetryOptions) aws.Retryer {
+	return &throttlingRetryer{
+		Standard: retry.NewStandard(func(so *retry.StandardOptions) {
+			so.MaxAttempts = o.MaxAttempts
+			so.MaxBackoff = o.MaxBackoff
+		}),
+		throttleBackoff: retry.NewExponentialJitterBackoff(o.MaxThrottleBackoff),
+	}
+}
+
+type throttlingRetryer struct {
+	*retry.Standard
+	throttleBackoff retry.BackoffDelayer
+}
+
+// RetryDelay returns the delay before retrying a request.
+func (r *throttlingRetryer) RetryDelay(attempt int, err error) (time.Duration, error) {
+	if IsThrottlingError(err) {
+		return r.throttleBackoff.BackoffDelay(attempt, err)
+	}
+	return r.Standard.RetryDelay(attempt, err)
+}
+
+// GetRetryToken deducts the cost of retrying a request from the retry quota.
+// Throttled requests are retried for free; when many requests are throttled
+// at once the quota would otherwise be exhausted, causing every request to
+// fail immediately rather than backing off. The RateLimiter is responsible
+// for protecting AWS from throttled retries.
+func (r *throttlingRetryer) GetRetryToken(ctx context.Context, err error) (func(error) error, error) {
+	if IsThrottlingError(err) {
+		return func(error) error { return nil }, nil
+	}
+	return r.Standard.GetRetryToken(ctx, err)
+}
+
+// A RateLimiter limits the rate of AWS API requests made to each service in
+// each region using a token bucket.
+type RateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewRateLimiter returns a RateLimiter that allows the supplied number of
+// requests per second, in bursts of up to the supplied size, to each service
+// in each region. Requests are not limited if the supplied rate is not
+// positive.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	l := rate.Limit(rps)
+	if rps <= 0 {
+		l = rate.Inf
+	}
+	return &RateLimiter{limit: l, burst: burst, limiters: map[string]*rate.Limiter{}}
+}
+
+// Wait until a request may be made to the supplied service in the supplied
+// region, or the supplied context is done.
+func (l *RateLimiter) Wait(ctx context.Context, service, region string) error {
+	return l.limiter(service, region).Wait(ctx)
+}
+
+func (l *RateLimiter) limiter(service, region string) *rate.Limiter {
+	key := service + "/" + region
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rl, ok := l.limiters[key]
+	if !ok {
+		rl = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[key] = rl
+	}
+	return rl
+}
+
+// Throttle configures the supplied config to rate limit each attempt of its
+// AWS API requests using the supplied RateLimiter, and to retry them using a
+// retryer created with the supplied RetryOptions. Throttling a config that is
+// already throttled replaces its RateLimiter and retryer.
+func Throttle(cfg *aws.Config, l *RateLimiter, o RetryOptions) {
+	cfg.Retryer = NewRetryer(o)
+	// Requests are limited as they are signed because signing happens before
+	// every attempt, and unlike sending stops the attempt if it fails.
+	cfg.Handlers.Sign.SetFrontNamed(aws.NamedHandler{Name: rateLimitHandlerName, Fn: func(r *aws.Request) {
+		// Presigning a request doesn't send it.
+		if r.ExpireTime != 0 {
+			return
+		}
+		if err := l.Wait(r.Context(), r.Metadata.EndpointsID, r.Config.Region); err != nil {
+			r.Error = errors.Wrap(err, errWaitRateLimit)
+		}
+	}})
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/pkg/errors"
+)
+
+func TestIsThrottlingError(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"Throttled": {
+			reason: "An AWS error with a throttling code should be a throttling error.",
+			err:    awserr.New("RequestLimitExceeded", "slow down", nil),
+			want:   true,
+		},
+		"ThrottledRequestFailure": {
+			reason: "A failed AWS request with a throttling code should be a throttling error.",
+			err:    awserr.NewRequestFailure(awserr.New("Throttling", "slow down", nil), 400, "cool"),
+			want:   true,
+		},
+		"OtherCode": {
+			reason: "An AWS error with any other code should not be a throttling error.",
+			err:    awserr.New("InvalidParameterValue", "nope", nil),
+			want:   false,
+		},
+		"NotAWSError": {
+			reason: "An error without a code should not be a throttling error.",
+			err:    errors.New("boom"),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsThrottlingError(tc.err); got != tc.want {
+				t.Errorf("\n%s\nIsThrottlingError(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRetryer(t *testing.T) {
+	o := RetryOptions{MaxAttempts: 7, MaxBackoff: 2 * time.Second, MaxThrottleBackoff: 64 * time.Second}
+	r := NewRetryer(o)
+
+	if got := r.MaxAttempts(); got != o.MaxAttempts {
+		t.Errorf("r.MaxAttempts(): want %d, got %d", o.MaxAttempts, got)
+	}
+
+	throttled := awserr.New("RequestLimitExceeded", "slow down", nil)
+	failed := awserr.New("InternalError", "oops", nil)
+
+	if d, _ := r.RetryDelay(10, throttled); d != o.MaxThrottleBackoff {
+		t.Errorf("r.RetryDelay(...): want throttled delay %s, got %s", o.MaxThrottleBackoff, d)
+	}
+	if d, _ := r.RetryDelay(10, failed); d != o.MaxBackoff {
+		t.Errorf("r.RetryDelay(...): want delay %s, got %s", o.MaxBackoff, d)
+	}
+
+	// Throttled retries should never exhaust the retry quota.
+	for i := 0; i < 1000; i++ {
+		if _, err := r.GetRetryToken(context.Background(), throttled); err != nil {
+			t.Fatalf("r.GetRetryToken(...): throttled retry %d: %s", i, err)
+		}
+	}
+
+	// Other retries should eventually exhaust it.
+	var err error
+	for i := 0; i < 1000 && err == nil; i++ {
+		_, err = r.GetRetryToken(context.Background(), failed)
+	}
+	if err == nil {
+		t.Errorf("r.GetRetryToken(...): want error once retry quota is exhausted")
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	type wait struct {
+		service string
+		region  string
+		wantErr bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		l      *RateLimiter
+		waits  []wait
+	}{
+		"Limited": {
+			reason: "Requests should be limited per service and region.",
+			l:      NewRateLimiter(0.1, 1),
+			waits: []wait{
+				{service: "ec2", region: "us-east-1"},
+				{service: "ec2", region: "us-east-1", wantErr: true},
+				{service: "s3", region: "us-east-1"},
+				{service: "ec2", region: "us-west-2"},
+			},
+		},
+		"Unlimited": {
+			reason: "Requests should not be limited if the rate is not positive.",
+			l:      NewRateLimiter(0, 0),
+			waits: []wait{
+				{service: "ec2", region: "us-east-1"},
+				{service: "ec2", region: "us-east-1"},
+				{service: "ec2", region: "us-east-1"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			for i, w := range tc.waits {
+				ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				err := tc.l.Wait(ctx, w.service, w.region)
+				cancel()
+				if (err != nil) != w.wantErr {
+					t.Errorf("\n%s\nl.Wait(...): wait %d: want error %t, got %v", tc.reason, i, w.wantErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	cfg := &aws.Config{}
+	o := RetryOptions{MaxAttempts: 7}
+	Throttle(cfg, NewRateLimiter(1, 1), o)
+	Throttle(cfg, NewRateLimiter(1, 1), o)
+
+	if got := cfg.Handlers.Sign.Len(); got != 1 {
+		t.Errorf("Throttle(...): want 1 Sign handler, got %d", got)
+	}
+	if got := cfg.Retryer.MaxAttempts(); got != o.MaxAttempts {
+		t.Errorf("Throttle(...): want retryer with %d max attempts, got %d", o.MaxAttempts, got)
+	}
+}
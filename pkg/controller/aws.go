@@ -17,76 +17,165 @@ limitations under the License.
 package controller
 
 import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	cachev1alpha1 "github.com/crossplane/crossplane/apis/cache/v1alpha1"
+	computev1alpha1 "github.com/crossplane/crossplane/apis/compute/v1alpha1"
+	databasev1alpha1 "github.com/crossplane/crossplane/apis/database/v1alpha1"
+	storagev1alpha1 "github.com/crossplane/crossplane/apis/storage/v1alpha1"
+	workloadv1alpha1 "github.com/crossplane/crossplane/apis/workload/v1alpha1"
 
+	awscachev1alpha1 "github.com/crossplane/provider-aws/apis/cache/v1alpha1"
+	awscachev1beta1 "github.com/crossplane/provider-aws/apis/cache/v1beta1"
+	awscomputev1alpha3 "github.com/crossplane/provider-aws/apis/compute/v1alpha3"
+	awsdatabasev1alpha1 "github.com/crossplane/provider-aws/apis/database/v1alpha1"
+	awsdatabasev1beta1 "github.com/crossplane/provider-aws/apis/database/v1beta1"
+	awsec2v1alpha4 "github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	awsec2v1beta1 "github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+	awseksv1beta1 "github.com/crossplane/provider-aws/apis/eks/v1beta1"
+	awsidentityv1alpha1 "github.com/crossplane/provider-aws/apis/identity/v1alpha1"
+	awsidentityv1beta1 "github.com/crossplane/provider-aws/apis/identity/v1beta1"
+	awsstoragev1alpha3 "github.com/crossplane/provider-aws/apis/storage/v1alpha3"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	"github.com/crossplane/provider-aws/pkg/controller/cache"
 	"github.com/crossplane/provider-aws/pkg/controller/cache/cachesubnetgroup"
 	"github.com/crossplane/provider-aws/pkg/controller/compute"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 	"github.com/crossplane/provider-aws/pkg/controller/database"
 	"github.com/crossplane/provider-aws/pkg/controller/database/dbsubnetgroup"
 	"github.com/crossplane/provider-aws/pkg/controller/database/dynamodb"
+	"github.com/crossplane/provider-aws/pkg/controller/ec2/elasticip"
 	"github.com/crossplane/provider-aws/pkg/controller/ec2/internetgateway"
+	"github.com/crossplane/provider-aws/pkg/controller/ec2/natgateway"
 	"github.com/crossplane/provider-aws/pkg/controller/ec2/routetable"
 	"github.com/crossplane/provider-aws/pkg/controller/ec2/securitygroup"
+	"github.com/crossplane/provider-aws/pkg/controller/ec2/securitygrouprule"
 	"github.com/crossplane/provider-aws/pkg/controller/ec2/subnet"
 	"github.com/crossplane/provider-aws/pkg/controller/ec2/vpc"
+	"github.com/crossplane/provider-aws/pkg/controller/ec2/vpcpeeringconnection"
 	"github.com/crossplane/provider-aws/pkg/controller/eks"
 	"github.com/crossplane/provider-aws/pkg/controller/identity/iampolicy"
 	"github.com/crossplane/provider-aws/pkg/controller/identity/iamrole"
 	"github.com/crossplane/provider-aws/pkg/controller/identity/iamrolepolicyattachment"
 	"github.com/crossplane/provider-aws/pkg/controller/identity/iamuser"
 	"github.com/crossplane/provider-aws/pkg/controller/identity/iamuserpolicyattachment"
+	"github.com/crossplane/provider-aws/pkg/controller/provider"
 	"github.com/crossplane/provider-aws/pkg/controller/s3"
 )
 
-// Setup creates all AWS controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger) error{
-		cache.SetupReplicationGroupClaimScheduling,
-		cache.SetupReplicationGroupClaimDefaulting,
-		cache.SetupReplicationGroupClaimBinding,
-		cache.SetupReplicationGroup,
-		cachesubnetgroup.SetupCacheSubnetGroup,
-		compute.SetupEKSClusterClaimScheduling,
-		compute.SetupEKSClusterClaimDefaulting,
-		compute.SetupEKSClusterClaimBinding,
-		compute.SetupEKSClusterSecret,
-		compute.SetupEKSClusterTarget,
-		compute.SetupEKSCluster,
-		database.SetupPostgreSQLInstanceClaimScheduling,
-		database.SetupPostgreSQLInstanceClaimDefaulting,
-		database.SetupPostgreSQLInstanceClaimBinding,
-		database.SetupMySQLInstanceClaimScheduling,
-		database.SetupMySQLInstanceClaimDefaulting,
-		database.SetupMySQLInstanceClaimBinding,
-		database.SetupRDSInstance,
-		eks.SetupCluster,
-		eks.SetupClusterSecret,
-		eks.SetupClusterTarget,
-		s3.SetupBucketClaimScheduling,
-		s3.SetupBucketClaimDefaulting,
-		s3.SetupBucketClaimBinding,
-		s3.SetupS3Bucket,
-		iamuser.SetupIAMUser,
-		iampolicy.SetupIAMPolicy,
-		iamrole.SetupIAMRole,
-		iamuserpolicyattachment.SetupIAMUserPolicyAttachment,
-		iamrolepolicyattachment.SetupIAMRolePolicyAttachment,
-		vpc.SetupVPC,
-		subnet.SetupSubnet,
-		securitygroup.SetupSecurityGroup,
-		internetgateway.SetupInternetGateway,
-		routetable.SetupRouteTable,
-		dbsubnetgroup.SetupDBSubnetGroup,
-		dynamodb.SetupDynamoTable,
+const errFmtCheckInstalled = "cannot determine whether the CRDs required by the controllers of %s are installed"
+
+// A kindSetup sets up a controller for an AWS kind.
+type kindSetup struct {
+	// kind is the AWS kind the controller is for. Controllers are enabled
+	// and disabled by kind.
+	kind schema.GroupVersionKind
+
+	// requires the CRDs of these kinds, in addition to the AWS kind, to be
+	// installed in order to set up the controller.
+	requires []schema.GroupVersionKind
+
+	setup func(ctrl.Manager, logging.Logger, config.Options) error
+}
+
+// Setup creates all enabled AWS controllers with the supplied logger and
+// options and adds them to the supplied manager. Controllers that require CRDs
+// that are not installed are skipped.
+func Setup(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
+	var (
+		redisCluster       = []schema.GroupVersionKind{awscachev1beta1.ReplicationGroupClassGroupVersionKind, cachev1alpha1.RedisClusterGroupVersionKind}
+		kubernetesCluster  = []schema.GroupVersionKind{awscomputev1alpha3.EKSClusterClassGroupVersionKind, computev1alpha1.KubernetesClusterGroupVersionKind}
+		postgreSQLInstance = []schema.GroupVersionKind{awsdatabasev1beta1.RDSInstanceClassGroupVersionKind, databasev1alpha1.PostgreSQLInstanceGroupVersionKind}
+		mySQLInstance      = []schema.GroupVersionKind{awsdatabasev1beta1.RDSInstanceClassGroupVersionKind, databasev1alpha1.MySQLInstanceGroupVersionKind}
+		bucket             = []schema.GroupVersionKind{awsstoragev1alpha3.S3BucketClassGroupVersionKind, storagev1alpha1.BucketGroupVersionKind}
+		kubernetesTarget   = []schema.GroupVersionKind{workloadv1alpha1.KubernetesTargetGroupVersionKind}
+		replicationGroup   = awscachev1beta1.ReplicationGroupGroupVersionKind
+		legacyEKSCluster   = awscomputev1alpha3.EKSClusterGroupVersionKind
+		rdsInstance        = awsdatabasev1beta1.RDSInstanceGroupVersionKind
+		eksCluster         = awseksv1beta1.ClusterGroupVersionKind
+		s3Bucket           = awsstoragev1alpha3.S3BucketGroupVersionKind
+	)
+
+	for _, ks := range []kindSetup{
+		{v1alpha3.ProviderGroupVersionKind, nil, provider.SetupProvider},
+		{replicationGroup, redisCluster, cache.SetupReplicationGroupClaimScheduling},
+		{replicationGroup, redisCluster, cache.SetupReplicationGroupClaimDefaulting},
+		{replicationGroup, redisCluster, cache.SetupReplicationGroupClaimBinding},
+		{replicationGroup, nil, cache.SetupReplicationGroup},
+		{awscachev1alpha1.CacheSubnetGroupGroupVersionKind, nil, cachesubnetgroup.SetupCacheSubnetGroup},
+		{legacyEKSCluster, kubernetesCluster, compute.SetupEKSClusterClaimScheduling},
+		{legacyEKSCluster, kubernetesCluster, compute.SetupEKSClusterClaimDefaulting},
+		{legacyEKSCluster, kubernetesCluster, compute.SetupEKSClusterClaimBinding},
+		{legacyEKSCluster, nil, compute.SetupEKSClusterSecret},
+		{legacyEKSCluster, kubernetesTarget, compute.SetupEKSClusterTarget},
+		{legacyEKSCluster, nil, compute.SetupEKSCluster},
+		{rdsInstance, postgreSQLInstance, database.SetupPostgreSQLInstanceClaimScheduling},
+		{rdsInstance, postgreSQLInstance, database.SetupPostgreSQLInstanceClaimDefaulting},
+		{rdsInstance, postgreSQLInstance, database.SetupPostgreSQLInstanceClaimBinding},
+		{rdsInstance, mySQLInstance, database.SetupMySQLInstanceClaimScheduling},
+		{rdsInstance, mySQLInstance, database.SetupMySQLInstanceClaimDefaulting},
+		{rdsInstance, mySQLInstance, database.SetupMySQLInstanceClaimBinding},
+		{rdsInstance, nil, database.SetupRDSInstance},
+		{eksCluster, nil, eks.SetupCluster},
+		{eksCluster, nil, eks.SetupClusterSecret},
+		{eksCluster, kubernetesTarget, eks.SetupClusterTarget},
+		{s3Bucket, bucket, s3.SetupBucketClaimScheduling},
+		{s3Bucket, bucket, s3.SetupBucketClaimDefaulting},
+		{s3Bucket, bucket, s3.SetupBucketClaimBinding},
+		{s3Bucket, nil, s3.SetupS3Bucket},
+		{awsidentityv1alpha1.IAMUserGroupVersionKind, nil, iamuser.SetupIAMUser},
+		{awsidentityv1alpha1.IAMPolicyGroupVersionKind, nil, iampolicy.SetupIAMPolicy},
+		{awsidentityv1beta1.IAMRoleGroupVersionKind, nil, iamrole.SetupIAMRole},
+		{awsidentityv1alpha1.IAMUserPolicyAttachmentGroupVersionKind, nil, iamuserpolicyattachment.SetupIAMUserPolicyAttachment},
+		{awsidentityv1beta1.IAMRolePolicyAttachmentGroupVersionKind, nil, iamrolepolicyattachment.SetupIAMRolePolicyAttachment},
+		{awsec2v1beta1.VPCGroupVersionKind, nil, vpc.SetupVPC},
+		{awsec2v1beta1.SubnetGroupVersionKind, nil, subnet.SetupSubnet},
+		{awsec2v1beta1.SecurityGroupGroupVersionKind, nil, securitygroup.SetupSecurityGroup},
+		{awsec2v1beta1.InternetGatewayGroupVersionKind, nil, internetgateway.SetupInternetGateway},
+		{awsec2v1alpha4.RouteTableGroupVersionKind, nil, routetable.SetupRouteTable},
+		{awsec2v1alpha4.SecurityGroupRuleGroupVersionKind, nil, securitygrouprule.SetupSecurityGroupRule},
+		{awsec2v1alpha4.ElasticIPGroupVersionKind, nil, elasticip.SetupElasticIP},
+		{awsec2v1alpha4.NATGatewayGroupVersionKind, nil, natgateway.SetupNATGateway},
+		{awsec2v1alpha4.VPCPeeringConnectionGroupVersionKind, nil, vpcpeeringconnection.SetupVPCPeeringConnection},
+		{awsdatabasev1beta1.DBSubnetGroupGroupVersionKind, nil, dbsubnetgroup.SetupDBSubnetGroup},
+		{awsdatabasev1alpha1.DynamoTableGroupVersionKind, nil, dynamodb.SetupDynamoTable},
 	} {
-		if err := setup(mgr, l); err != nil {
+		log := l.WithValues("kind", ks.kind.GroupKind().String())
+		if !o.IsEnabled(ks.kind.GroupKind()) {
+			log.Debug("Skipping controller for disabled kind")
+			continue
+		}
+		ok, err := installed(mgr.GetRESTMapper(), append([]schema.GroupVersionKind{ks.kind}, ks.requires...)...)
+		if err != nil {
+			return errors.Wrapf(err, errFmtCheckInstalled, ks.kind.GroupKind())
+		}
+		if !ok {
+			log.Info("Skipping controller that requires CRDs that are not installed")
+			continue
+		}
+		if err := ks.setup(mgr, l, o); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// installed returns true if the CRDs of all of the supplied kinds are
+// installed.
+func installed(m meta.RESTMapper, kinds ...schema.GroupVersionKind) (bool, error) {
+	for _, gvk := range kinds {
+		_, err := m.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
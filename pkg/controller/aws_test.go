@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+type errMapper struct {
+	meta.RESTMapper
+	err error
+}
+
+func (m errMapper) RESTMapping(_ schema.GroupKind, _ ...string) (*meta.RESTMapping, error) {
+	return nil, m.err
+}
+
+func TestInstalled(t *testing.T) {
+	errBoom := errors.New("boom")
+	vpc := schema.GroupVersionKind{Group: "ec2.aws.crossplane.io", Version: "v1beta1", Kind: "VPC"}
+	subnet := schema.GroupVersionKind{Group: "ec2.aws.crossplane.io", Version: "v1beta1", Kind: "Subnet"}
+
+	installedMapper := meta.NewDefaultRESTMapper(nil)
+	installedMapper.Add(vpc, meta.RESTScopeRoot)
+
+	type want struct {
+		ok  bool
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		m      meta.RESTMapper
+		kinds  []schema.GroupVersionKind
+		want   want
+	}{
+		"AllInstalled": {
+			reason: "We should return true if all kinds are installed.",
+			m:      installedMapper,
+			kinds:  []schema.GroupVersionKind{vpc},
+			want:   want{ok: true},
+		},
+		"NotInstalled": {
+			reason: "We should return false if any kind is not installed.",
+			m:      installedMapper,
+			kinds:  []schema.GroupVersionKind{vpc, subnet},
+			want:   want{ok: false},
+		},
+		"MappingError": {
+			reason: "Errors other than a kind not being installed should be returned.",
+			m:      errMapper{err: errBoom},
+			kinds:  []schema.GroupVersionKind{vpc},
+			want:   want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ok, err := installed(tc.m, tc.kinds...)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ninstalled(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if ok != tc.want.ok {
+				t.Errorf("\n%s\ninstalled(...): want %t, got %t", tc.reason, tc.want.ok, ok)
+			}
+		})
+	}
+}
@@ -22,10 +22,7 @@ import (
 	commonaws "github.com/aws/aws-sdk-go-v2/aws"
 	awscache "github.com/aws/aws-sdk-go-v2/service/elasticache"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -35,10 +32,10 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/cache/v1alpha1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/elasticache"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 // Error strings.
@@ -48,22 +45,21 @@ const (
 	errCreateSubnetGroup   = "cannot create Subnet Group"
 	errModifySubnetGroup   = "cannot modify Subnet Group"
 	errDeleteSubnetGroup   = "cannot delete Subnet Group"
-
-	errNewClient         = "cannot create new ElastiCache client"
-	errGetProvider       = "cannot get provider"
-	errGetProviderSecret = "cannot get provider secret"
 )
 
 // SetupCacheSubnetGroup adds a controller that reconciles SubnetGroups.
-func SetupCacheSubnetGroup(mgr ctrl.Manager, l logging.Logger) error {
+func SetupCacheSubnetGroup(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1alpha1.CacheSubnetGroupGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.CacheSubnetGroup{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.CacheSubnetGroup{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.CacheSubnetGroupGroupVersionKind),
-			managed.WithExternalConnecter(&connector{client: mgr.GetClient(), newClientFn: elasticache.NewClient}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *commonaws.Config) (managed.ExternalClient, error) {
+				return &external{client: elasticache.NewClient(cfg)}, nil
+			})),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient())),
 			managed.WithLogger(l.WithValues("controller", name)),
@@ -71,40 +67,6 @@ func SetupCacheSubnetGroup(mgr ctrl.Manager, l logging.Logger) error {
 		))
 }
 
-type connector struct {
-	client      client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (elasticache.Client, error)
-}
-
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	g, ok := mg.(*v1alpha1.CacheSubnetGroup)
-	if !ok {
-		return nil, errors.New(errNotSubnetGroup)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := c.client.Get(ctx, meta.NamespacedNameOf(g.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if commonaws.BoolValue(p.Spec.UseServiceAccount) {
-		awsClient, err := c.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{client: awsClient}, errors.Wrap(err, errNewClient)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := c.client.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-	awsClient, err := c.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{client: awsClient}, errors.Wrap(err, errNewClient)
-}
-
 type external struct {
 	client elasticache.Client
 }
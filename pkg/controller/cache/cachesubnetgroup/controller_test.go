@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-   http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -25,29 +25,18 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-aws/apis/cache/v1alpha1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
-	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/elasticache"
 	"github.com/crossplane/provider-aws/pkg/clients/elasticache/fake"
 )
 
 const (
-	providerName    = "aws-creds"
-	secretNamespace = "crossplane-system"
-	testRegion      = "us-east-1"
-
-	connectionSecretName = "my-little-secret"
-	secretKey            = "credentials"
-	credData             = "confidential!"
+	providerName = "aws-creds"
 )
 
 var (
@@ -88,171 +77,6 @@ func csg(m ...csgModifier) *v1alpha1.CacheSubnetGroup {
 }
 
 var _ managed.ExternalClient = &external{}
-var _ managed.ExternalConnecter = &connector{}
-
-func TestConnect(t *testing.T) {
-	secret := corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      connectionSecretName,
-			Namespace: secretNamespace,
-		},
-		Data: map[string][]byte{
-			secretKey: []byte(credData),
-		},
-	}
-
-	providerSA := func(saVal bool) awsv1alpha3.Provider {
-		return awsv1alpha3.Provider{
-			Spec: awsv1alpha3.ProviderSpec{
-				Region:            testRegion,
-				UseServiceAccount: &saVal,
-				ProviderSpec: runtimev1alpha1.ProviderSpec{
-					CredentialsSecretRef: &runtimev1alpha1.SecretKeySelector{
-						SecretReference: runtimev1alpha1.SecretReference{
-							Namespace: secretNamespace,
-							Name:      connectionSecretName,
-						},
-						Key: secretKey,
-					},
-				},
-			},
-		}
-	}
-	type args struct {
-		kube        client.Client
-		newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (elasticache.Client, error)
-		cr          *v1alpha1.CacheSubnetGroup
-	}
-	type want struct {
-		err error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"Successful": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							secret.DeepCopyInto(obj.(*corev1.Secret))
-							return nil
-						}
-						return errBoom
-					},
-				},
-				newClientFn: func(_ context.Context, credentials []byte, region string, _ awsclients.AuthMethod) (i elasticache.Client, e error) {
-					if diff := cmp.Diff(credData, string(credentials)); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					if diff := cmp.Diff(testRegion, region); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return nil, nil
-				},
-				cr: csg(),
-			},
-		},
-		"SuccessfulUseServiceAccount": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						if key == (client.ObjectKey{Name: providerName}) {
-							p := providerSA(true)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						}
-						return errBoom
-					},
-				},
-				newClientFn: func(_ context.Context, credentials []byte, region string, _ awsclients.AuthMethod) (i elasticache.Client, e error) {
-					if diff := cmp.Diff("", string(credentials)); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					if diff := cmp.Diff(testRegion, region); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return nil, nil
-				},
-				cr: csg(),
-			},
-		},
-		"ProviderGetFailed": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						return errBoom
-					},
-				},
-				cr: csg(),
-			},
-			want: want{
-				err: errors.Wrap(errBoom, errGetProvider),
-			},
-		},
-		"SecretGetFailed": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							return errBoom
-						default:
-							return nil
-						}
-					},
-				},
-				cr: csg(),
-			},
-			want: want{
-				err: errors.Wrap(errBoom, errGetProviderSecret),
-			},
-		},
-		"SecretGetFailedNil": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.SetCredentialsSecretReference(nil)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							return errBoom
-						default:
-							return nil
-						}
-					},
-				},
-				cr: csg(),
-			},
-			want: want{
-				err: errors.New(errGetProviderSecret),
-			},
-		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			c := &connector{client: tc.kube, newClientFn: tc.newClientFn}
-			_, err := c.Connect(context.Background(), tc.args.cr)
-			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
 
 func TestObserve(t *testing.T) {
 	type want struct {
@@ -35,18 +35,20 @@ import (
 
 	"github.com/crossplane/provider-aws/apis/cache/v1beta1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 // SetupReplicationGroupClaimScheduling adds a controller that reconciles
 // RedisCluster claims that include a class selector but omit their class and
 // resource references by picking a random matching ReplicationGroupClass, if
 // any.
-func SetupReplicationGroupClaimScheduling(mgr ctrl.Manager, l logging.Logger) error {
+func SetupReplicationGroupClaimScheduling(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimscheduling.ControllerName(cachev1alpha1.RedisClusterGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&cachev1alpha1.RedisCluster{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&cachev1alpha1.RedisCluster{}, o.Selected()).
 		WithEventFilter(resource.NewPredicates(resource.AllOf(
 			resource.HasClassSelector(),
 			resource.HasNoClassReference(),
@@ -63,12 +65,13 @@ func SetupReplicationGroupClaimScheduling(mgr ctrl.Manager, l logging.Logger) er
 // SetupReplicationGroupClaimDefaulting adds a controller that reconciles
 // RedisCluster claims that omit their resource ref, class ref, and class
 // selector by choosing a default ReplicationGroupClass if one exists.
-func SetupReplicationGroupClaimDefaulting(mgr ctrl.Manager, l logging.Logger) error {
+func SetupReplicationGroupClaimDefaulting(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimdefaulting.ControllerName(cachev1alpha1.RedisClusterGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&cachev1alpha1.RedisCluster{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&cachev1alpha1.RedisCluster{}, o.Selected()).
 		WithEventFilter(resource.NewPredicates(resource.AllOf(
 			resource.HasNoClassSelector(),
 			resource.HasNoClassReference(),
@@ -85,7 +88,7 @@ func SetupReplicationGroupClaimDefaulting(mgr ctrl.Manager, l logging.Logger) er
 // SetupReplicationGroupClaimBinding adds a controller that reconciles
 // RedisCluster claims with ReplicationGroups, dynamically provisioning them if
 // needed.
-func SetupReplicationGroupClaimBinding(mgr ctrl.Manager, l logging.Logger) error {
+func SetupReplicationGroupClaimBinding(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimbinding.ControllerName(cachev1alpha1.RedisClusterGroupKind)
 
 	r := claimbinding.NewReconciler(mgr,
@@ -108,8 +111,9 @@ func SetupReplicationGroupClaimBinding(mgr ctrl.Manager, l logging.Logger) error
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		Watches(&source.Kind{Type: &v1beta1.ReplicationGroup{}}, &resource.EnqueueRequestForClaim{}).
-		For(&cachev1alpha1.RedisCluster{}).
+		For(&cachev1alpha1.RedisCluster{}, o.Selected()).
 		WithEventFilter(p).
 		Complete(r)
 }
@@ -19,13 +19,10 @@ package cache
 import (
 	"context"
 	"reflect"
-	"sort"
 
 	commonaws "github.com/aws/aws-sdk-go-v2/aws"
 	elasticacheservice "github.com/aws/aws-sdk-go-v2/service/elasticache"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -38,19 +35,16 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/cache/v1beta1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/elasticache"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 // Error strings.
 const (
 	errUpdateReplicationGroupCR = "cannot update ReplicationGroup Custom Resource"
 	errGetCacheClusterList      = "cannot get cache cluster list"
-	errGetProvider              = "cannot get provider"
-	errGetProviderSecret        = "cannot get provider secret"
 
-	errNewClient                = "cannot create new ElastiCache client"
 	errNotReplicationGroup      = "managed resource is not an ElastiCache replication group"
 	errDescribeReplicationGroup = "cannot describe ElastiCache replication group"
 	errGenerateAuthToken        = "cannot generate ElastiCache auth token"
@@ -60,56 +54,25 @@ const (
 )
 
 // SetupReplicationGroup adds a controller that reconciles ReplicationGroups.
-func SetupReplicationGroup(mgr ctrl.Manager, l logging.Logger) error {
+func SetupReplicationGroup(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1beta1.ReplicationGroupGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1beta1.ReplicationGroup{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.ReplicationGroup{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.ReplicationGroupGroupVersionKind),
-			managed.WithExternalConnecter(&connecter{client: mgr.GetClient(), newClientFn: elasticache.NewClient}),
-			managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), &tagger{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *commonaws.Config) (managed.ExternalClient, error) {
+				return &external{client: elasticache.NewClient(cfg), kube: mgr.GetClient()}, nil
+			})),
+			managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		))
 }
 
-type connecter struct {
-	client      client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (elasticache.Client, error)
-}
-
-func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	g, ok := mg.(*v1beta1.ReplicationGroup)
-	if !ok {
-		return nil, errors.New(errNotReplicationGroup)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := c.client.Get(ctx, meta.NamespacedNameOf(g.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if commonaws.BoolValue(p.Spec.UseServiceAccount) {
-		awsClient, err := c.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{client: awsClient, kube: c.client}, errors.Wrap(err, errNewClient)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := c.client.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-	awsClient, err := c.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{client: awsClient, kube: c.client}, errors.Wrap(err, errNewClient)
-}
-
 type external struct {
 	client elasticache.Client
 	kube   client.Client
@@ -225,32 +188,12 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	return errors.Wrap(resource.Ignore(elasticache.IsNotFound, err), errDeleteReplicationGroup)
 }
 
-type tagger struct {
-	kube client.Client
-}
-
-func (t *tagger) Initialize(ctx context.Context, mg resource.Managed) error {
+func specTags(mg resource.Managed) (interface{}, error) {
 	cr, ok := mg.(*v1beta1.ReplicationGroup)
 	if !ok {
-		return errors.New(errNotReplicationGroup)
-	}
-	tagMap := map[string]string{}
-	for _, t := range cr.Spec.ForProvider.Tags {
-		tagMap[t.Key] = t.Value
-	}
-	for k, v := range resource.GetExternalTags(mg) {
-		tagMap[k] = v
-	}
-	cr.Spec.ForProvider.Tags = make([]v1beta1.Tag, len(tagMap))
-	i := 0
-	for k, v := range tagMap {
-		cr.Spec.ForProvider.Tags[i] = v1beta1.Tag{Key: k, Value: v}
-		i++
+		return nil, errors.New(errNotReplicationGroup)
 	}
-	sort.Slice(cr.Spec.ForProvider.Tags, func(i, j int) bool {
-		return cr.Spec.ForProvider.Tags[i].Key < cr.Spec.ForProvider.Tags[j].Key
-	})
-	return errors.Wrap(t.kube.Update(ctx, cr), errUpdateReplicationGroupCR)
+	return &cr.Spec.ForProvider.Tags, nil
 }
 
 func getCacheClusterList(ctx context.Context, client elasticache.Client, idList []string) ([]elasticacheservice.CacheCluster, error) {
@@ -29,10 +29,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
-	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -44,7 +41,6 @@ import (
 	"github.com/crossplane/provider-aws/apis/cache/v1beta1"
 	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
-	elasticacheclient "github.com/crossplane/provider-aws/pkg/clients/elasticache"
 	"github.com/crossplane/provider-aws/pkg/clients/elasticache/fake"
 )
 
@@ -55,7 +51,6 @@ const (
 	providerName       = "cool-aws"
 	providerSecretName = "cool-aws-secret"
 	providerSecretKey  = "credentials"
-	providerSecretData = "definitelyini"
 
 	connectionSecretName = "cool-connection-secret"
 )
@@ -93,11 +88,6 @@ var (
 			},
 		},
 	}
-
-	providerSecret = corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: providerSecretName},
-		Data:       map[string][]byte{providerSecretKey: []byte(providerSecretData)},
-	}
 )
 
 type testCase struct {
@@ -194,7 +184,6 @@ func replicationGroup(rm ...replicationGroupModifier) *v1beta1.ReplicationGroup
 
 // Test that our Reconciler implementation satisfies the Reconciler interface.
 var _ managed.ExternalClient = &external{}
-var _ managed.ExternalConnecter = &connecter{}
 
 func TestCreate(t *testing.T) {
 	cases := []testCase{
@@ -672,144 +661,6 @@ func TestDelete(t *testing.T) {
 	}
 }
 
-func TestConnect(t *testing.T) {
-	providerSA := func(saVal bool) awsv1alpha3.Provider {
-		return awsv1alpha3.Provider{
-			Spec: awsv1alpha3.ProviderSpec{
-				UseServiceAccount: &saVal,
-				ProviderSpec:      runtimev1alpha1.ProviderSpec{},
-			},
-		}
-	}
-
-	cases := []struct {
-		name    string
-		conn    *connecter
-		i       *v1beta1.ReplicationGroup
-		wantErr error
-	}{
-		{
-			name: "SuccessfulConnect",
-			conn: &connecter{
-				client: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							*obj.(*awsv1alpha3.Provider) = provider
-						case client.ObjectKey{Namespace: namespace, Name: providerSecretName}:
-							*obj.(*corev1.Secret) = providerSecret
-						}
-						return nil
-					},
-				},
-				newClientFn: func(_ context.Context, _ []byte, _ string, _ awsclients.AuthMethod) (elasticacheclient.Client, error) {
-					return &fake.MockClient{}, nil
-				},
-			},
-			i: replicationGroup(),
-		},
-		{
-			name: "SuccessfulConnectWithServiceAccount",
-			conn: &connecter{
-				client: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							*obj.(*awsv1alpha3.Provider) = providerSA(true)
-						case client.ObjectKey{Namespace: namespace, Name: providerSecretName}:
-							*obj.(*corev1.Secret) = providerSecret
-						}
-						return nil
-					},
-				},
-				newClientFn: func(_ context.Context, _ []byte, _ string, _ awsclients.AuthMethod) (elasticacheclient.Client, error) {
-					return &fake.MockClient{}, nil
-				},
-			},
-			i: replicationGroup(),
-		},
-		{
-			name: "FailedToGetProvider",
-			conn: &connecter{
-				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-					return kerrors.NewNotFound(schema.GroupResource{}, providerName)
-				}},
-				newClientFn: func(_ context.Context, _ []byte, _ string, _ awsclients.AuthMethod) (elasticacheclient.Client, error) {
-					return &fake.MockClient{}, nil
-				},
-			},
-			i:       replicationGroup(),
-			wantErr: errors.WithStack(errors.Errorf("cannot get provider:  \"%s\" not found", providerName)),
-		},
-		{
-			name: "FailedToGetProviderSecret",
-			conn: &connecter{
-				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-					switch key {
-					case client.ObjectKey{Name: providerName}:
-						*obj.(*awsv1alpha3.Provider) = provider
-					case client.ObjectKey{Namespace: namespace, Name: providerSecretName}:
-						return kerrors.NewNotFound(schema.GroupResource{}, providerSecretName)
-					}
-					return nil
-				}},
-				newClientFn: func(_ context.Context, _ []byte, _ string, _ awsclients.AuthMethod) (elasticacheclient.Client, error) {
-					return &fake.MockClient{}, nil
-				},
-			},
-			i:       replicationGroup(),
-			wantErr: errors.WithStack(errors.Errorf("cannot get provider secret:  \"%s\" not found", providerSecretName)),
-		},
-		{
-			name: "FailedToGetProviderSecretNil",
-			conn: &connecter{
-				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-					switch key {
-					case client.ObjectKey{Name: providerName}:
-						*obj.(*awsv1alpha3.Provider) = providerSA(false)
-					case client.ObjectKey{Namespace: namespace, Name: providerSecretName}:
-						return kerrors.NewNotFound(schema.GroupResource{}, providerSecretName)
-					}
-					return nil
-				}},
-				newClientFn: func(_ context.Context, _ []byte, _ string, _ awsclients.AuthMethod) (elasticacheclient.Client, error) {
-					return &fake.MockClient{}, nil
-				},
-			},
-			i:       replicationGroup(),
-			wantErr: errors.New("cannot get provider secret"),
-		},
-		{
-			name: "FailedToCreateElastiCacheClient",
-			conn: &connecter{
-				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-					switch key {
-					case client.ObjectKey{Name: providerName}:
-						*obj.(*awsv1alpha3.Provider) = provider
-					case client.ObjectKey{Namespace: namespace, Name: providerSecretName}:
-						*obj.(*corev1.Secret) = providerSecret
-					}
-					return nil
-				}},
-				newClientFn: func(_ context.Context, _ []byte, _ string, _ awsclients.AuthMethod) (elasticacheclient.Client, error) {
-					return nil, errorBoom
-				},
-			},
-			i:       replicationGroup(),
-			wantErr: errors.Wrap(errorBoom, errNewClient),
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			_, gotErr := tc.conn.Connect(ctx, tc.i)
-			if diff := cmp.Diff(tc.wantErr, gotErr, test.EquateErrors()); diff != "" {
-				t.Errorf("tc.conn.Connect(...): want error != got error:\n%s", diff)
-			}
-		})
-	}
-}
-
 func TestInitialize(t *testing.T) {
 	type args struct {
 		cr   *v1beta1.ReplicationGroup
@@ -826,27 +677,21 @@ func TestInitialize(t *testing.T) {
 	}{
 		"Successful": {
 			args: args{
-				cr:   replicationGroup(withTags(map[string]string{"foo": "bar"})),
-				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				cr: replicationGroup(withTags(map[string]string{"foo": "bar"})),
+				kube: &test.MockClient{
+					MockGet:    test.NewMockGetFn(nil),
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
 			},
 			want: want{
 				cr: replicationGroup(withTags(resource.GetExternalTags(replicationGroup()), map[string]string{"foo": "bar"})),
 			},
 		},
-		"UpdateFailed": {
-			args: args{
-				cr:   replicationGroup(),
-				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errorBoom)},
-			},
-			want: want{
-				err: errors.Wrap(errorBoom, errUpdateReplicationGroupCR),
-			},
-		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &tagger{kube: tc.kube}
+			e := awsclients.NewTagger(tc.kube, specTags)
 			err := e.Initialize(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
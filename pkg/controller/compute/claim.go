@@ -33,17 +33,19 @@ import (
 	computev1alpha1 "github.com/crossplane/crossplane/apis/compute/v1alpha1"
 
 	"github.com/crossplane/provider-aws/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 // SetupEKSClusterClaimScheduling adds a controller that reconciles
 // KubernetesCluster claims that include a class selector but omit their class
 // and resource references by picking a random matching EKSClusterClass, if any.
-func SetupEKSClusterClaimScheduling(mgr ctrl.Manager, l logging.Logger) error {
+func SetupEKSClusterClaimScheduling(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimscheduling.ControllerName(computev1alpha1.KubernetesClusterGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&computev1alpha1.KubernetesCluster{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&computev1alpha1.KubernetesCluster{}, o.Selected()).
 		WithEventFilter(resource.NewPredicates(resource.AllOf(
 			resource.HasClassSelector(),
 			resource.HasNoClassReference(),
@@ -60,12 +62,13 @@ func SetupEKSClusterClaimScheduling(mgr ctrl.Manager, l logging.Logger) error {
 // SetupEKSClusterClaimDefaulting adds a controller that reconciles
 // KubernetesCluster claims that omit their resource ref, class ref, and class
 // selector by choosing a default EKSClusterClass if one exists.
-func SetupEKSClusterClaimDefaulting(mgr ctrl.Manager, l logging.Logger) error {
+func SetupEKSClusterClaimDefaulting(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimdefaulting.ControllerName(computev1alpha1.KubernetesClusterGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&computev1alpha1.KubernetesCluster{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&computev1alpha1.KubernetesCluster{}, o.Selected()).
 		WithEventFilter(resource.NewPredicates(resource.AllOf(
 			resource.HasNoClassSelector(),
 			resource.HasNoClassReference(),
@@ -82,7 +85,7 @@ func SetupEKSClusterClaimDefaulting(mgr ctrl.Manager, l logging.Logger) error {
 // SetupEKSClusterClaimBinding adds a controller that reconciles
 // KubernetesCluster claims with EKSClusters, dynamically provisioning them if
 // needed.
-func SetupEKSClusterClaimBinding(mgr ctrl.Manager, l logging.Logger) error {
+func SetupEKSClusterClaimBinding(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimbinding.ControllerName(computev1alpha1.KubernetesClusterGroupKind)
 
 	r := claimbinding.NewReconciler(mgr,
@@ -106,8 +109,9 @@ func SetupEKSClusterClaimBinding(mgr ctrl.Manager, l logging.Logger) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		Watches(&source.Kind{Type: &v1alpha3.EKSCluster{}}, &resource.EnqueueRequestForClaim{}).
-		For(&computev1alpha1.KubernetesCluster{}).
+		For(&computev1alpha1.KubernetesCluster{}, o.Selected()).
 		WithEventFilter(p).
 		Complete(r)
 }
@@ -43,9 +43,10 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	awscomputev1alpha3 "github.com/crossplane/provider-aws/apis/compute/v1alpha3"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	cloudformationclient "github.com/crossplane/provider-aws/pkg/clients/cloudformation"
 	eks "github.com/crossplane/provider-aws/pkg/clients/legacyeks"
-	"github.com/crossplane/provider-aws/pkg/controller/utils"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
@@ -70,6 +71,7 @@ const (
 // Error strings
 const (
 	errUpdateCustomResource = "cannot update ekscluster custom resource"
+	errObserveOnlyNotFound  = "EKS cluster does not exist, and will not be created because the EKSCluster is observe-only"
 )
 
 // CloudFormation States that are non-transitory
@@ -99,6 +101,7 @@ type Reconciler struct {
 	create  func(*awscomputev1alpha3.EKSCluster, eks.Client) (reconcile.Result, error)
 	sync    func(*awscomputev1alpha3.EKSCluster, *eks.Cluster, eks.Client) (reconcile.Result, error)
 	delete  func(*awscomputev1alpha3.EKSCluster, eks.Client) (reconcile.Result, error)
+	observe func(*awscomputev1alpha3.EKSCluster, eks.Client) (reconcile.Result, error)
 	secret  func(*eks.Cluster, *awscomputev1alpha3.EKSCluster, eks.Client) error
 	awsauth func(*eks.Cluster, *awscomputev1alpha3.EKSCluster, eks.Client, string) error
 
@@ -106,7 +109,7 @@ type Reconciler struct {
 }
 
 // SetupEKSCluster adds a controller that reconciles EKSClusters.
-func SetupEKSCluster(mgr ctrl.Manager, l logging.Logger) error {
+func SetupEKSCluster(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(awscomputev1alpha3.EKSClusterGroupKind)
 
 	r := &Reconciler{
@@ -120,12 +123,14 @@ func SetupEKSCluster(mgr ctrl.Manager, l logging.Logger) error {
 	r.create = r._create
 	r.sync = r._sync
 	r.delete = r._delete
+	r.observe = r._observe
 	r.secret = r._secret
 	r.awsauth = r._awsauth
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&awscomputev1alpha3.EKSCluster{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&awscomputev1alpha3.EKSCluster{}, o.Selected()).
 		Complete(r)
 }
 
@@ -140,7 +145,7 @@ func (r *Reconciler) fail(instance *awscomputev1alpha3.EKSCluster, err error) (r
 }
 
 func (r *Reconciler) _connect(instance *awscomputev1alpha3.EKSCluster) (eks.Client, error) {
-	config, err := utils.RetrieveAwsConfigFromProvider(ctx, r, instance.Spec.ProviderReference)
+	config, err := awsclients.GetConfig(ctx, r, instance.Spec.ProviderReference)
 	if err != nil {
 		return nil, err
 	}
@@ -321,6 +326,43 @@ func (r *Reconciler) _sync(instance *awscomputev1alpha3.EKSCluster, cluster *eks
 	return reconcile.Result{RequeueAfter: aLongWait}, r.Update(ctx, instance)
 }
 
+// _observe observes a cluster without ever changing it. Its worker nodes are
+// neither created nor observed, and the cluster is orphaned when the
+// EKSCluster is deleted.
+func (r *Reconciler) _observe(instance *awscomputev1alpha3.EKSCluster, client eks.Client) (reconcile.Result, error) {
+	if instance.DeletionTimestamp != nil {
+		meta.RemoveFinalizer(instance, finalizer)
+		return reconcile.Result{}, errors.Wrap(r.Update(ctx, instance), errUpdateCustomResource)
+	}
+
+	cluster, err := client.Get(meta.GetExternalName(instance))
+	switch {
+	case eks.IsErrorNotFound(err):
+		return r.fail(instance, errors.New(errObserveOnlyNotFound))
+	case err != nil:
+		return r.fail(instance, err)
+	}
+
+	instance.Status.Endpoint = cluster.Endpoint
+	instance.Status.State = cluster.Status
+	instance.Status.ClusterVersion = cluster.Version
+
+	drift := awsclients.NotDrifted()
+	if instance.Spec.ClusterVersion != "" && instance.Spec.ClusterVersion != cluster.Version {
+		drift = awsclients.Drifted()
+	}
+	instance.Status.SetConditions(drift, runtimev1alpha1.ReconcileSuccess())
+
+	if cluster.Status != awscomputev1alpha3.ClusterStatusActive {
+		instance.Status.SetConditions(runtimev1alpha1.Unavailable())
+		return reconcile.Result{RequeueAfter: aShortWait}, errors.Wrap(r.Update(ctx, instance), errUpdateCustomResource)
+	}
+
+	instance.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(instance)
+	return reconcile.Result{RequeueAfter: aLongWait}, errors.Wrap(r.Update(ctx, instance), errUpdateCustomResource)
+}
+
 func (r *Reconciler) _secret(cluster *eks.Cluster, instance *awscomputev1alpha3.EKSCluster, client eks.Client) error {
 	token, err := client.ConnectionToken(meta.GetExternalName(instance))
 	if err != nil {
@@ -384,6 +426,11 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		// be requeued because we return an error.
 		return reconcile.Result{}, resource.IgnoreNotFound(err)
 	}
+	if awsclients.IsPaused(instance) {
+		instance.Status.SetConditions(awsclients.Paused())
+		return reconcile.Result{}, r.Update(ctx, instance)
+	}
+	awsclients.Resume(instance)
 	if err := r.initializer.Initialize(ctx, instance); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -399,6 +446,12 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return reconcile.Result{RequeueAfter: aLongWait}, errors.Wrap(r.Update(ctx, instance), errUpdateCustomResource)
 	}
 
+	// Clusters in dry-run mode are observed without being changed, since the
+	// EKS client does not support planning its requests.
+	if awsclients.IsObserveOnly(instance) || awsclients.IsDryRun(instance) {
+		return r.observe(instance, eksClient)
+	}
+
 	// Add finalizer
 	meta.AddFinalizer(instance, finalizer)
 
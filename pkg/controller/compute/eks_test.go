@@ -37,6 +37,7 @@ import (
 
 	"github.com/crossplane/provider-aws/apis"
 	. "github.com/crossplane/provider-aws/apis/compute/v1alpha3"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	eksclients "github.com/crossplane/provider-aws/pkg/clients/legacyeks"
 	"github.com/crossplane/provider-aws/pkg/clients/legacyeks/fake"
 
@@ -606,3 +607,63 @@ func TestReconcileSync(t *testing.T) {
 	g.Expect(rc.Finalizers).To(HaveLen(1))
 	g.Expect(rc.Finalizers).To(ContainElement(finalizer))
 }
+
+func TestReconcileObserveOnly(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := testCluster()
+	tc.SetAnnotations(map[string]string{awsclients.AnnotationKeyManagementPolicy: awsclients.ManagementPolicyObserveOnly})
+	tc.Spec.ClusterVersion = "1.16"
+	kube := NewFakeClient(tc)
+	r := &Reconciler{
+		Client: kube,
+		connect: func(*EKSCluster) (eksclients.Client, error) {
+			return &fake.MockEKSClient{MockGet: func(_ string) (*eksclients.Cluster, error) {
+				return &eksclients.Cluster{Status: ClusterStatusActive, Version: "1.15"}, nil
+			}}, nil
+		},
+		sync: func(*EKSCluster, *eksclients.Cluster, eksclients.Client) (reconcile.Result, error) {
+			t.Errorf("r.Reconcile(...): observe-only clusters should not be synced")
+			return reconcile.Result{}, nil
+		},
+		ReferenceResolver: managed.NewAPISimpleReferenceResolver(kube),
+		log:               logging.NewNopLogger(),
+		initializer:       managed.NewNameAsExternalName(kube),
+	}
+	r.observe = r._observe
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(awsclients.Drifted(), runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
+
+	rs, err := r.Reconcile(request)
+	g.Expect(rs).To(Equal(reconcile.Result{RequeueAfter: aLongWait}))
+	g.Expect(err).To(BeNil())
+
+	rc := assertResource(g, r, expectedStatus)
+	g.Expect(rc.Finalizers).To(BeEmpty())
+	g.Expect(rc.Status.ClusterVersion).To(Equal("1.15"))
+}
+
+func TestReconcilePaused(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := testCluster()
+	tc.SetAnnotations(map[string]string{awsclients.AnnotationKeyPaused: "true"})
+	r := &Reconciler{
+		Client: NewFakeClient(tc),
+		connect: func(*EKSCluster) (eksclients.Client, error) {
+			t.Errorf("r.Reconcile(...): paused clusters should not be connected to")
+			return nil, nil
+		},
+		log: logging.NewNopLogger(),
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(awsclients.Paused())
+
+	rs, err := r.Reconcile(request)
+	g.Expect(rs).To(Equal(reconcile.Result{}))
+	g.Expect(err).To(BeNil())
+
+	assertResource(g, r, expectedStatus)
+}
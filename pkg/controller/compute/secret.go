@@ -29,16 +29,20 @@ import (
 	workloadv1alpha1 "github.com/crossplane/crossplane/apis/workload/v1alpha1"
 
 	"github.com/crossplane/provider-aws/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 // SetupEKSClusterSecret adds a controller that propagates EKSCluster connection
 // secrets to the connection secrets of their resource claims.
-func SetupEKSClusterSecret(mgr ctrl.Manager, l logging.Logger) error {
+func SetupEKSClusterSecret(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := secret.ControllerName(v1alpha3.EKSClusterGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		Watches(&source.Kind{Type: &corev1.Secret{}}, &resource.EnqueueRequestForPropagated{}).
+		// Connection secrets are not selected by label because they are
+		// created by Crossplane rather than by users.
 		For(&corev1.Secret{}).
 		WithEventFilter(resource.NewPredicates(resource.AnyOf(
 			resource.AllOf(resource.IsControlledByKind(v1alpha1.KubernetesClusterGroupVersionKind), resource.IsPropagated()),
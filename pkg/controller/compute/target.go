@@ -29,17 +29,19 @@ import (
 	"github.com/crossplane/crossplane/apis/workload/v1alpha1"
 
 	"github.com/crossplane/provider-aws/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 // SetupEKSClusterTarget adds a controller that propagates EKSCluster connection
 // secrets to the connection secrets of their targets.
-func SetupEKSClusterTarget(mgr ctrl.Manager, l logging.Logger) error {
+func SetupEKSClusterTarget(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := target.ControllerName(v1alpha3.EKSClusterGroupKind)
 	p := resource.NewPredicates(resource.HasManagedResourceReferenceKind(resource.ManagedKind(v1alpha3.EKSClusterGroupVersionKind)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(strings.ToLower(fmt.Sprintf("kubernetestarget.%s.%s", v1alpha3.EKSClusterKind, v1alpha3.Group))).
-		For(&v1alpha1.KubernetesTarget{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.KubernetesTarget{}, o.Selected()).
 		WithEventFilter(p).
 		Complete(target.NewReconciler(mgr,
 			resource.TargetKind(v1alpha1.KubernetesTargetGroupVersionKind),
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config contains options that configure AWS controllers.
+package config
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Options configure how AWS controllers are set up.
+type Options struct {
+	// MaxConcurrentReconciles is the maximum number of reconciles each
+	// controller may run concurrently. Defaults to 1.
+	MaxConcurrentReconciles int
+
+	// LabelSelector restricts controllers to reconciling the resources that
+	// it selects. All resources are reconciled if it is nil.
+	LabelSelector labels.Selector
+
+	// EnabledKinds are the API groups, such as ec2.aws.crossplane.io, or
+	// kinds qualified by their group, such as VPC.ec2.aws.crossplane.io,
+	// whose controllers should be set up. The controllers of all kinds are
+	// set up if it is empty.
+	EnabledKinds []string
+
+	// DisabledKinds are the API groups or kinds qualified by their group
+	// whose controllers should not be set up, even if they are enabled.
+	DisabledKinds []string
+}
+
+// IsEnabled returns true if the controllers of the supplied kind should be
+// set up.
+func (o Options) IsEnabled(gk schema.GroupKind) bool {
+	if len(o.EnabledKinds) > 0 && !matchesAny(o.EnabledKinds, gk) {
+		return false
+	}
+	return !matchesAny(o.DisabledKinds, gk)
+}
+
+func matchesAny(kinds []string, gk schema.GroupKind) bool {
+	for _, k := range kinds {
+		if strings.EqualFold(k, gk.Group) || strings.EqualFold(k, gk.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForControllerRuntime returns the controller-runtime options that correspond
+// to these options.
+func (o Options) ForControllerRuntime() controller.Options {
+	return controller.Options{MaxConcurrentReconciles: o.MaxConcurrentReconciles}
+}
+
+// Selected returns a builder.ForOption that restricts a controller to
+// reconciling the resources selected by the LabelSelector.
+func (o Options) Selected() builder.ForOption {
+	return builder.WithPredicates(resource.NewPredicates(o.selects))
+}
+
+func (o Options) selects(obj runtime.Object) bool {
+	if o.LabelSelector == nil {
+		return true
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return o.LabelSelector.Matches(labels.Set(m.GetLabels()))
+}
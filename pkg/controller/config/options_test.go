@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func TestSelects(t *testing.T) {
+	withLabels := func(l map[string]string) runtime.Object {
+		mg := &fake.Managed{}
+		mg.SetLabels(l)
+		return mg
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      Options
+		obj    runtime.Object
+		want   bool
+	}{
+		"NoSelector": {
+			reason: "All resources should be selected if there is no label selector.",
+			o:      Options{},
+			obj:    withLabels(nil),
+			want:   true,
+		},
+		"Matches": {
+			reason: "Resources whose labels match the label selector should be selected.",
+			o:      Options{LabelSelector: labels.SelectorFromSet(labels.Set{"env": "prod"})},
+			obj:    withLabels(map[string]string{"env": "prod", "team": "web"}),
+			want:   true,
+		},
+		"DoesNotMatch": {
+			reason: "Resources whose labels do not match the label selector should not be selected.",
+			o:      Options{LabelSelector: labels.SelectorFromSet(labels.Set{"env": "prod"})},
+			obj:    withLabels(map[string]string{"env": "dev"}),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.o.selects(tc.obj); got != tc.want {
+				t.Errorf("\n%s\no.selects(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsEnabled(t *testing.T) {
+	vpc := schema.GroupKind{Group: "ec2.aws.crossplane.io", Kind: "VPC"}
+
+	cases := map[string]struct {
+		reason string
+		o      Options
+		want   bool
+	}{
+		"AllEnabled": {
+			reason: "All kinds should be enabled if none are explicitly enabled or disabled.",
+			o:      Options{},
+			want:   true,
+		},
+		"GroupEnabled": {
+			reason: "A kind should be enabled if its group is enabled.",
+			o:      Options{EnabledKinds: []string{"database.aws.crossplane.io", "ec2.aws.crossplane.io"}},
+			want:   true,
+		},
+		"KindEnabled": {
+			reason: "A kind should be enabled if it is enabled, regardless of case.",
+			o:      Options{EnabledKinds: []string{"vpc.ec2.aws.crossplane.io"}},
+			want:   true,
+		},
+		"NotEnabled": {
+			reason: "A kind should not be enabled if other kinds are enabled.",
+			o:      Options{EnabledKinds: []string{"Subnet.ec2.aws.crossplane.io"}},
+			want:   false,
+		},
+		"GroupDisabled": {
+			reason: "A kind should not be enabled if its group is disabled.",
+			o:      Options{DisabledKinds: []string{"ec2.aws.crossplane.io"}},
+			want:   false,
+		},
+		"KindDisabled": {
+			reason: "A kind should not be enabled if it is disabled, even if its group is enabled.",
+			o:      Options{EnabledKinds: []string{"ec2.aws.crossplane.io"}, DisabledKinds: []string{"VPC.ec2.aws.crossplane.io"}},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.o.IsEnabled(vpc); got != tc.want {
+				t.Errorf("\n%s\no.IsEnabled(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
@@ -35,18 +35,20 @@ import (
 
 	"github.com/crossplane/provider-aws/apis/database/v1beta1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 // SetupPostgreSQLInstanceClaimScheduling adds a controller that reconciles
 // PostgreSQLInstance claims that include a class selector but omit their class
 // and resource references by picking a random matching RDSInstanceClass, if
 // any.
-func SetupPostgreSQLInstanceClaimScheduling(mgr ctrl.Manager, l logging.Logger) error {
+func SetupPostgreSQLInstanceClaimScheduling(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimscheduling.ControllerName(databasev1alpha1.PostgreSQLInstanceGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&databasev1alpha1.PostgreSQLInstance{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&databasev1alpha1.PostgreSQLInstance{}, o.Selected()).
 		WithEventFilter(resource.NewPredicates(resource.AllOf(
 			resource.HasClassSelector(),
 			resource.HasNoClassReference(),
@@ -63,12 +65,13 @@ func SetupPostgreSQLInstanceClaimScheduling(mgr ctrl.Manager, l logging.Logger)
 // SetupPostgreSQLInstanceClaimDefaulting adds a controller that reconciles
 // PostgreSQLInstance claims that omit their resource ref, class ref, and class
 // selector by choosing a default RDSInstanceClass if one exists.
-func SetupPostgreSQLInstanceClaimDefaulting(mgr ctrl.Manager, l logging.Logger) error {
+func SetupPostgreSQLInstanceClaimDefaulting(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimdefaulting.ControllerName(databasev1alpha1.PostgreSQLInstanceGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&databasev1alpha1.PostgreSQLInstance{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&databasev1alpha1.PostgreSQLInstance{}, o.Selected()).
 		WithEventFilter(resource.NewPredicates(resource.AllOf(
 			resource.HasNoClassSelector(),
 			resource.HasNoClassReference(),
@@ -85,7 +88,7 @@ func SetupPostgreSQLInstanceClaimDefaulting(mgr ctrl.Manager, l logging.Logger)
 // SetupPostgreSQLInstanceClaimBinding adds a controller that reconciles
 // PostgreSQLInstance claims with RDSInstances, dynamically provisioning them if
 // needed.
-func SetupPostgreSQLInstanceClaimBinding(mgr ctrl.Manager, l logging.Logger) error {
+func SetupPostgreSQLInstanceClaimBinding(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimbinding.ControllerName(databasev1alpha1.PostgreSQLInstanceGroupKind)
 
 	r := claimbinding.NewReconciler(mgr,
@@ -108,8 +111,9 @@ func SetupPostgreSQLInstanceClaimBinding(mgr ctrl.Manager, l logging.Logger) err
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		Watches(&source.Kind{Type: &v1beta1.RDSInstance{}}, &resource.EnqueueRequestForClaim{}).
-		For(&databasev1alpha1.PostgreSQLInstance{}).
+		For(&databasev1alpha1.PostgreSQLInstance{}, o.Selected()).
 		WithEventFilter(p).
 		Complete(r)
 }
@@ -161,12 +165,13 @@ func ConfigurePostgreRDSInstance(_ context.Context, cm resource.Claim, cs resour
 // SetupMySQLInstanceClaimScheduling adds a controller that reconciles
 // MySQLInstance claims that include a class selector but omit their class and
 // resource references by picking a random matching RDSInstanceClass, if any.
-func SetupMySQLInstanceClaimScheduling(mgr ctrl.Manager, l logging.Logger) error {
+func SetupMySQLInstanceClaimScheduling(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimscheduling.ControllerName(databasev1alpha1.MySQLInstanceGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&databasev1alpha1.MySQLInstance{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&databasev1alpha1.MySQLInstance{}, o.Selected()).
 		WithEventFilter(resource.NewPredicates(resource.AllOf(
 			resource.HasClassSelector(),
 			resource.HasNoClassReference(),
@@ -183,12 +188,13 @@ func SetupMySQLInstanceClaimScheduling(mgr ctrl.Manager, l logging.Logger) error
 // SetupMySQLInstanceClaimDefaulting adds a controller that reconciles
 // MySQLInstance claims that omit their resource ref, class ref, and class
 // selector by choosing a default RDSInstanceClass if one exists.
-func SetupMySQLInstanceClaimDefaulting(mgr ctrl.Manager, l logging.Logger) error {
+func SetupMySQLInstanceClaimDefaulting(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimdefaulting.ControllerName(databasev1alpha1.MySQLInstanceGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&databasev1alpha1.MySQLInstance{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&databasev1alpha1.MySQLInstance{}, o.Selected()).
 		WithEventFilter(resource.NewPredicates(resource.AllOf(
 			resource.HasNoClassSelector(),
 			resource.HasNoClassReference(),
@@ -205,7 +211,7 @@ func SetupMySQLInstanceClaimDefaulting(mgr ctrl.Manager, l logging.Logger) error
 // SetupMySQLInstanceClaimBinding adds a controller that reconciles
 // MySQLInstance claims with RDSInstances, dynamically provisioning them if
 // needed
-func SetupMySQLInstanceClaimBinding(mgr ctrl.Manager, l logging.Logger) error {
+func SetupMySQLInstanceClaimBinding(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimbinding.ControllerName(databasev1alpha1.MySQLInstanceGroupKind)
 
 	r := claimbinding.NewReconciler(mgr,
@@ -228,8 +234,9 @@ func SetupMySQLInstanceClaimBinding(mgr ctrl.Manager, l logging.Logger) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		Watches(&source.Kind{Type: &v1beta1.RDSInstance{}}, &resource.EnqueueRequestForClaim{}).
-		For(&databasev1alpha1.MySQLInstance{}).
+		For(&databasev1alpha1.MySQLInstance{}, o.Selected()).
 		WithEventFilter(p).
 		Complete(r)
 }
@@ -24,8 +24,6 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsrds "github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -37,16 +35,13 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	v1beta1 "github.com/crossplane/provider-aws/apis/database/v1beta1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	dbsg "github.com/crossplane/provider-aws/pkg/clients/dbsubnetgroup"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
-	errKubeUpdateFailed          = "cannot update DBSubnetGroup custom resource"
-	errGetProvider               = "cannot get provider"
-	errCreateDBSubnetGroupClient = "cannot create DBSubnetGroup client"
-	errGetProviderSecret         = "cannot get provider secret"
+	errKubeUpdateFailed = "cannot update DBSubnetGroup custom resource"
 
 	errUnexpectedObject   = "The managed resource is not an DBSubnetGroup resource"
 	errDescribe           = "failed to describe DBSubnetGroup with groupName: %v"
@@ -59,56 +54,25 @@ const (
 )
 
 // SetupDBSubnetGroup adds a controller that reconciles DBSubnetGroups.
-func SetupDBSubnetGroup(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDBSubnetGroup(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1beta1.DBSubnetGroupGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1beta1.DBSubnetGroup{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.DBSubnetGroup{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.DBSubnetGroupGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: dbsg.NewClient}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: dbsg.NewClient(cfg), kube: mgr.GetClient()}, nil
+			})),
+			managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
-type connector struct {
-	kube        client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (dbsg.Client, error)
-}
-
-func (conn *connector) Connect(ctx context.Context, mgd resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mgd.(*v1beta1.DBSubnetGroup)
-	if !ok {
-		return nil, errors.New(errUnexpectedObject)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := conn.kube.Get(ctx, meta.NamespacedNameOf(cr.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if aws.BoolValue(p.Spec.UseServiceAccount) {
-		dbSubnetGroupclient, err := conn.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{client: dbSubnetGroupclient, kube: conn.kube}, errors.Wrap(err, errCreateDBSubnetGroupClient)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := conn.kube.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-
-	dbSubnetGroupclient, err := conn.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{client: dbSubnetGroupclient, kube: conn.kube}, errors.Wrap(err, errCreateDBSubnetGroupClient)
-}
-
 type external struct {
 	client dbsg.Client
 	kube   client.Client
@@ -231,3 +195,11 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 	}).Send(ctx)
 	return errors.Wrap(resource.Ignore(dbsg.IsDBSubnetGroupNotFoundErr, err), errDelete)
 }
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1beta1.DBSubnetGroup)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
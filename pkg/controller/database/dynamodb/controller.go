@@ -22,8 +22,6 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -36,78 +34,48 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/database/v1alpha1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/dynamodb"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
 	errNotDynamoTable   = "managed resource is not an DynamoTable custom resource"
 	errKubeUpdateFailed = "cannot update DynamoDB table custom resource"
 
-	errCreateDynamoClient = "cannot create DynamoDB client"
-	errGetProvider        = "cannot get provider"
-	errGetProviderSecret  = "cannot get provider secret"
-
 	errCreateFailed   = "cannot create DynamoDB table"
 	errDeleteFailed   = "cannot delete DynamoDB table"
 	errDescribeFailed = "cannot describe DynamoDB table"
 	errUpdateFailed   = "cannot update DynamoDB table"
+	errListTagsFailed = "cannot list tags of DynamoDB table"
+	errTagFailed      = "cannot tag DynamoDB table"
+	errUntagFailed    = "cannot untag DynamoDB table"
 	errUpToDateFailed = "cannot check whether object is up-to-date"
 )
 
 // SetupDynamoTable adds a controller that reconciles DynamoTable.
-func SetupDynamoTable(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDynamoTable(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1alpha1.DynamoTableGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.DynamoTable{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.DynamoTable{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.DynamoTableGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: dynamodb.NewClient}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: dynamodb.NewClient(cfg), kube: mgr.GetClient(), recorder: event.NewAPIRecorder(mgr.GetEventRecorderFor(name))}, nil
+			})),
+			managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
-type connector struct {
-	kube        client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (dynamodb.Client, error)
-}
-
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1alpha1.DynamoTable)
-	if !ok {
-		return nil, errors.New(errNotDynamoTable)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := c.kube.Get(ctx, meta.NamespacedNameOf(cr.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if aws.BoolValue(p.Spec.UseServiceAccount) {
-		dynamoClient, err := c.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{client: dynamoClient, kube: c.kube}, errors.Wrap(err, errCreateDynamoClient)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := c.kube.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-
-	dynamoClient, err := c.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{client: dynamoClient, kube: c.kube}, errors.Wrap(err, errCreateDynamoClient)
-}
-
 type external struct {
-	client dynamodb.Client
-	kube   client.Client
+	client   dynamodb.Client
+	kube     client.Client
+	recorder event.Recorder
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -147,11 +115,24 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		cr.Status.SetConditions(runtimev1alpha1.Unavailable())
 	}
 
-	upToDate, err := dynamodb.IsUpToDate(cr.Spec.ForProvider, *table)
+	tags, err := dynamodb.ListTags(ctx, e.client, aws.StringValue(table.TableArn))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListTagsFailed)
+	}
+
+	upToDate, err := dynamodb.IsUpToDate(cr.Spec.ForProvider, *table, tags)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errUpToDateFailed)
 	}
 
+	var drift []v1alpha3.FieldDrift
+	if !upToDate {
+		if drift, err = dynamodb.Diff(cr.Spec.ForProvider, *table, tags); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errUpToDateFailed)
+		}
+	}
+	awsclients.ReportDrift(e.recorder, cr, &cr.Status, drift)
+
 	return managed.ExternalObservation{
 		ResourceExists:   true,
 		ResourceUpToDate: upToDate,
@@ -184,11 +165,58 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, nil
 	}
 
-	_, err := e.client.UpdateTableRequest(dynamodb.GenerateUpdateTableInput(cr.Status.AtProvider.TableName, &cr.Spec.ForProvider)).Send(ctx)
+	rsp, err := e.client.DescribeTableRequest(&awsdynamo.DescribeTableInput{
+		TableName: aws.String(meta.GetExternalName(cr)),
+	}).Send(ctx)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDescribeFailed)
+	}
+	table := rsp.DescribeTableOutput.Table
+
+	tags, err := dynamodb.ListTags(ctx, e.client, aws.StringValue(table.TableArn))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errListTagsFailed)
+	}
+	if err := e.updateTags(ctx, aws.StringValue(table.TableArn), cr.Spec.ForProvider.Tags, tags); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Tables are updated separately from their tags, and can't be updated
+	// without changes.
+	patch, err := dynamodb.CreatePatch(table, tags, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpToDateFailed)
+	}
+	patch.Tags = nil
+	if cmp.Equal(&v1alpha1.DynamoTableParameters{}, patch) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	_, err = e.client.UpdateTableRequest(dynamodb.GenerateUpdateTableInput(cr.Status.AtProvider.TableName, &cr.Spec.ForProvider)).Send(ctx)
 
 	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
 }
 
+func (e *external) updateTags(ctx context.Context, arn string, desired []v1alpha1.Tag, observed []awsdynamo.Tag) error {
+	add, remove := awsclients.DiffTags(awsclients.TagMap(desired), awsclients.TagMap(observed))
+	if len(remove) != 0 {
+		if _, err := e.client.UntagResourceRequest(&awsdynamo.UntagResourceInput{
+			ResourceArn: aws.String(arn),
+			TagKeys:     remove,
+		}).Send(ctx); err != nil {
+			return errors.Wrap(err, errUntagFailed)
+		}
+	}
+	if len(add) != 0 {
+		input := &awsdynamo.TagResourceInput{ResourceArn: aws.String(arn)}
+		awsclients.SetTagMap(&input.Tags, add)
+		if _, err := e.client.TagResourceRequest(input).Send(ctx); err != nil {
+			return errors.Wrap(err, errTagFailed)
+		}
+	}
+	return nil
+}
+
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1alpha1.DynamoTable)
 	if !ok {
@@ -204,3 +232,11 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	}).Send(ctx)
 	return errors.Wrap(resource.Ignore(dynamodb.IsErrorNotFound, err), errDeleteFailed)
 }
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1alpha1.DynamoTable)
+	if !ok {
+		return nil, errors.New(errNotDynamoTable)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
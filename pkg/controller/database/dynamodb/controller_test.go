@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-   http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -23,33 +23,26 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsdynamo "github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/provider-aws/apis/database/v1alpha1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
-	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	"github.com/crossplane/provider-aws/pkg/clients/dynamodb"
 	"github.com/crossplane/provider-aws/pkg/clients/dynamodb/fake"
 )
 
 const (
-	providerName    = "aws-creds"
-	secretNamespace = "crossplane-system"
-	testRegion      = "us-east-1"
-
-	connectionSecretName = "my-little-secret"
-	secretKey            = "credentials"
-	credData             = "confidential!"
+	providerName = "aws-creds"
 )
 
 var (
+	tableArn = "arn:aws:dynamodb:us-east-1:123456789012:table/cool"
+
 	errBoom = errors.New("boom")
 )
 
@@ -73,6 +66,44 @@ func withStatus(s v1alpha1.DynamoTableObservation) tableModifier {
 	return func(r *v1alpha1.DynamoTable) { r.Status.AtProvider = s }
 }
 
+func withTags(tags ...v1alpha1.Tag) tableModifier {
+	return func(r *v1alpha1.DynamoTable) { r.Spec.ForProvider.Tags = tags }
+}
+
+func withThroughput(units int64) tableModifier {
+	return func(r *v1alpha1.DynamoTable) {
+		r.Spec.ForProvider.ProvisionedThroughput = &v1alpha1.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(units),
+			WriteCapacityUnits: aws.Int64(units),
+		}
+	}
+}
+
+func withDrift(d ...v1alpha3.FieldDrift) tableModifier {
+	return func(r *v1alpha1.DynamoTable) { r.Status.Drift = d }
+}
+
+func describe(t awsdynamo.TableDescription) func(*awsdynamo.DescribeTableInput) awsdynamo.DescribeTableRequest {
+	return func(_ *awsdynamo.DescribeTableInput) awsdynamo.DescribeTableRequest {
+		return awsdynamo.DescribeTableRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsdynamo.DescribeTableOutput{Table: &t}},
+		}
+	}
+}
+
+func listTags(tags []awsdynamo.Tag, err error) func(*awsdynamo.ListTagsOfResourceInput) awsdynamo.ListTagsOfResourceRequest {
+	return func(_ *awsdynamo.ListTagsOfResourceInput) awsdynamo.ListTagsOfResourceRequest {
+		if err != nil {
+			return awsdynamo.ListTagsOfResourceRequest{
+				Request: &aws.Request{HTTPRequest: &http.Request{}, Error: err},
+			}
+		}
+		return awsdynamo.ListTagsOfResourceRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsdynamo.ListTagsOfResourceOutput{Tags: tags}},
+		}
+	}
+}
+
 func table(m ...tableModifier) *v1alpha1.DynamoTable {
 	cr := &v1alpha1.DynamoTable{
 		Spec: v1alpha1.DynamoTableSpec{
@@ -88,171 +119,6 @@ func table(m ...tableModifier) *v1alpha1.DynamoTable {
 }
 
 var _ managed.ExternalClient = &external{}
-var _ managed.ExternalConnecter = &connector{}
-
-func TestConnect(t *testing.T) {
-	secret := corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      connectionSecretName,
-			Namespace: secretNamespace,
-		},
-		Data: map[string][]byte{
-			secretKey: []byte(credData),
-		},
-	}
-
-	providerSA := func(saVal bool) awsv1alpha3.Provider {
-		return awsv1alpha3.Provider{
-			Spec: awsv1alpha3.ProviderSpec{
-				Region:            testRegion,
-				UseServiceAccount: &saVal,
-				ProviderSpec: runtimev1alpha1.ProviderSpec{
-					CredentialsSecretRef: &runtimev1alpha1.SecretKeySelector{
-						SecretReference: runtimev1alpha1.SecretReference{
-							Namespace: secretNamespace,
-							Name:      connectionSecretName,
-						},
-						Key: secretKey,
-					},
-				},
-			},
-		}
-	}
-	type args struct {
-		kube        client.Client
-		newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (dynamodb.Client, error)
-		cr          *v1alpha1.DynamoTable
-	}
-	type want struct {
-		err error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"Successful": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							secret.DeepCopyInto(obj.(*corev1.Secret))
-							return nil
-						}
-						return errBoom
-					},
-				},
-				newClientFn: func(_ context.Context, credentials []byte, region string, _ awsclients.AuthMethod) (i dynamodb.Client, e error) {
-					if diff := cmp.Diff(credData, string(credentials)); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					if diff := cmp.Diff(testRegion, region); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return nil, nil
-				},
-				cr: table(),
-			},
-		},
-		"SuccessfulUseServiceAccount": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						if key == (client.ObjectKey{Name: providerName}) {
-							p := providerSA(true)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						}
-						return errBoom
-					},
-				},
-				newClientFn: func(_ context.Context, credentials []byte, region string, _ awsclients.AuthMethod) (i dynamodb.Client, e error) {
-					if diff := cmp.Diff("", string(credentials)); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					if diff := cmp.Diff(testRegion, region); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return nil, nil
-				},
-				cr: table(),
-			},
-		},
-		"ProviderGetFailed": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						return errBoom
-					},
-				},
-				cr: table(),
-			},
-			want: want{
-				err: errors.Wrap(errBoom, errGetProvider),
-			},
-		},
-		"SecretGetFailed": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							return errBoom
-						default:
-							return nil
-						}
-					},
-				},
-				cr: table(),
-			},
-			want: want{
-				err: errors.Wrap(errBoom, errGetProviderSecret),
-			},
-		},
-		"SecretGetFailedNil": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.SetCredentialsSecretReference(nil)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							return errBoom
-						default:
-							return nil
-						}
-					},
-				},
-				cr: table(),
-			},
-			want: want{
-				err: errors.New(errGetProviderSecret),
-			},
-		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			c := &connector{kube: tc.kube, newClientFn: tc.newClientFn}
-			_, err := c.Connect(context.Background(), tc.args.cr)
-			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
 
 func TestObserve(t *testing.T) {
 	type want struct {
@@ -279,6 +145,7 @@ func TestObserve(t *testing.T) {
 							},
 						}
 					},
+					MockListTags: listTags(nil, nil),
 				},
 				cr: table(),
 			},
@@ -309,6 +176,7 @@ func TestObserve(t *testing.T) {
 							},
 						}
 					},
+					MockListTags: listTags(nil, nil),
 				},
 				cr: table(),
 			},
@@ -324,6 +192,66 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		"TagsOutOfDate": {
+			args: args{
+				dynamo: &fake.MockDynamoClient{
+					MockDescribe: describe(awsdynamo.TableDescription{
+						TableArn:    aws.String(tableArn),
+						TableStatus: v1alpha1.DynamoTableStateAvailable,
+					}),
+					MockListTags: func(input *awsdynamo.ListTagsOfResourceInput) awsdynamo.ListTagsOfResourceRequest {
+						if diff := cmp.Diff(&awsdynamo.ListTagsOfResourceInput{ResourceArn: aws.String(tableArn)}, input); diff != "" {
+							return awsdynamo.ListTagsOfResourceRequest{
+								Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(diff)},
+							}
+						}
+						return listTags([]awsdynamo.Tag{{Key: aws.String("k"), Value: aws.String("old")}}, nil)(input)
+					},
+				},
+				cr: table(withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
+			},
+			want: want{
+				cr: table(
+					withTags(v1alpha1.Tag{Key: "k", Value: "v"}),
+					withStatus(v1alpha1.DynamoTableObservation{
+						TableArn:    tableArn,
+						TableStatus: v1alpha1.DynamoTableStateAvailable,
+					}),
+					withDrift(v1alpha3.FieldDrift{
+						Path:     "spec.forProvider.tag",
+						Observed: `[{"tag":"k","value":"old"}]`,
+						Desired:  `[{"tag":"k","value":"v"}]`,
+					}),
+					withConditions(runtimev1alpha1.Available()),
+					withBindingPhase(runtimev1alpha1.BindingPhaseUnbound)),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"FailedListTags": {
+			args: args{
+				dynamo: &fake.MockDynamoClient{
+					MockDescribe: describe(awsdynamo.TableDescription{
+						TableArn:    aws.String(tableArn),
+						TableStatus: v1alpha1.DynamoTableStateAvailable,
+					}),
+					MockListTags: listTags(nil, errBoom),
+				},
+				cr: table(),
+			},
+			want: want{
+				cr: table(
+					withStatus(v1alpha1.DynamoTableObservation{
+						TableArn:    tableArn,
+						TableStatus: v1alpha1.DynamoTableStateAvailable,
+					}),
+					withConditions(runtimev1alpha1.Available()),
+					withBindingPhase(runtimev1alpha1.BindingPhaseUnbound)),
+				err: errors.Wrap(errBoom, errListTagsFailed),
+			},
+		},
 		"FailedDescribeRequest": {
 			args: args{
 				dynamo: &fake.MockDynamoClient{
@@ -359,7 +287,7 @@ func TestObserve(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.dynamo}
+			e := &external{client: tc.dynamo, recorder: event.NewNopRecorder()}
 			o, err := e.Observe(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -463,17 +391,98 @@ func TestUpdate(t *testing.T) {
 		"Successful": {
 			args: args{
 				dynamo: &fake.MockDynamoClient{
+					MockDescribe: describe(awsdynamo.TableDescription{
+						ProvisionedThroughput: &awsdynamo.ProvisionedThroughputDescription{
+							ReadCapacityUnits:  aws.Int64(1),
+							WriteCapacityUnits: aws.Int64(1),
+						},
+					}),
+					MockListTags: listTags(nil, nil),
 					MockUpdate: func(input *awsdynamo.UpdateTableInput) awsdynamo.UpdateTableRequest {
 						return awsdynamo.UpdateTableRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsdynamo.UpdateTableOutput{}},
 						}
 					},
 				},
-				cr: table(),
+				cr: table(withThroughput(2)),
+			},
+			want: want{
+				cr: table(withThroughput(2)),
+			},
+		},
+		"TagsOnly": {
+			args: args{
+				dynamo: &fake.MockDynamoClient{
+					MockDescribe: describe(awsdynamo.TableDescription{TableArn: aws.String(tableArn)}),
+					MockListTags: listTags([]awsdynamo.Tag{
+						{Key: aws.String("k"), Value: aws.String("old")},
+						{Key: aws.String("gone"), Value: aws.String("v")},
+					}, nil),
+					MockUntag: func(input *awsdynamo.UntagResourceInput) awsdynamo.UntagResourceRequest {
+						want := &awsdynamo.UntagResourceInput{ResourceArn: aws.String(tableArn), TagKeys: []string{"gone"}}
+						if diff := cmp.Diff(want, input); diff != "" {
+							return awsdynamo.UntagResourceRequest{
+								Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(diff)},
+							}
+						}
+						return awsdynamo.UntagResourceRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsdynamo.UntagResourceOutput{}},
+						}
+					},
+					MockTag: func(input *awsdynamo.TagResourceInput) awsdynamo.TagResourceRequest {
+						want := &awsdynamo.TagResourceInput{
+							ResourceArn: aws.String(tableArn),
+							Tags:        []awsdynamo.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+						}
+						if diff := cmp.Diff(want, input); diff != "" {
+							return awsdynamo.TagResourceRequest{
+								Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(diff)},
+							}
+						}
+						return awsdynamo.TagResourceRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsdynamo.TagResourceOutput{}},
+						}
+					},
+				},
+				cr: table(withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
 			},
 			want: want{
+				cr: table(withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
+			},
+		},
+		"FailedTag": {
+			args: args{
+				dynamo: &fake.MockDynamoClient{
+					MockDescribe: describe(awsdynamo.TableDescription{TableArn: aws.String(tableArn)}),
+					MockListTags: listTags(nil, nil),
+					MockTag: func(input *awsdynamo.TagResourceInput) awsdynamo.TagResourceRequest {
+						return awsdynamo.TagResourceRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: table(withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
+			},
+			want: want{
+				cr:  table(withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
+				err: errors.Wrap(errBoom, errTagFailed),
+			},
+		},
+		"FailedDescribe": {
+			args: args{
+				dynamo: &fake.MockDynamoClient{
+					MockDescribe: func(input *awsdynamo.DescribeTableInput) awsdynamo.DescribeTableRequest {
+						return awsdynamo.DescribeTableRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
 				cr: table(),
 			},
+			want: want{
+				cr:  table(),
+				err: errors.Wrap(errBoom, errDescribeFailed),
+			},
 		},
 		"AlreadyModifying": {
 			args: args{
@@ -490,16 +499,23 @@ func TestUpdate(t *testing.T) {
 		"FailedModify": {
 			args: args{
 				dynamo: &fake.MockDynamoClient{
+					MockDescribe: describe(awsdynamo.TableDescription{
+						ProvisionedThroughput: &awsdynamo.ProvisionedThroughputDescription{
+							ReadCapacityUnits:  aws.Int64(1),
+							WriteCapacityUnits: aws.Int64(1),
+						},
+					}),
+					MockListTags: listTags(nil, nil),
 					MockUpdate: func(input *awsdynamo.UpdateTableInput) awsdynamo.UpdateTableRequest {
 						return awsdynamo.UpdateTableRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
 						}
 					},
 				},
-				cr: table(),
+				cr: table(withThroughput(2)),
 			},
 			want: want{
-				cr:  table(),
+				cr:  table(withThroughput(2)),
 				err: errors.Wrap(errBoom, errUpdateFailed),
 			},
 		},
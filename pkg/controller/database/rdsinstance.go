@@ -19,13 +19,10 @@ package database
 import (
 	"context"
 	"reflect"
-	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsrds "github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -38,19 +35,16 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/database/v1beta1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/rds"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
 	errNotRDSInstance   = "managed resource is not an RDS instance custom resource"
 	errKubeUpdateFailed = "cannot update RDS instance custom resource"
 
-	errCreateRDSClient   = "cannot create RDS client"
-	errGetProvider       = "cannot get provider"
-	errGetProviderSecret = "cannot get provider secret"
-
 	errCreateFailed        = "cannot create RDS instance"
 	errModifyFailed        = "cannot modify RDS instance"
 	errAddTagsFailed       = "cannot add tags to RDS instance"
@@ -61,59 +55,28 @@ const (
 )
 
 // SetupRDSInstance adds a controller that reconciles RDSInstances.
-func SetupRDSInstance(mgr ctrl.Manager, l logging.Logger) error {
+func SetupRDSInstance(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1beta1.RDSInstanceGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1beta1.RDSInstance{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.RDSInstance{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.RDSInstanceGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: rds.NewClient}),
-			managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), &tagger{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: rds.NewClient(cfg), kube: mgr.GetClient(), recorder: event.NewAPIRecorder(mgr.GetEventRecorderFor(name))}, nil
+			})),
+			managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
-type connector struct {
-	kube        client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (rds.Client, error)
-}
-
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1beta1.RDSInstance)
-	if !ok {
-		return nil, errors.New(errNotRDSInstance)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := c.kube.Get(ctx, meta.NamespacedNameOf(cr.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if aws.BoolValue(p.Spec.UseServiceAccount) {
-		rdsClient, err := c.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{client: rdsClient, kube: c.kube}, errors.Wrap(err, errCreateRDSClient)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := c.kube.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-
-	rdsClient, err := c.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{client: rdsClient, kube: c.kube}, errors.Wrap(err, errCreateRDSClient)
-}
-
 type external struct {
-	client rds.Client
-	kube   client.Client
+	client   rds.Client
+	kube     client.Client
+	recorder event.Recorder
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -159,6 +122,14 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, errUpToDateFailed)
 	}
 
+	var drift []v1alpha3.FieldDrift
+	if !upToDate {
+		if drift, err = rds.Diff(cr.Spec.ForProvider, instance); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errUpToDateFailed)
+		}
+	}
+	awsclients.ReportDrift(e.recorder, cr, &cr.Status, drift)
+
 	return managed.ExternalObservation{
 		ResourceExists:    true,
 		ResourceUpToDate:  upToDate,
@@ -264,30 +235,10 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	return errors.Wrap(resource.Ignore(rds.IsErrorNotFound, err), errDeleteFailed)
 }
 
-type tagger struct {
-	kube client.Client
-}
-
-func (t *tagger) Initialize(ctx context.Context, mg resource.Managed) error {
+func specTags(mg resource.Managed) (interface{}, error) {
 	cr, ok := mg.(*v1beta1.RDSInstance)
 	if !ok {
-		return errors.New(errNotRDSInstance)
-	}
-	tagMap := map[string]string{}
-	for _, t := range cr.Spec.ForProvider.Tags {
-		tagMap[t.Key] = t.Value
-	}
-	for k, v := range resource.GetExternalTags(mg) {
-		tagMap[k] = v
-	}
-	cr.Spec.ForProvider.Tags = make([]v1beta1.Tag, len(tagMap))
-	i := 0
-	for k, v := range tagMap {
-		cr.Spec.ForProvider.Tags[i] = v1beta1.Tag{Key: k, Value: v}
-		i++
+		return nil, errors.New(errNotRDSInstance)
 	}
-	sort.Slice(cr.Spec.ForProvider.Tags, func(i, j int) bool {
-		return cr.Spec.ForProvider.Tags[i].Key < cr.Spec.ForProvider.Tags[j].Key
-	})
-	return errors.Wrap(t.kube.Update(ctx, cr), errKubeUpdateFailed)
+	return &cr.Spec.ForProvider.Tags, nil
 }
@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticip
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
+)
+
+const (
+	errUnexpectedObject = "The managed resource is not an ElasticIP resource"
+	errDescribe         = "failed to describe ElasticIP"
+	errNotSingleItem    = "either no or multiple ElasticIPs retrieved for the given allocationId"
+	errCreate           = "failed to allocate the ElasticIP resource"
+	errDelete           = "failed to release the ElasticIP resource"
+	errSpecUpdate       = "cannot update spec of the ElasticIP resource"
+	errStatusUpdate     = "cannot update status of the ElasticIP resource"
+	errUpdateTags       = "failed to update tags for the ElasticIP resource"
+)
+
+// SetupElasticIP adds a controller that reconciles ElasticIPs.
+func SetupElasticIP(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
+	name := managed.ControllerName(v1alpha4.ElasticIPGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha4.ElasticIP{}, o.Selected()).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha4.ElasticIPGroupVersionKind),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: ec2.NewElasticIPClient(cfg), kube: mgr.GetClient()}, nil
+			})),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(awsclients.NewTagger(mgr.GetClient(), specTags)),
+			managed.WithConnectionPublishers(),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type external struct {
+	kube   client.Client
+	client ec2.ElasticIPClient
+}
+
+func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha4.ElasticIP)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errUnexpectedObject)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	response, err := e.client.DescribeAddressesRequest(ec2.GenerateDescribeAddressesInput(meta.GetExternalName(cr), cr.Spec.ForProvider)).Send(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(ec2.IsAddressNotFoundErr, err), errDescribe)
+	}
+
+	// in a successful response, there should be one and only one object
+	if len(response.Addresses) != 1 {
+		return managed.ExternalObservation{}, errors.New(errNotSingleItem)
+	}
+
+	observed := response.Addresses[0]
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	ec2.LateInitializeElasticIP(&cr.Spec.ForProvider, &observed)
+	if !cmp.Equal(current, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSpecUpdate)
+		}
+	}
+
+	cr.SetConditions(runtimev1alpha1.Available())
+
+	cr.Status.AtProvider = ec2.GenerateElasticIPObservation(observed)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: ec2.IsElasticIPUpToDate(cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha4.ElasticIP)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errUnexpectedObject)
+	}
+
+	cr.Status.SetConditions(runtimev1alpha1.Creating())
+	if err := e.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errStatusUpdate)
+	}
+
+	result, err := e.client.AllocateAddressRequest(&awsec2.AllocateAddressInput{
+		Domain:             awsec2.DomainType(aws.StringValue(cr.Spec.ForProvider.Domain)),
+		Address:            cr.Spec.ForProvider.Address,
+		PublicIpv4Pool:     cr.Spec.ForProvider.PublicIPv4Pool,
+		NetworkBorderGroup: cr.Spec.ForProvider.NetworkBorderGroup,
+	}).Send(ctx)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	// The domain determines how the address is identified, so it must be
+	// known before the address is next observed.
+	if result.Domain != "" {
+		cr.Spec.ForProvider.Domain = awsclients.LateInitializeStringPtr(cr.Spec.ForProvider.Domain, aws.String(string(result.Domain)))
+	}
+
+	id := aws.StringValue(result.AllocationId)
+	if ec2.IsStandardAddress(cr.Spec.ForProvider) {
+		id = aws.StringValue(result.PublicIp)
+	}
+	meta.SetExternalName(cr, id)
+
+	return managed.ExternalCreation{}, errors.Wrap(e.kube.Update(ctx, cr), errSpecUpdate)
+}
+
+func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha4.ElasticIP)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
+	}
+
+	// NOTE: Addresses in the standard domain are identified by their public
+	// IP address, which cannot be used as a resource ID for tagging.
+	if ec2.IsStandardAddress(cr.Spec.ForProvider) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	err := ec2.UpdateTags(ctx, e.client, meta.GetExternalName(cr), cr.Spec.ForProvider.Tags, cr.Status.AtProvider.Tags)
+
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
+}
+
+func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha4.ElasticIP)
+	if !ok {
+		return errors.New(errUnexpectedObject)
+	}
+
+	cr.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	_, err := e.client.ReleaseAddressRequest(ec2.GenerateReleaseAddressInput(meta.GetExternalName(cr), cr.Spec.ForProvider)).Send(ctx)
+
+	return errors.Wrap(resource.Ignore(ec2.IsAddressNotFoundErr, err), errDelete)
+}
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1alpha4.ElasticIP)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
@@ -0,0 +1,438 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticip
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2/fake"
+)
+
+const (
+	providerName = "aws-creds"
+)
+
+var (
+	allocationID = "eipalloc-1"
+	publicIP     = "198.51.100.1"
+	vpc          = v1alpha4.ElasticIPDomainVPC
+	standard     = v1alpha4.ElasticIPDomainStandard
+
+	errBoom = errors.New("boom")
+)
+
+type args struct {
+	eip  ec2.ElasticIPClient
+	kube client.Client
+	cr   *v1alpha4.ElasticIP
+}
+
+type eipModifier func(*v1alpha4.ElasticIP)
+
+func withExternalName(name string) eipModifier {
+	return func(r *v1alpha4.ElasticIP) { meta.SetExternalName(r, name) }
+}
+
+func withConditions(c ...runtimev1alpha1.Condition) eipModifier {
+	return func(r *v1alpha4.ElasticIP) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withSpec(p v1alpha4.ElasticIPParameters) eipModifier {
+	return func(r *v1alpha4.ElasticIP) { r.Spec.ForProvider = p }
+}
+
+func withStatus(s v1alpha4.ElasticIPObservation) eipModifier {
+	return func(r *v1alpha4.ElasticIP) { r.Status.AtProvider = s }
+}
+
+func eip(m ...eipModifier) *v1alpha4.ElasticIP {
+	cr := &v1alpha4.ElasticIP{
+		Spec: v1alpha4.ElasticIPSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &corev1.ObjectReference{Name: providerName},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+var _ managed.ExternalClient = &external{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha4.ElasticIP
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Successful": {
+			reason: "An allocated address should be observed as available and up to date.",
+			args: args{
+				eip: &fake.MockElasticIPClient{
+					MockDescribe: func(input *awsec2.DescribeAddressesInput) awsec2.DescribeAddressesRequest {
+						return awsec2.DescribeAddressesRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeAddressesOutput{
+								Addresses: []awsec2.Address{{AllocationId: aws.String(allocationID), PublicIp: aws.String(publicIP), Domain: awsec2.DomainTypeVpc}},
+							}},
+						}
+					},
+				},
+				cr: eip(withSpec(v1alpha4.ElasticIPParameters{Domain: &vpc}), withExternalName(allocationID)),
+			},
+			want: want{
+				cr: eip(withSpec(v1alpha4.ElasticIPParameters{Domain: &vpc}), withExternalName(allocationID),
+					withStatus(v1alpha4.ElasticIPObservation{AllocationID: allocationID, PublicIP: publicIP, Domain: vpc}),
+					withConditions(runtimev1alpha1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"NotFound": {
+			reason: "An address that is not found should not exist.",
+			args: args{
+				eip: &fake.MockElasticIPClient{
+					MockDescribe: func(input *awsec2.DescribeAddressesInput) awsec2.DescribeAddressesRequest {
+						return awsec2.DescribeAddressesRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: awserr.New(ec2.AllocationIDNotFound, "", nil)},
+						}
+					},
+				},
+				cr: eip(withExternalName(allocationID)),
+			},
+			want: want{
+				cr: eip(withExternalName(allocationID)),
+			},
+		},
+		"FailedRequest": {
+			reason: "Errors describing the address should be returned.",
+			args: args{
+				eip: &fake.MockElasticIPClient{
+					MockDescribe: func(input *awsec2.DescribeAddressesInput) awsec2.DescribeAddressesRequest {
+						return awsec2.DescribeAddressesRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: eip(withExternalName(allocationID)),
+			},
+			want: want{
+				cr:  eip(withExternalName(allocationID)),
+				err: errors.Wrap(errBoom, errDescribe),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.eip}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha4.ElasticIP
+		result managed.ExternalCreation
+		err    error
+	}
+
+	allocate := func(out *awsec2.AllocateAddressOutput) func(*awsec2.AllocateAddressInput) awsec2.AllocateAddressRequest {
+		return func(*awsec2.AllocateAddressInput) awsec2.AllocateAddressRequest {
+			return awsec2.AllocateAddressRequest{
+				Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: out},
+			}
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"VPC": {
+			reason: "The external name of an address in the vpc domain should be its allocation ID.",
+			args: args{
+				kube: &test.MockClient{
+					MockUpdate:       test.NewMockClient().Update,
+					MockStatusUpdate: test.NewMockClient().MockStatusUpdate,
+				},
+				eip: &fake.MockElasticIPClient{
+					MockAllocate: allocate(&awsec2.AllocateAddressOutput{AllocationId: aws.String(allocationID), PublicIp: aws.String(publicIP), Domain: awsec2.DomainTypeVpc}),
+				},
+				cr: eip(),
+			},
+			want: want{
+				cr: eip(withSpec(v1alpha4.ElasticIPParameters{Domain: &vpc}), withExternalName(allocationID), withConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"Standard": {
+			reason: "The external name of an address in the standard domain should be its public IP address.",
+			args: args{
+				kube: &test.MockClient{
+					MockUpdate:       test.NewMockClient().Update,
+					MockStatusUpdate: test.NewMockClient().MockStatusUpdate,
+				},
+				eip: &fake.MockElasticIPClient{
+					MockAllocate: allocate(&awsec2.AllocateAddressOutput{PublicIp: aws.String(publicIP), Domain: awsec2.DomainTypeStandard}),
+				},
+				cr: eip(),
+			},
+			want: want{
+				cr: eip(withSpec(v1alpha4.ElasticIPParameters{Domain: &standard}), withExternalName(publicIP), withConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"FailedRequest": {
+			reason: "Errors allocating the address should be returned.",
+			args: args{
+				kube: &test.MockClient{
+					MockStatusUpdate: test.NewMockClient().MockStatusUpdate,
+				},
+				eip: &fake.MockElasticIPClient{
+					MockAllocate: func(input *awsec2.AllocateAddressInput) awsec2.AllocateAddressRequest {
+						return awsec2.AllocateAddressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: eip(),
+			},
+			want: want{
+				cr:  eip(withConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errBoom, errCreate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.eip}
+			o, err := e.Create(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha4.ElasticIP
+		result managed.ExternalUpdate
+		err    error
+	}
+
+	tags := []v1beta1.Tag{{Key: "k", Value: "v"}}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Successful": {
+			reason: "Tags that are not observed should be created on the allocation.",
+			args: args{
+				eip: &fake.MockElasticIPClient{
+					MockCreateTags: func(input *awsec2.CreateTagsInput) awsec2.CreateTagsRequest {
+						want := &awsec2.CreateTagsInput{
+							Resources: []string{allocationID},
+							Tags:      []awsec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+						}
+						if diff := cmp.Diff(want, input); diff != "" {
+							return awsec2.CreateTagsRequest{
+								Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(diff)},
+							}
+						}
+						return awsec2.CreateTagsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.CreateTagsOutput{}},
+						}
+					},
+				},
+				cr: eip(withSpec(v1alpha4.ElasticIPParameters{Domain: &vpc, Tags: tags}), withExternalName(allocationID)),
+			},
+			want: want{
+				cr: eip(withSpec(v1alpha4.ElasticIPParameters{Domain: &vpc, Tags: tags}), withExternalName(allocationID)),
+			},
+		},
+		"CreateTagsFail": {
+			reason: "Errors updating the tags should be returned.",
+			args: args{
+				eip: &fake.MockElasticIPClient{
+					MockCreateTags: func(input *awsec2.CreateTagsInput) awsec2.CreateTagsRequest {
+						return awsec2.CreateTagsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: eip(withSpec(v1alpha4.ElasticIPParameters{Domain: &vpc, Tags: tags}), withExternalName(allocationID)),
+			},
+			want: want{
+				cr:  eip(withSpec(v1alpha4.ElasticIPParameters{Domain: &vpc, Tags: tags}), withExternalName(allocationID)),
+				err: errors.Wrap(errors.Wrap(errBoom, "cannot create tags"), errUpdateTags),
+			},
+		},
+		"StandardAddress": {
+			reason: "Addresses in the standard domain are identified by their public IP and should not be tagged.",
+			args: args{
+				eip: &fake.MockElasticIPClient{},
+				cr:  eip(withSpec(v1alpha4.ElasticIPParameters{Domain: &standard, Tags: tags}), withExternalName(publicIP)),
+			},
+			want: want{
+				cr: eip(withSpec(v1alpha4.ElasticIPParameters{Domain: &standard, Tags: tags}), withExternalName(publicIP)),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.eip}
+			o, err := e.Update(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		cr  *v1alpha4.ElasticIP
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Successful": {
+			reason: "The address should be released.",
+			args: args{
+				eip: &fake.MockElasticIPClient{
+					MockRelease: func(input *awsec2.ReleaseAddressInput) awsec2.ReleaseAddressRequest {
+						return awsec2.ReleaseAddressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.ReleaseAddressOutput{}},
+						}
+					},
+				},
+				cr: eip(withExternalName(allocationID)),
+			},
+			want: want{
+				cr: eip(withExternalName(allocationID), withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"NotFound": {
+			reason: "Addresses that are not found should be considered released.",
+			args: args{
+				eip: &fake.MockElasticIPClient{
+					MockRelease: func(input *awsec2.ReleaseAddressInput) awsec2.ReleaseAddressRequest {
+						return awsec2.ReleaseAddressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: awserr.New(ec2.AllocationIDNotFound, "", nil)},
+						}
+					},
+				},
+				cr: eip(withExternalName(allocationID)),
+			},
+			want: want{
+				cr: eip(withExternalName(allocationID), withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"ReleaseFail": {
+			reason: "Errors releasing the address should be returned.",
+			args: args{
+				eip: &fake.MockElasticIPClient{
+					MockRelease: func(input *awsec2.ReleaseAddressInput) awsec2.ReleaseAddressRequest {
+						return awsec2.ReleaseAddressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: eip(withExternalName(allocationID)),
+			},
+			want: want{
+				cr:  eip(withExternalName(allocationID), withConditions(runtimev1alpha1.Deleting())),
+				err: errors.Wrap(errBoom, errDelete),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.eip}
+			err := e.Delete(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
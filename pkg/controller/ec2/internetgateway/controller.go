@@ -23,8 +23,6 @@ import (
 	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -36,16 +34,12 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
-	errClient            = "cannot create a new InternetGatewayClient"
-	errGetProvider       = "cannot get provider"
-	errGetProviderSecret = "cannot get provider secret"
-
 	errUnexpectedObject    = "The managed resource is not an InternetGateway resource"
 	errDescribe            = "failed to describe InternetGateway"
 	errNotSingleItem       = "either no or multiple InternetGateways retrieved for the given internetGatewayId"
@@ -56,61 +50,29 @@ const (
 	errUpdate              = "failed to update the InternetGateway resource"
 	errSpecUpdate          = "cannot update spec of the InternetGateway resource"
 	errStatusUpdate        = "cannot update status of the InternetGateway resource"
-	errCreateTags          = "failed to create tags for the InternetGateway resource"
+	errUpdateTags          = "failed to update tags for the InternetGateway resource"
 )
 
 // SetupInternetGateway adds a controller that reconciles InternetGateways.
-func SetupInternetGateway(mgr ctrl.Manager, l logging.Logger) error {
+func SetupInternetGateway(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1beta1.InternetGatewayGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1beta1.InternetGateway{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.InternetGateway{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.InternetGatewayGroupVersionKind),
-			managed.WithExternalConnecter(&connector{client: mgr.GetClient(), newClientFn: ec2.NewInternetGatewayClient}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: ec2.NewInternetGatewayClient(cfg), kube: mgr.GetClient()}, nil
+			})),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithInitializers(),
+			managed.WithInitializers(awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithConnectionPublishers(),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
-type connector struct {
-	client      client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (ec2.InternetGatewayClient, error)
-}
-
-func (conn *connector) Connect(ctx context.Context, mgd resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mgd.(*v1beta1.InternetGateway)
-	if !ok {
-		return nil, errors.New(errUnexpectedObject)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := conn.client.Get(ctx, meta.NamespacedNameOf(cr.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if aws.BoolValue(p.Spec.UseServiceAccount) {
-		igClient, err := conn.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{client: igClient, kube: conn.client}, errors.Wrap(err, errClient)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := conn.client.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-
-	igClient, err := conn.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{client: igClient, kube: conn.client}, errors.Wrap(err, errClient)
-}
-
 type external struct {
 	kube   client.Client
 	client ec2.InternetGatewayClient
@@ -187,14 +149,8 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
 	}
 
-	// Tagging the created InternetGateway
-	if len(cr.Spec.ForProvider.Tags) > 0 {
-		if _, err := e.client.CreateTagsRequest(&awsec2.CreateTagsInput{
-			Resources: []string{meta.GetExternalName(cr)},
-			Tags:      v1beta1.GenerateEC2Tags(cr.Spec.ForProvider.Tags),
-		}).Send(ctx); err != nil {
-			return managed.ExternalUpdate{}, errors.Wrap(err, errCreateTags)
-		}
+	if err := ec2.UpdateTags(ctx, e.client, meta.GetExternalName(cr), cr.Spec.ForProvider.Tags, cr.Status.AtProvider.Tags); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
 	}
 
 	response, err := e.client.DescribeInternetGatewaysRequest(&awsec2.DescribeInternetGatewaysInput{
@@ -263,3 +219,11 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 
 	return errors.Wrap(resource.Ignore(ec2.IsInternetGatewayNotFoundErr, err), errDelete)
 }
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1beta1.InternetGateway)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
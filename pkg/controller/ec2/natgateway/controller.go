@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package natgateway
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
+)
+
+const (
+	errUnexpectedObject = "The managed resource is not a NATGateway resource"
+	errDescribe         = "failed to describe NATGateway"
+	errNotSingleItem    = "either no or multiple NATGateways retrieved for the given natGatewayId"
+	errCreate           = "failed to create the NATGateway resource"
+	errDelete           = "failed to delete the NATGateway resource"
+	errSpecUpdate       = "cannot update spec of the NATGateway resource"
+	errStatusUpdate     = "cannot update status of the NATGateway resource"
+	errUpdateTags       = "failed to update tags for the NATGateway resource"
+)
+
+// SetupNATGateway adds a controller that reconciles NATGateways.
+func SetupNATGateway(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
+	name := managed.ControllerName(v1alpha4.NATGatewayGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha4.NATGateway{}, o.Selected()).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha4.NATGatewayGroupVersionKind),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: ec2.NewNATGatewayClient(cfg), kube: mgr.GetClient()}, nil
+			})),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(awsclients.NewTagger(mgr.GetClient(), specTags)),
+			managed.WithConnectionPublishers(),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type external struct {
+	kube   client.Client
+	client ec2.NATGatewayClient
+}
+
+func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
+	cr, ok := mgd.(*v1alpha4.NATGateway)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errUnexpectedObject)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	response, err := e.client.DescribeNatGatewaysRequest(&awsec2.DescribeNatGatewaysInput{
+		NatGatewayIds: []string{meta.GetExternalName(cr)},
+	}).Send(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(ec2.IsNATGatewayNotFoundErr, err), errDescribe)
+	}
+
+	// in a successful response, there should be one and only one object
+	if len(response.NatGateways) != 1 {
+		return managed.ExternalObservation{}, errors.New(errNotSingleItem)
+	}
+
+	observed := response.NatGateways[0]
+
+	// Deleted NAT gateways remain visible for a while after their deletion.
+	if observed.State == awsec2.NatGatewayStateDeleted {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	ec2.LateInitializeNATGateway(&cr.Spec.ForProvider, &observed)
+	if !cmp.Equal(current, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSpecUpdate)
+		}
+	}
+
+	switch observed.State {
+	case awsec2.NatGatewayStateAvailable:
+		cr.SetConditions(runtimev1alpha1.Available())
+	case awsec2.NatGatewayStatePending:
+		cr.SetConditions(runtimev1alpha1.Creating())
+	case awsec2.NatGatewayStateFailed:
+		cr.SetConditions(runtimev1alpha1.Unavailable().WithMessage(aws.StringValue(observed.FailureMessage)))
+	case awsec2.NatGatewayStateDeleting:
+		cr.SetConditions(runtimev1alpha1.Deleting())
+	}
+
+	cr.Status.AtProvider = ec2.GenerateNATGatewayObservation(observed)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: ec2.IsNATGatewayUpToDate(cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha4.NATGateway)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errUnexpectedObject)
+	}
+
+	cr.Status.SetConditions(runtimev1alpha1.Creating())
+	if err := e.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errStatusUpdate)
+	}
+
+	result, err := e.client.CreateNatGatewayRequest(ec2.GenerateCreateNATGatewayInput(cr.Spec.ForProvider)).Send(ctx)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	meta.SetExternalName(cr, aws.StringValue(result.NatGateway.NatGatewayId))
+
+	return managed.ExternalCreation{}, errors.Wrap(e.kube.Update(ctx, cr), errSpecUpdate)
+}
+
+func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha4.NATGateway)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
+	}
+
+	err := ec2.UpdateTags(ctx, e.client, meta.GetExternalName(cr), cr.Spec.ForProvider.Tags, cr.Status.AtProvider.Tags)
+
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
+}
+
+func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha4.NATGateway)
+	if !ok {
+		return errors.New(errUnexpectedObject)
+	}
+
+	cr.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	// NAT gateways that are already being deleted cannot be deleted again.
+	if cr.Status.AtProvider.State == string(awsec2.NatGatewayStateDeleting) {
+		return nil
+	}
+
+	_, err := e.client.DeleteNatGatewayRequest(&awsec2.DeleteNatGatewayInput{
+		NatGatewayId: aws.String(meta.GetExternalName(cr)),
+	}).Send(ctx)
+
+	return errors.Wrap(resource.Ignore(ec2.IsNATGatewayNotFoundErr, err), errDelete)
+}
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1alpha4.NATGateway)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
@@ -0,0 +1,508 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package natgateway
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2/fake"
+)
+
+const (
+	providerName = "aws-creds"
+)
+
+var (
+	natID        = "nat-1"
+	allocationID = "eipalloc-1"
+	subnetID     = "subnet-1"
+	failure      = "Network vpc-1 has no Internet gateway attached"
+
+	errBoom = errors.New("boom")
+)
+
+type args struct {
+	nat  ec2.NATGatewayClient
+	kube client.Client
+	cr   *v1alpha4.NATGateway
+}
+
+type natModifier func(*v1alpha4.NATGateway)
+
+func withExternalName(name string) natModifier {
+	return func(r *v1alpha4.NATGateway) { meta.SetExternalName(r, name) }
+}
+
+func withConditions(c ...runtimev1alpha1.Condition) natModifier {
+	return func(r *v1alpha4.NATGateway) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withSpec(p v1alpha4.NATGatewayParameters) natModifier {
+	return func(r *v1alpha4.NATGateway) { r.Spec.ForProvider = p }
+}
+
+func withStatus(s v1alpha4.NATGatewayObservation) natModifier {
+	return func(r *v1alpha4.NATGateway) { r.Status.AtProvider = s }
+}
+
+func nat(m ...natModifier) *v1alpha4.NATGateway {
+	cr := &v1alpha4.NATGateway{
+		Spec: v1alpha4.NATGatewaySpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &corev1.ObjectReference{Name: providerName},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func spec() v1alpha4.NATGatewayParameters {
+	return v1alpha4.NATGatewayParameters{
+		AllocationID: aws.String(allocationID),
+		SubnetID:     aws.String(subnetID),
+	}
+}
+
+func describe(gws ...awsec2.NatGateway) func(*awsec2.DescribeNatGatewaysInput) awsec2.DescribeNatGatewaysRequest {
+	return func(*awsec2.DescribeNatGatewaysInput) awsec2.DescribeNatGatewaysRequest {
+		return awsec2.DescribeNatGatewaysRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeNatGatewaysOutput{
+				NatGateways: gws,
+			}},
+		}
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha4.NATGateway
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"NoExternalName": {
+			reason: "A NATGateway without an external name should not exist.",
+			args:   args{cr: nat(withSpec(spec()))},
+			want: want{
+				cr: nat(withSpec(spec())),
+			},
+		},
+		"Available": {
+			reason: "An available NAT gateway should be observed as available and up to date.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDescribe: describe(awsec2.NatGateway{NatGatewayId: aws.String(natID), State: awsec2.NatGatewayStateAvailable}),
+				},
+				cr: nat(withSpec(spec()), withExternalName(natID)),
+			},
+			want: want{
+				cr: nat(withSpec(spec()), withExternalName(natID),
+					withStatus(v1alpha4.NATGatewayObservation{NATGatewayID: natID, State: string(awsec2.NatGatewayStateAvailable)}),
+					withConditions(runtimev1alpha1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"Pending": {
+			reason: "A pending NAT gateway should be observed as creating.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDescribe: describe(awsec2.NatGateway{NatGatewayId: aws.String(natID), State: awsec2.NatGatewayStatePending}),
+				},
+				cr: nat(withSpec(spec()), withExternalName(natID)),
+			},
+			want: want{
+				cr: nat(withSpec(spec()), withExternalName(natID),
+					withStatus(v1alpha4.NATGatewayObservation{NATGatewayID: natID, State: string(awsec2.NatGatewayStatePending)}),
+					withConditions(runtimev1alpha1.Creating())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"Failed": {
+			reason: "A failed NAT gateway should be observed as unavailable with its failure message.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDescribe: describe(awsec2.NatGateway{NatGatewayId: aws.String(natID), State: awsec2.NatGatewayStateFailed, FailureMessage: aws.String(failure)}),
+				},
+				cr: nat(withSpec(spec()), withExternalName(natID)),
+			},
+			want: want{
+				cr: nat(withSpec(spec()), withExternalName(natID),
+					withStatus(v1alpha4.NATGatewayObservation{NATGatewayID: natID, State: string(awsec2.NatGatewayStateFailed), FailureMessage: failure}),
+					withConditions(runtimev1alpha1.Unavailable().WithMessage(failure))),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"Deleted": {
+			reason: "A deleted NAT gateway should not exist.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDescribe: describe(awsec2.NatGateway{NatGatewayId: aws.String(natID), State: awsec2.NatGatewayStateDeleted}),
+				},
+				cr: nat(withSpec(spec()), withExternalName(natID)),
+			},
+			want: want{
+				cr: nat(withSpec(spec()), withExternalName(natID)),
+			},
+		},
+		"OutdatedTags": {
+			reason: "A NAT gateway without the desired tags should not be up to date.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDescribe: describe(awsec2.NatGateway{NatGatewayId: aws.String(natID), State: awsec2.NatGatewayStateAvailable}),
+				},
+				cr: nat(withSpec(v1alpha4.NATGatewayParameters{Tags: []v1beta1.Tag{{Key: "k", Value: "v"}}}), withExternalName(natID)),
+			},
+			want: want{
+				cr: nat(withSpec(v1alpha4.NATGatewayParameters{Tags: []v1beta1.Tag{{Key: "k", Value: "v"}}}), withExternalName(natID),
+					withStatus(v1alpha4.NATGatewayObservation{NATGatewayID: natID, State: string(awsec2.NatGatewayStateAvailable)}),
+					withConditions(runtimev1alpha1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+		"NotFound": {
+			reason: "A NAT gateway that is not found should not exist.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDescribe: func(input *awsec2.DescribeNatGatewaysInput) awsec2.DescribeNatGatewaysRequest {
+						return awsec2.DescribeNatGatewaysRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: awserr.New(ec2.NATGatewayNotFound, "", nil)},
+						}
+					},
+				},
+				cr: nat(withSpec(spec()), withExternalName(natID)),
+			},
+			want: want{
+				cr: nat(withSpec(spec()), withExternalName(natID)),
+			},
+		},
+		"MultipleNATGateways": {
+			reason: "An error should be returned if multiple NAT gateways are described.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDescribe: describe(awsec2.NatGateway{}, awsec2.NatGateway{}),
+				},
+				cr: nat(withSpec(spec()), withExternalName(natID)),
+			},
+			want: want{
+				cr:  nat(withSpec(spec()), withExternalName(natID)),
+				err: errors.New(errNotSingleItem),
+			},
+		},
+		"FailedRequest": {
+			reason: "Errors describing the NAT gateway should be returned.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDescribe: func(input *awsec2.DescribeNatGatewaysInput) awsec2.DescribeNatGatewaysRequest {
+						return awsec2.DescribeNatGatewaysRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: nat(withSpec(spec()), withExternalName(natID)),
+			},
+			want: want{
+				cr:  nat(withSpec(spec()), withExternalName(natID)),
+				err: errors.Wrap(errBoom, errDescribe),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.nat}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha4.NATGateway
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Successful": {
+			reason: "The external name should be set to the ID of the created NAT gateway.",
+			args: args{
+				kube: &test.MockClient{
+					MockUpdate:       test.NewMockClient().Update,
+					MockStatusUpdate: test.NewMockClient().MockStatusUpdate,
+				},
+				nat: &fake.MockNATGatewayClient{
+					MockCreate: func(input *awsec2.CreateNatGatewayInput) awsec2.CreateNatGatewayRequest {
+						return awsec2.CreateNatGatewayRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.CreateNatGatewayOutput{
+								NatGateway: &awsec2.NatGateway{NatGatewayId: aws.String(natID)},
+							}},
+						}
+					},
+				},
+				cr: nat(withSpec(spec())),
+			},
+			want: want{
+				cr: nat(withSpec(spec()), withExternalName(natID), withConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"FailedRequest": {
+			reason: "Errors creating the NAT gateway should be returned.",
+			args: args{
+				kube: &test.MockClient{
+					MockStatusUpdate: test.NewMockClient().MockStatusUpdate,
+				},
+				nat: &fake.MockNATGatewayClient{
+					MockCreate: func(input *awsec2.CreateNatGatewayInput) awsec2.CreateNatGatewayRequest {
+						return awsec2.CreateNatGatewayRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: nat(withSpec(spec())),
+			},
+			want: want{
+				cr:  nat(withSpec(spec()), withConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errBoom, errCreate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.nat}
+			o, err := e.Create(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha4.NATGateway
+		result managed.ExternalUpdate
+		err    error
+	}
+
+	tags := []v1beta1.Tag{{Key: "k", Value: "v"}}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Successful": {
+			reason: "Tags that are not observed should be created.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockCreateTags: func(input *awsec2.CreateTagsInput) awsec2.CreateTagsRequest {
+						return awsec2.CreateTagsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.CreateTagsOutput{}},
+						}
+					},
+				},
+				cr: nat(withSpec(v1alpha4.NATGatewayParameters{Tags: tags}), withExternalName(natID)),
+			},
+			want: want{
+				cr: nat(withSpec(v1alpha4.NATGatewayParameters{Tags: tags}), withExternalName(natID)),
+			},
+		},
+		"CreateTagsFail": {
+			reason: "Errors updating the tags should be returned.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockCreateTags: func(input *awsec2.CreateTagsInput) awsec2.CreateTagsRequest {
+						return awsec2.CreateTagsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: nat(withSpec(v1alpha4.NATGatewayParameters{Tags: tags}), withExternalName(natID)),
+			},
+			want: want{
+				cr:  nat(withSpec(v1alpha4.NATGatewayParameters{Tags: tags}), withExternalName(natID)),
+				err: errors.Wrap(errors.Wrap(errBoom, "cannot create tags"), errUpdateTags),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.nat}
+			o, err := e.Update(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		cr  *v1alpha4.NATGateway
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Successful": {
+			reason: "The NAT gateway should be deleted.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDelete: func(input *awsec2.DeleteNatGatewayInput) awsec2.DeleteNatGatewayRequest {
+						return awsec2.DeleteNatGatewayRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DeleteNatGatewayOutput{}},
+						}
+					},
+				},
+				cr: nat(withExternalName(natID)),
+			},
+			want: want{
+				cr: nat(withExternalName(natID), withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"AlreadyDeleting": {
+			reason: "A NAT gateway that is already being deleted should not be deleted again.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{},
+				cr:  nat(withExternalName(natID), withStatus(v1alpha4.NATGatewayObservation{State: string(awsec2.NatGatewayStateDeleting)})),
+			},
+			want: want{
+				cr: nat(withExternalName(natID), withStatus(v1alpha4.NATGatewayObservation{State: string(awsec2.NatGatewayStateDeleting)}),
+					withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"NotFound": {
+			reason: "NAT gateways that are not found should be considered deleted.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDelete: func(input *awsec2.DeleteNatGatewayInput) awsec2.DeleteNatGatewayRequest {
+						return awsec2.DeleteNatGatewayRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: awserr.New(ec2.NATGatewayNotFound, "", nil)},
+						}
+					},
+				},
+				cr: nat(withExternalName(natID)),
+			},
+			want: want{
+				cr: nat(withExternalName(natID), withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"DeleteFail": {
+			reason: "Errors deleting the NAT gateway should be returned.",
+			args: args{
+				nat: &fake.MockNATGatewayClient{
+					MockDelete: func(input *awsec2.DeleteNatGatewayInput) awsec2.DeleteNatGatewayRequest {
+						return awsec2.DeleteNatGatewayRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: nat(withExternalName(natID)),
+			},
+			want: want{
+				cr:  nat(withExternalName(natID), withConditions(runtimev1alpha1.Deleting())),
+				err: errors.Wrap(errBoom, errDelete),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.nat}
+			err := e.Delete(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
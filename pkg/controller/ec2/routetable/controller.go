@@ -23,8 +23,6 @@ import (
 	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -36,86 +34,51 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
-	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
 	errUnexpectedObject = "The managed resource is not an RouteTable resource"
 
-	errGetProvider       = "cannot get provider"
-	errGetProviderSecret = "cannot get provider secret"
-	errKubeUpdateFailed  = "cannot update Subnet custom resource"
+	errKubeUpdateFailed = "cannot update Subnet custom resource"
 
-	errClient             = "cannot create a new RouteTable client"
 	errDescribe           = "failed to describe RouteTable"
 	errMultipleItems      = "retrieved multiple RouteTables for the given routeTableId"
 	errCreate             = "failed to create the RouteTable resource"
-	errUpdate             = "failed to update the RouteTable"
 	errUpdateNotFound     = "cannot update the RouteTable, since the RouteTableID is not present"
 	errDelete             = "failed to delete the RouteTable resource"
 	errCreateRoute        = "failed to create a route in the RouteTable resource"
+	errReplaceRoute       = "failed to replace a route in the RouteTable resource"
+	errDeleteRoute        = "failed to delete a route from the RouteTable resource"
 	errAssociateSubnet    = "failed to associate subnet %v to the RouteTable resource"
 	errDisassociateSubnet = "failed to disassociate subnet %v from the RouteTable resource"
 	errSpecUpdate         = "cannot update spec of the RouteTable custom resource"
 	errStatusUpdate       = "cannot update status of the RouteTable custom resource"
-	errCreateTags         = "failed to create tags for the RouteTable resource"
+	errUpdateTags         = "failed to update tags for the RouteTable resource"
 )
 
 // SetupRouteTable adds a controller that reconciles RouteTables.
-func SetupRouteTable(mgr ctrl.Manager, l logging.Logger) error {
+func SetupRouteTable(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1alpha4.RouteTableGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha4.RouteTable{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha4.RouteTable{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha4.RouteTableGroupVersionKind),
-			managed.WithExternalConnecter(&connector{client: mgr.GetClient(), newClientFn: ec2.NewRouteTableClient}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: ec2.NewRouteTableClient(cfg), kube: mgr.GetClient()}, nil
+			})),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithInitializers(),
+			managed.WithInitializers(awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithConnectionPublishers(),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
-type connector struct {
-	client      client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (ec2.RouteTableClient, error)
-}
-
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1alpha4.RouteTable)
-	if !ok {
-		return nil, errors.New(errUnexpectedObject)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := c.client.Get(ctx, meta.NamespacedNameOf(cr.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if aws.BoolValue(p.Spec.UseServiceAccount) {
-		rtClient, err := c.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{client: rtClient, kube: c.client}, errors.Wrap(err, errUnexpectedObject)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := c.client.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-
-	rtClient, err := c.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{client: rtClient, kube: c.client}, errors.Wrap(err, errClient)
-}
-
 type external struct {
 	kube   client.Client
 	client ec2.RouteTableClient
@@ -210,7 +173,7 @@ func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.Ex
 	return managed.ExternalCreation{}, errors.Wrap(e.kube.Update(ctx, cr), errSpecUpdate)
 }
 
-func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) { // nolint:gocyclo
+func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
 	cr, ok := mgd.(*v1alpha4.RouteTable)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
@@ -230,36 +193,20 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 
 	table := response.RouteTables[0]
 
-	patch, err := ec2.CreateRTPatch(table, cr.Spec.ForProvider)
-	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	if err := ec2.UpdateTags(ctx, e.client, meta.GetExternalName(cr), cr.Spec.ForProvider.Tags, cr.Status.AtProvider.Tags); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
 	}
 
-	if len(patch.Tags) != 0 {
-		// tagging the RouteTable
-		if _, err := e.client.CreateTagsRequest(&awsec2.CreateTagsInput{
-			Resources: []string{meta.GetExternalName(cr)},
-			Tags:      v1beta1.GenerateEC2Tags(cr.Spec.ForProvider.Tags),
-		}).Send(ctx); err != nil {
-			return managed.ExternalUpdate{}, errors.Wrap(err, errCreateTags)
-		}
-	}
-
-	if patch.Routes != nil {
-		// Attach the routes in Spec
-		if err := e.createRoutes(ctx, meta.GetExternalName(cr), cr.Spec.ForProvider.Routes, cr.Status.AtProvider.Routes); err != nil {
-			return managed.ExternalUpdate{}, err
-		}
+	if err := e.updateRoutes(ctx, meta.GetExternalName(cr), ec2.DiffRoutes(cr.Spec.ForProvider.Routes, table.Routes)); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
-	if patch.Associations != nil {
-		// Associate route table to subnets in Spec.
-		if err := e.createAssociations(ctx, meta.GetExternalName(cr), cr.Spec.ForProvider.Associations, cr.Status.AtProvider.Associations); err != nil {
-			return managed.ExternalUpdate{}, err
-		}
+	associate, disassociate := ec2.DiffAssociations(cr.Spec.ForProvider.Associations, table.Associations)
+	if err := e.createAssociations(ctx, meta.GetExternalName(cr), associate); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
-	return managed.ExternalUpdate{}, nil
+	return managed.ExternalUpdate{}, e.deleteAssociations(ctx, disassociate)
 }
 
 func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
@@ -271,7 +218,11 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 	cr.Status.SetConditions(runtimev1alpha1.Deleting())
 
 	// the subnet associations have to be deleted before deleting the route table.
-	if err := e.deleteAssociations(ctx, cr.Status.AtProvider.Associations); err != nil {
+	ids := make([]string, 0, len(cr.Status.AtProvider.Associations))
+	for _, asc := range cr.Status.AtProvider.Associations {
+		ids = append(ids, asc.AssociationID)
+	}
+	if err := e.deleteAssociations(ctx, ids); err != nil {
 		return err
 	}
 
@@ -282,70 +233,77 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 	return errors.Wrap(resource.Ignore(ec2.IsRouteTableNotFoundErr, err), errDelete)
 }
 
-func (e *external) createRoutes(ctx context.Context, tableID string, desired []v1alpha4.Route, observed []v1alpha4.RouteState) error {
-	for _, rt := range desired {
-		isObserved := false
-		for _, ob := range observed {
-			if ob.GatewayID == aws.StringValue(rt.GatewayID) && ob.DestinationCIDRBlock == aws.StringValue(rt.DestinationCIDRBlock) {
-				isObserved = true
-				break
-			}
+// updateRoutes applies the supplied diff to the routes of the route table
+// with the supplied ID. Routes are created and replaced before they are
+// deleted.
+func (e *external) updateRoutes(ctx context.Context, tableID string, d ec2.RoutesDiff) error {
+	for _, rt := range d.Create {
+		_, err := e.client.CreateRouteRequest(ec2.GenerateCreateRouteInput(tableID, rt)).Send(ctx)
+
+		if err != nil {
+			return errors.Wrap(err, errCreateRoute)
 		}
-		// if the route is already created, skip it
-		if !isObserved {
-			_, err := e.client.CreateRouteRequest(&awsec2.CreateRouteInput{
-				RouteTableId:         aws.String(tableID),
-				DestinationCidrBlock: rt.DestinationCIDRBlock,
-				GatewayId:            rt.GatewayID,
-			}).Send(ctx)
-
-			if err != nil {
-				return errors.Wrap(err, errCreateRoute)
-			}
+	}
+
+	for _, rt := range d.Replace {
+		_, err := e.client.ReplaceRouteRequest(ec2.GenerateReplaceRouteInput(tableID, rt)).Send(ctx)
+
+		if err != nil {
+			return errors.Wrap(err, errReplaceRoute)
+		}
+	}
+
+	for _, rt := range d.Delete {
+		_, err := e.client.DeleteRouteRequest(&awsec2.DeleteRouteInput{
+			RouteTableId:             aws.String(tableID),
+			DestinationCidrBlock:     rt.DestinationCidrBlock,
+			DestinationIpv6CidrBlock: rt.DestinationIpv6CidrBlock,
+		}).Send(ctx)
+
+		if err != nil && !ec2.IsRouteNotFoundErr(err) {
+			return errors.Wrap(err, errDeleteRoute)
 		}
 	}
 
 	return nil
 }
 
-func (e *external) createAssociations(ctx context.Context, tableID string, desired []v1alpha4.Association, observed []v1alpha4.AssociationState) error {
-	for _, asc := range desired {
-		isObserved := false
-		for _, ob := range observed {
-			if ob.SubnetID == aws.StringValue(asc.SubnetID) {
-				isObserved = true
-				break
-			}
-		}
-		// if the association is already created, skip it
-		if !isObserved {
-			_, err := e.client.AssociateRouteTableRequest(&awsec2.AssociateRouteTableInput{
-				RouteTableId: aws.String(tableID),
-				SubnetId:     asc.SubnetID,
-			}).Send(ctx)
-
-			if err != nil {
-				return errors.Wrap(err, errAssociateSubnet)
-			}
+func (e *external) createAssociations(ctx context.Context, tableID string, subnetIDs []string) error {
+	for _, id := range subnetIDs {
+		_, err := e.client.AssociateRouteTableRequest(&awsec2.AssociateRouteTableInput{
+			RouteTableId: aws.String(tableID),
+			SubnetId:     aws.String(id),
+		}).Send(ctx)
+
+		if err != nil {
+			return errors.Wrapf(err, errAssociateSubnet, id)
 		}
 	}
 
 	return nil
 }
 
-func (e *external) deleteAssociations(ctx context.Context, observed []v1alpha4.AssociationState) error {
-	for _, asc := range observed {
+func (e *external) deleteAssociations(ctx context.Context, associationIDs []string) error {
+	for _, id := range associationIDs {
 		req := e.client.DisassociateRouteTableRequest(&awsec2.DisassociateRouteTableInput{
-			AssociationId: aws.String(asc.AssociationID),
+			AssociationId: aws.String(id),
 		})
 
 		if _, err := req.Send(ctx); err != nil {
 			if ec2.IsAssociationIDNotFoundErr(err) {
 				continue
 			}
-			return errors.Wrap(err, errDisassociateSubnet)
+			return errors.Wrapf(err, errDisassociateSubnet, id)
 		}
 	}
 
 	return nil
 }
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1alpha4.RouteTable)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
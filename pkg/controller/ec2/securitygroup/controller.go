@@ -23,8 +23,6 @@ import (
 	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -36,86 +34,56 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
 	errUnexpectedObject = "The managed resource is not an SecurityGroup resource"
 	errKubeUpdateFailed = "cannot update Security Group instance custom resource"
 
-	errCreateClient      = "cannot create Security Group client"
-	errGetProvider       = "cannot get provider"
-	errGetProviderSecret = "cannot get provider secret"
-
 	errDescribe         = "failed to describe SecurityGroup"
 	errMultipleItems    = "retrieved multiple SecurityGroups for the given securityGroupId"
 	errCreate           = "failed to create the SecurityGroup resource"
 	errAuthorizeIngress = "failed to authorize ingress rules"
 	errAuthorizeEgress  = "failed to authorize egress rules"
+	errRevokeIngress    = "failed to revoke ingress rules"
+	errRevokeEgress     = "failed to revoke egress rules"
+	errDescribeIngress  = "failed to update descriptions of ingress rules"
+	errDescribeEgress   = "failed to update descriptions of egress rules"
 	errDelete           = "failed to delete the SecurityGroup resource"
 	errSpecUpdate       = "cannot update spec of the SecurityGroup custom resource"
 	errStatusUpdate     = "cannot update status of the SecurityGroup custom resource"
-	errUpdate           = "failed to update the SecurityGroup resource"
-	errCreateTags       = "failed to create tags for the Security Group resource"
+	errUpdateTags       = "failed to update tags for the Security Group resource"
+	errPeerGroupName    = "rules of a security group in a VPC must identify peer security groups by groupId rather than groupName"
 )
 
 // SetupSecurityGroup adds a controller that reconciles SecurityGroups.
-func SetupSecurityGroup(mgr ctrl.Manager, l logging.Logger) error {
+func SetupSecurityGroup(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1beta1.SecurityGroupGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1beta1.SecurityGroup{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.SecurityGroup{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.SecurityGroupGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: ec2.NewSecurityGroupClient}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{sg: ec2.NewSecurityGroupClient(cfg), kube: mgr.GetClient(), recorder: event.NewAPIRecorder(mgr.GetEventRecorderFor(name))}, nil
+			})),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithInitializers(),
+			managed.WithInitializers(awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithConnectionPublishers(),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
-type connector struct {
-	kube        client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (ec2.SecurityGroupClient, error)
-}
-
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1beta1.SecurityGroup)
-	if !ok {
-		return nil, errors.New(errUnexpectedObject)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := c.kube.Get(ctx, meta.NamespacedNameOf(cr.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if aws.BoolValue(p.Spec.UseServiceAccount) {
-		sgClient, err := c.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{sg: sgClient, kube: c.kube}, errors.Wrap(err, errCreateClient)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := c.kube.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-
-	sgClient, err := c.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{sg: sgClient, kube: c.kube}, errors.Wrap(err, errCreateClient)
-}
-
 type external struct {
-	sg   ec2.SecurityGroupClient
-	kube client.Client
+	sg       ec2.SecurityGroupClient
+	kube     client.Client
+	recorder event.Recorder
 }
 
 func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
@@ -157,6 +125,14 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 		return managed.ExternalObservation{}, errors.Wrap(err, errDescribe)
 	}
 
+	var drift []v1alpha3.FieldDrift
+	if !upToDate {
+		if drift, err = ec2.DiffSG(cr.Spec.ForProvider, observed); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errDescribe)
+		}
+	}
+	awsclients.ReportDrift(e.recorder, cr, &cr.Status, drift)
+
 	// this is to make sure that the security group exists with the specified traffic rules.
 	if upToDate {
 		cr.SetConditions(runtimev1alpha1.Available())
@@ -199,7 +175,7 @@ func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.Ex
 	return managed.ExternalCreation{}, errors.Wrap(e.kube.Update(ctx, cr), errSpecUpdate)
 }
 
-func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) { // nolint:gocyclo
+func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
 	cr, ok := mgd.(*v1beta1.SecurityGroup)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
@@ -212,39 +188,92 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 		return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(ec2.IsSecurityGroupNotFoundErr, err), errDescribe)
 	}
 
-	patch, err := ec2.CreateSGPatch(response.SecurityGroups[0], cr.Spec.ForProvider)
-	if err != nil {
-		return managed.ExternalUpdate{}, errors.New(errUpdate)
+	observed := response.SecurityGroups[0]
+
+	// Rules that identify a peer security group by name would never match
+	// the observed rules, so they would be authorized and revoked forever.
+	p := cr.Spec.ForProvider
+	if observed.VpcId != nil && (ec2.HasPeerGroupNames(p.Ingress) || ec2.HasPeerGroupNames(p.Egress)) {
+		return managed.ExternalUpdate{}, errors.New(errPeerGroupName)
 	}
 
-	if len(patch.Tags) != 0 {
-		if _, err := e.sg.CreateTagsRequest(&awsec2.CreateTagsInput{
-			Resources: []string{meta.GetExternalName(cr)},
-			Tags:      v1beta1.GenerateEC2Tags(cr.Spec.ForProvider.Tags),
-		}).Send(ctx); err != nil {
-			return managed.ExternalUpdate{}, errors.Wrap(err, errCreateTags)
-		}
+	if err := ec2.UpdateTags(ctx, e.sg, meta.GetExternalName(cr), cr.Spec.ForProvider.Tags, cr.Status.AtProvider.Tags); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
 	}
 
-	if patch.Ingress != nil {
+	if err := e.updateIngress(ctx, meta.GetExternalName(cr), ec2.DiffIngress(cr.Spec.ForProvider, observed)); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{}, e.updateEgress(ctx, meta.GetExternalName(cr), ec2.DiffEgress(cr.Spec.ForProvider, observed))
+}
+
+// updateIngress applies the supplied diff to the ingress rules of the
+// security group with the supplied ID. Missing rules are authorized before
+// extra rules are revoked, so that traffic allowed both before and after the
+// update is never interrupted.
+func (e *external) updateIngress(ctx context.Context, id string, d ec2.PermissionsDiff) error {
+	if len(d.Authorize) != 0 {
 		if _, err := e.sg.AuthorizeSecurityGroupIngressRequest(&awsec2.AuthorizeSecurityGroupIngressInput{
-			GroupId:       aws.String(meta.GetExternalName(cr)),
-			IpPermissions: v1beta1.BuildEC2Permissions(cr.Spec.ForProvider.Ingress),
+			GroupId:       aws.String(id),
+			IpPermissions: d.Authorize,
 		}).Send(ctx); err != nil && !ec2.IsRuleAlreadyExistsErr(err) {
-			return managed.ExternalUpdate{}, errors.Wrap(err, errAuthorizeIngress)
+			return errors.Wrap(err, errAuthorizeIngress)
 		}
 	}
 
-	if patch.Egress != nil {
-		if _, err = e.sg.AuthorizeSecurityGroupEgressRequest(&awsec2.AuthorizeSecurityGroupEgressInput{
-			GroupId:       aws.String(meta.GetExternalName(cr)),
-			IpPermissions: v1beta1.BuildEC2Permissions(cr.Spec.ForProvider.Egress),
+	if len(d.Describe) != 0 {
+		if _, err := e.sg.UpdateSecurityGroupRuleDescriptionsIngressRequest(&awsec2.UpdateSecurityGroupRuleDescriptionsIngressInput{
+			GroupId:       aws.String(id),
+			IpPermissions: d.Describe,
+		}).Send(ctx); err != nil {
+			return errors.Wrap(err, errDescribeIngress)
+		}
+	}
+
+	if len(d.Revoke) != 0 {
+		if _, err := e.sg.RevokeSecurityGroupIngressRequest(&awsec2.RevokeSecurityGroupIngressInput{
+			GroupId:       aws.String(id),
+			IpPermissions: d.Revoke,
+		}).Send(ctx); err != nil && !ec2.IsRuleNotFoundErr(err) {
+			return errors.Wrap(err, errRevokeIngress)
+		}
+	}
+
+	return nil
+}
+
+// updateEgress applies the supplied diff to the egress rules of the security
+// group with the supplied ID, in the same order as updateIngress.
+func (e *external) updateEgress(ctx context.Context, id string, d ec2.PermissionsDiff) error {
+	if len(d.Authorize) != 0 {
+		if _, err := e.sg.AuthorizeSecurityGroupEgressRequest(&awsec2.AuthorizeSecurityGroupEgressInput{
+			GroupId:       aws.String(id),
+			IpPermissions: d.Authorize,
 		}).Send(ctx); err != nil && !ec2.IsRuleAlreadyExistsErr(err) {
-			return managed.ExternalUpdate{}, errors.Wrap(err, errAuthorizeEgress)
+			return errors.Wrap(err, errAuthorizeEgress)
 		}
 	}
 
-	return managed.ExternalUpdate{}, nil
+	if len(d.Describe) != 0 {
+		if _, err := e.sg.UpdateSecurityGroupRuleDescriptionsEgressRequest(&awsec2.UpdateSecurityGroupRuleDescriptionsEgressInput{
+			GroupId:       aws.String(id),
+			IpPermissions: d.Describe,
+		}).Send(ctx); err != nil {
+			return errors.Wrap(err, errDescribeEgress)
+		}
+	}
+
+	if len(d.Revoke) != 0 {
+		if _, err := e.sg.RevokeSecurityGroupEgressRequest(&awsec2.RevokeSecurityGroupEgressInput{
+			GroupId:       aws.String(id),
+			IpPermissions: d.Revoke,
+		}).Send(ctx); err != nil && !ec2.IsRuleNotFoundErr(err) {
+			return errors.Wrap(err, errRevokeEgress)
+		}
+	}
+
+	return nil
 }
 
 func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
@@ -261,3 +290,11 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 
 	return errors.Wrap(resource.Ignore(ec2.IsSecurityGroupNotFoundErr, err), errDelete)
 }
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1beta1.SecurityGroup)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
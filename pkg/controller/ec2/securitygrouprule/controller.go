@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygrouprule
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
+)
+
+const (
+	errUnexpectedObject = "The managed resource is not a SecurityGroupRule resource"
+
+	errDescribe        = "failed to describe the SecurityGroup of the SecurityGroupRule"
+	errMultipleItems   = "retrieved multiple SecurityGroups for the given securityGroupId"
+	errAuthorize       = "failed to authorize the SecurityGroupRule"
+	errUpdate          = "failed to update the description of the SecurityGroupRule"
+	errRevoke          = "failed to revoke the SecurityGroupRule"
+	errUnsupportedType = "unsupported SecurityGroupRule type"
+	errSource          = "exactly one of cidrIp, cidrIpv6, prefixListId or sourceSecurityGroupId must be set"
+)
+
+// SetupSecurityGroupRule adds a controller that reconciles SecurityGroupRules.
+func SetupSecurityGroupRule(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
+	name := managed.ControllerName(v1alpha4.SecurityGroupRuleGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha4.SecurityGroupRule{}, o.Selected()).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha4.SecurityGroupRuleGroupVersionKind),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{sg: ec2.NewSecurityGroupClient(cfg)}, nil
+			})),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(),
+			managed.WithConnectionPublishers(),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type external struct {
+	sg ec2.SecurityGroupClient
+}
+
+func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha4.SecurityGroupRule)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errUnexpectedObject)
+	}
+
+	if !hasSingleSource(cr.Spec.ForProvider) {
+		// A rule without a single source can't have been authorized, so
+		// there is nothing to revoke when it is deleted.
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{}, nil
+		}
+		return managed.ExternalObservation{}, errors.New(errSource)
+	}
+
+	response, err := e.sg.DescribeSecurityGroupsRequest(&awsec2.DescribeSecurityGroupsInput{
+		GroupIds: []string{aws.StringValue(cr.Spec.ForProvider.SecurityGroupID)},
+	}).Send(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(ec2.IsSecurityGroupNotFoundErr, err), errDescribe)
+	}
+
+	// in a successful response, there should be one and only one object
+	if len(response.SecurityGroups) != 1 {
+		return managed.ExternalObservation{}, errors.New(errMultipleItems)
+	}
+
+	perms := response.SecurityGroups[0].IpPermissions
+	if cr.Spec.ForProvider.Type == v1alpha4.SecurityGroupRuleTypeEgress {
+		perms = response.SecurityGroups[0].IpPermissionsEgress
+	}
+
+	rule, ok := ec2.FindSGRule(perms, cr.Spec.ForProvider)
+	if !ok {
+		return managed.ExternalObservation{}, nil
+	}
+
+	cr.SetConditions(runtimev1alpha1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: ec2.IsSGRuleUpToDate(cr.Spec.ForProvider, rule),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha4.SecurityGroupRule)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errUnexpectedObject)
+	}
+
+	cr.SetConditions(runtimev1alpha1.Creating())
+
+	id := cr.Spec.ForProvider.SecurityGroupID
+	perms := []awsec2.IpPermission{ec2.GenerateSGRulePermission(cr.Spec.ForProvider)}
+
+	var err error
+	switch cr.Spec.ForProvider.Type {
+	case v1alpha4.SecurityGroupRuleTypeIngress:
+		_, err = e.sg.AuthorizeSecurityGroupIngressRequest(&awsec2.AuthorizeSecurityGroupIngressInput{GroupId: id, IpPermissions: perms}).Send(ctx)
+	case v1alpha4.SecurityGroupRuleTypeEgress:
+		_, err = e.sg.AuthorizeSecurityGroupEgressRequest(&awsec2.AuthorizeSecurityGroupEgressInput{GroupId: id, IpPermissions: perms}).Send(ctx)
+	default:
+		return managed.ExternalCreation{}, errors.New(errUnsupportedType)
+	}
+
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(ec2.IsRuleAlreadyExistsErr, err), errAuthorize)
+}
+
+func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha4.SecurityGroupRule)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
+	}
+
+	id := cr.Spec.ForProvider.SecurityGroupID
+	perms := []awsec2.IpPermission{ec2.GenerateSGRulePermission(cr.Spec.ForProvider)}
+
+	var err error
+	switch cr.Spec.ForProvider.Type {
+	case v1alpha4.SecurityGroupRuleTypeIngress:
+		_, err = e.sg.UpdateSecurityGroupRuleDescriptionsIngressRequest(&awsec2.UpdateSecurityGroupRuleDescriptionsIngressInput{GroupId: id, IpPermissions: perms}).Send(ctx)
+	case v1alpha4.SecurityGroupRuleTypeEgress:
+		_, err = e.sg.UpdateSecurityGroupRuleDescriptionsEgressRequest(&awsec2.UpdateSecurityGroupRuleDescriptionsEgressInput{GroupId: id, IpPermissions: perms}).Send(ctx)
+	default:
+		return managed.ExternalUpdate{}, errors.New(errUnsupportedType)
+	}
+
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha4.SecurityGroupRule)
+	if !ok {
+		return errors.New(errUnexpectedObject)
+	}
+
+	cr.SetConditions(runtimev1alpha1.Deleting())
+
+	id := cr.Spec.ForProvider.SecurityGroupID
+	perms := []awsec2.IpPermission{ec2.GenerateSGRulePermission(cr.Spec.ForProvider)}
+
+	var err error
+	switch cr.Spec.ForProvider.Type {
+	case v1alpha4.SecurityGroupRuleTypeIngress:
+		_, err = e.sg.RevokeSecurityGroupIngressRequest(&awsec2.RevokeSecurityGroupIngressInput{GroupId: id, IpPermissions: perms}).Send(ctx)
+	case v1alpha4.SecurityGroupRuleTypeEgress:
+		_, err = e.sg.RevokeSecurityGroupEgressRequest(&awsec2.RevokeSecurityGroupEgressInput{GroupId: id, IpPermissions: perms}).Send(ctx)
+	default:
+		return errors.New(errUnsupportedType)
+	}
+
+	err = resource.Ignore(ec2.IsRuleNotFoundErr, err)
+	return errors.Wrap(resource.Ignore(ec2.IsSecurityGroupNotFoundErr, err), errRevoke)
+}
+
+// hasSingleSource returns true if the supplied parameters allow traffic from
+// (or to) exactly one source.
+func hasSingleSource(p v1alpha4.SecurityGroupRuleParameters) bool {
+	n := 0
+	for _, src := range []*string{p.CIDRIP, p.CIDRIPv6, p.PrefixListID, p.SourceSecurityGroupID} {
+		if src != nil {
+			n++
+		}
+	}
+	return n == 1
+}
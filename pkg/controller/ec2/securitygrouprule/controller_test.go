@@ -0,0 +1,450 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygrouprule
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2/fake"
+)
+
+const (
+	providerName = "aws-creds"
+)
+
+var (
+	sgID        = "some sgID"
+	cidr        = "192.168.0.0/32"
+	description = "some description"
+	deletedAt   = metav1.Unix(1, 0)
+
+	errBoom = errors.New("boom")
+)
+
+type args struct {
+	sg ec2.SecurityGroupClient
+	cr *v1alpha4.SecurityGroupRule
+}
+
+type ruleModifier func(*v1alpha4.SecurityGroupRule)
+
+func withType(t string) ruleModifier {
+	return func(r *v1alpha4.SecurityGroupRule) { r.Spec.ForProvider.Type = t }
+}
+
+func withDescription(d string) ruleModifier {
+	return func(r *v1alpha4.SecurityGroupRule) { r.Spec.ForProvider.Description = aws.String(d) }
+}
+
+func withSource(cidrIP, sourceSecurityGroupID *string) ruleModifier {
+	return func(r *v1alpha4.SecurityGroupRule) {
+		r.Spec.ForProvider.CIDRIP = cidrIP
+		r.Spec.ForProvider.SourceSecurityGroupID = sourceSecurityGroupID
+	}
+}
+
+func withDeletionTimestamp() ruleModifier {
+	return func(r *v1alpha4.SecurityGroupRule) { r.SetDeletionTimestamp(&deletedAt) }
+}
+
+func withConditions(c ...runtimev1alpha1.Condition) ruleModifier {
+	return func(r *v1alpha4.SecurityGroupRule) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func rule(m ...ruleModifier) *v1alpha4.SecurityGroupRule {
+	cr := &v1alpha4.SecurityGroupRule{
+		Spec: v1alpha4.SecurityGroupRuleSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &corev1.ObjectReference{Name: providerName},
+			},
+			ForProvider: v1alpha4.SecurityGroupRuleParameters{
+				Type:            v1alpha4.SecurityGroupRuleTypeIngress,
+				SecurityGroupID: aws.String(sgID),
+				IPProtocol:      "tcp",
+				FromPort:        aws.Int64(80),
+				ToPort:          aws.Int64(80),
+				CIDRIP:          aws.String(cidr),
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func permissions(port int64, d *string) []awsec2.IpPermission {
+	return []awsec2.IpPermission{{
+		FromPort:   aws.Int64(port),
+		ToPort:     aws.Int64(port),
+		IpProtocol: aws.String("tcp"),
+		IpRanges:   []awsec2.IpRange{{CidrIp: aws.String(cidr), Description: d}},
+	}}
+}
+
+func describe(sg awsec2.SecurityGroup) func(*awsec2.DescribeSecurityGroupsInput) awsec2.DescribeSecurityGroupsRequest {
+	return func(*awsec2.DescribeSecurityGroupsInput) awsec2.DescribeSecurityGroupsRequest {
+		return awsec2.DescribeSecurityGroupsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeSecurityGroupsOutput{
+				SecurityGroups: []awsec2.SecurityGroup{sg},
+			}},
+		}
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha4.SecurityGroupRule
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SuccessfulAvailable": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockDescribe: describe(awsec2.SecurityGroup{IpPermissions: permissions(80, nil)}),
+				},
+				cr: rule(),
+			},
+			want: want{
+				cr: rule(withConditions(runtimev1alpha1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"DifferentDescription": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockDescribe: describe(awsec2.SecurityGroup{IpPermissions: permissions(80, nil)}),
+				},
+				cr: rule(withDescription(description)),
+			},
+			want: want{
+				cr: rule(withDescription(description), withConditions(runtimev1alpha1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"NotFound": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockDescribe: describe(awsec2.SecurityGroup{
+						IpPermissions:       permissions(100, nil),
+						IpPermissionsEgress: permissions(80, nil),
+					}),
+				},
+				cr: rule(),
+			},
+			want: want{
+				cr: rule(),
+			},
+		},
+		"SecurityGroupNotFound": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockDescribe: func(*awsec2.DescribeSecurityGroupsInput) awsec2.DescribeSecurityGroupsRequest {
+						return awsec2.DescribeSecurityGroupsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: awserr.New(ec2.InvalidGroupNotFound, "", nil)},
+						}
+					},
+				},
+				cr: rule(),
+			},
+			want: want{
+				cr: rule(),
+			},
+		},
+		"NoSource": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{},
+				cr: rule(withSource(nil, nil)),
+			},
+			want: want{
+				cr:  rule(withSource(nil, nil)),
+				err: errors.New(errSource),
+			},
+		},
+		"MultipleSources": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{},
+				cr: rule(withSource(aws.String(cidr), aws.String(sgID))),
+			},
+			want: want{
+				cr:  rule(withSource(aws.String(cidr), aws.String(sgID))),
+				err: errors.New(errSource),
+			},
+		},
+		"DeletedWithoutSource": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{},
+				cr: rule(withSource(nil, nil), withDeletionTimestamp()),
+			},
+			want: want{
+				cr: rule(withSource(nil, nil), withDeletionTimestamp()),
+			},
+		},
+		"DescribeFailure": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockDescribe: func(*awsec2.DescribeSecurityGroupsInput) awsec2.DescribeSecurityGroupsRequest {
+						return awsec2.DescribeSecurityGroupsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: rule(),
+			},
+			want: want{
+				cr:  rule(),
+				err: errors.Wrap(errBoom, errDescribe),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{sg: tc.sg}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr  *v1alpha4.SecurityGroupRule
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SuccessfulIngress": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockAuthorizeIgress: func(input *awsec2.AuthorizeSecurityGroupIngressInput) awsec2.AuthorizeSecurityGroupIngressRequest {
+						return awsec2.AuthorizeSecurityGroupIngressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.AuthorizeSecurityGroupIngressOutput{}},
+						}
+					},
+				},
+				cr: rule(),
+			},
+			want: want{
+				cr: rule(withConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"AlreadyExists": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockAuthorizeEgress: func(input *awsec2.AuthorizeSecurityGroupEgressInput) awsec2.AuthorizeSecurityGroupEgressRequest {
+						return awsec2.AuthorizeSecurityGroupEgressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: awserr.New(ec2.InvalidPermissionDuplicate, "", nil)},
+						}
+					},
+				},
+				cr: rule(withType(v1alpha4.SecurityGroupRuleTypeEgress)),
+			},
+			want: want{
+				cr: rule(withType(v1alpha4.SecurityGroupRuleTypeEgress), withConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"AuthorizeFailure": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockAuthorizeIgress: func(input *awsec2.AuthorizeSecurityGroupIngressInput) awsec2.AuthorizeSecurityGroupIngressRequest {
+						return awsec2.AuthorizeSecurityGroupIngressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: rule(),
+			},
+			want: want{
+				cr:  rule(withConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errBoom, errAuthorize),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{sg: tc.sg}
+			_, err := e.Create(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		args
+		want error
+	}{
+		"Successful": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockDescribeIngress: func(input *awsec2.UpdateSecurityGroupRuleDescriptionsIngressInput) awsec2.UpdateSecurityGroupRuleDescriptionsIngressRequest {
+						return awsec2.UpdateSecurityGroupRuleDescriptionsIngressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.UpdateSecurityGroupRuleDescriptionsIngressOutput{}},
+						}
+					},
+				},
+				cr: rule(withDescription(description)),
+			},
+		},
+		"UpdateFailure": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockDescribeEgress: func(input *awsec2.UpdateSecurityGroupRuleDescriptionsEgressInput) awsec2.UpdateSecurityGroupRuleDescriptionsEgressRequest {
+						return awsec2.UpdateSecurityGroupRuleDescriptionsEgressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: rule(withType(v1alpha4.SecurityGroupRuleTypeEgress), withDescription(description)),
+			},
+			want: errors.Wrap(errBoom, errUpdate),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{sg: tc.sg}
+			_, err := e.Update(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		cr  *v1alpha4.SecurityGroupRule
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockRevokeIngress: func(input *awsec2.RevokeSecurityGroupIngressInput) awsec2.RevokeSecurityGroupIngressRequest {
+						return awsec2.RevokeSecurityGroupIngressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.RevokeSecurityGroupIngressOutput{}},
+						}
+					},
+				},
+				cr: rule(),
+			},
+			want: want{
+				cr: rule(withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"AlreadyRevoked": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockRevokeEgress: func(input *awsec2.RevokeSecurityGroupEgressInput) awsec2.RevokeSecurityGroupEgressRequest {
+						return awsec2.RevokeSecurityGroupEgressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: awserr.New(ec2.InvalidPermissionNotFound, "", nil)},
+						}
+					},
+				},
+				cr: rule(withType(v1alpha4.SecurityGroupRuleTypeEgress)),
+			},
+			want: want{
+				cr: rule(withType(v1alpha4.SecurityGroupRuleTypeEgress), withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"RevokeFailure": {
+			args: args{
+				sg: &fake.MockSecurityGroupClient{
+					MockRevokeIngress: func(input *awsec2.RevokeSecurityGroupIngressInput) awsec2.RevokeSecurityGroupIngressRequest {
+						return awsec2.RevokeSecurityGroupIngressRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: rule(),
+			},
+			want: want{
+				cr:  rule(withConditions(runtimev1alpha1.Deleting())),
+				err: errors.Wrap(errBoom, errRevoke),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{sg: tc.sg}
+			err := e.Delete(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -23,8 +23,6 @@ import (
 	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -36,19 +34,15 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
 	errUnexpectedObject = "The managed resource is not an Subnet resource"
 	errKubeUpdateFailed = "cannot update Subnet custom resource"
 
-	errCreateSubnetClient = "cannot create Subnet client"
-	errGetProvider        = "cannot get provider"
-	errGetProviderSecret  = "cannot get provider secret"
-
 	errDescribe      = "failed to describe Subnet"
 	errMultipleItems = "retrieved multiple Subnets"
 	errCreate        = "failed to create the Subnet resource"
@@ -56,60 +50,28 @@ const (
 	errUpdate        = "failed to update the Subnet resource"
 	errSpecUpdate    = "cannot update spec of the Subnet custom resource"
 	errStatusUpdate  = "cannot update status of the Subnet custom resource"
-	errCreateTags    = "failed to create tags for the Subnet resource"
+	errUpdateTags    = "failed to update tags for the Subnet resource"
 )
 
 // SetupSubnet adds a controller that reconciles Subnets.
-func SetupSubnet(mgr ctrl.Manager, l logging.Logger) error {
+func SetupSubnet(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1beta1.SubnetGroupKind)
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1beta1.Subnet{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.Subnet{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.SubnetGroupVersionKind),
-			managed.WithExternalConnecter(&connector{client: mgr.GetClient(), newClientFn: ec2.NewSubnetClient}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: ec2.NewSubnetClient(cfg), kube: mgr.GetClient()}, nil
+			})),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithInitializers(),
+			managed.WithInitializers(awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithConnectionPublishers(),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
-type connector struct {
-	client      client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (ec2.SubnetClient, error)
-}
-
-func (conn *connector) Connect(ctx context.Context, mgd resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mgd.(*v1beta1.Subnet)
-	if !ok {
-		return nil, errors.New(errUnexpectedObject)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := conn.client.Get(ctx, meta.NamespacedNameOf(cr.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if aws.BoolValue(p.Spec.UseServiceAccount) {
-		subnetClient, err := conn.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{client: subnetClient, kube: conn.client}, errors.Wrap(err, errCreateSubnetClient)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := conn.client.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-
-	subnetClient, err := conn.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{client: subnetClient, kube: conn.client}, errors.Wrap(err, errCreateSubnetClient)
-}
-
 type external struct {
 	kube   client.Client
 	client ec2.SubnetClient
@@ -214,13 +176,8 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 
 	subnet := response.Subnets[0]
 
-	if !v1beta1.CompareTags(cr.Spec.ForProvider.Tags, subnet.Tags) {
-		if _, err := e.client.CreateTagsRequest(&awsec2.CreateTagsInput{
-			Resources: []string{meta.GetExternalName(cr)},
-			Tags:      v1beta1.GenerateEC2Tags(cr.Spec.ForProvider.Tags),
-		}).Send(ctx); err != nil {
-			return managed.ExternalUpdate{}, errors.Wrap(err, errCreateTags)
-		}
+	if err := ec2.UpdateTags(ctx, e.client, meta.GetExternalName(cr), cr.Spec.ForProvider.Tags, cr.Status.AtProvider.Tags); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
 	}
 
 	if subnet.MapPublicIpOnLaunch != cr.Spec.ForProvider.MapPublicIPOnLaunch {
@@ -261,3 +218,11 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 
 	return errors.Wrap(resource.Ignore(ec2.IsSubnetNotFoundErr, err), errDelete)
 }
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1beta1.Subnet)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
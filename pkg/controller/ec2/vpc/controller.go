@@ -18,14 +18,11 @@ package vpc
 
 import (
 	"context"
-	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -37,81 +34,47 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
 	errUnexpectedObject = "The managed resource is not an VPC resource"
-	errKubeUpdateFailed = "cannot update VPC custom resource"
-
-	errCreateVpcClient   = "cannot create VPC client"
-	errGetProvider       = "cannot get provider"
-	errGetProviderSecret = "cannot get provider secret"
 
 	errDescribe            = "failed to describe VPC with id"
 	errMultipleItems       = "retrieved multiple VPCs for the given vpcId"
 	errCreate              = "failed to create the VPC resource"
 	errUpdate              = "failed to update VPC resource"
 	errModifyVPCAttributes = "failed to modify the VPC resource attributes"
-	errCreateTags          = "failed to create tags for the VPC resource"
+	errUpdateTags          = "failed to update tags for the VPC resource"
+	errAssociateCIDR       = "failed to associate CIDR block %v with the VPC resource"
+	errAssociateIPv6CIDR   = "failed to associate an IPv6 CIDR block with the VPC resource"
+	errDisassociateCIDR    = "failed to disassociate CIDR block association %v from the VPC resource"
 	errDelete              = "failed to delete the VPC resource"
 	errSpecUpdate          = "cannot update spec of VPC custom resource"
 	errStatusUpdate        = "cannot update status of VPC custom resource"
 )
 
 // SetupVPC adds a controller that reconciles VPCs.
-func SetupVPC(mgr ctrl.Manager, l logging.Logger) error {
+func SetupVPC(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1beta1.VPCGroupKind)
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1beta1.VPC{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.VPC{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.VPCGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: ec2.NewVpcClient}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: ec2.NewVpcClient(cfg), kube: mgr.GetClient()}, nil
+			})),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
-			managed.WithInitializers(&tagger{kube: mgr.GetClient()}),
+			managed.WithInitializers(awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
-type connector struct {
-	kube        client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (ec2.VPCClient, error)
-}
-
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1beta1.VPC)
-	if !ok {
-		return nil, errors.New(errUnexpectedObject)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := c.kube.Get(ctx, meta.NamespacedNameOf(cr.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if aws.BoolValue(p.Spec.UseServiceAccount) {
-		vpcClient, err := c.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{client: vpcClient, kube: c.kube}, errors.Wrap(err, errCreateVpcClient)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := c.kube.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-
-	vpcClient, err := c.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{client: vpcClient, kube: c.kube}, errors.Wrap(err, errCreateVpcClient)
-}
-
 type external struct {
 	kube   client.Client
 	client ec2.VPCClient
@@ -170,7 +133,7 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 		r, err := e.client.DescribeVpcAttributeRequest(&awsec2.DescribeVpcAttributeInput{
 			VpcId:     aws.String(meta.GetExternalName(cr)),
 			Attribute: input,
-		}).Send(context.Background())
+		}).Send(ctx)
 
 		if err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errDescribe)
@@ -203,8 +166,9 @@ func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.Ex
 	}
 
 	result, err := e.client.CreateVpcRequest(&awsec2.CreateVpcInput{
-		CidrBlock:       aws.String(cr.Spec.ForProvider.CIDRBlock),
-		InstanceTenancy: awsec2.Tenancy(aws.StringValue(cr.Spec.ForProvider.InstanceTenancy)),
+		CidrBlock:                   aws.String(cr.Spec.ForProvider.CIDRBlock),
+		InstanceTenancy:             awsec2.Tenancy(aws.StringValue(cr.Spec.ForProvider.InstanceTenancy)),
+		AmazonProvidedIpv6CidrBlock: cr.Spec.ForProvider.AmazonProvidedIPv6CIDRBlock,
 	}).Send(ctx)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
@@ -236,13 +200,13 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 		}
 	}
 
-	// NOTE(muvaf): VPCs can only be tagged after the creation and this request
-	// is idempotent.
-	if _, err := e.client.CreateTagsRequest(&awsec2.CreateTagsInput{
-		Resources: []string{meta.GetExternalName(cr)},
-		Tags:      v1beta1.GenerateEC2Tags(cr.Spec.ForProvider.Tags),
-	}).Send(ctx); err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, errCreateTags)
+	// NOTE(muvaf): VPCs can only be tagged after the creation.
+	if err := ec2.UpdateTags(ctx, e.client, meta.GetExternalName(cr), cr.Spec.ForProvider.Tags, cr.Status.AtProvider.Tags); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
+	}
+
+	if err := e.updateCIDRBlocks(ctx, meta.GetExternalName(cr), ec2.DiffCIDRBlocks(cr.Spec.ForProvider, cr.Status.AtProvider)); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
 	_, err := e.client.ModifyVpcTenancyRequest(&awsec2.ModifyVpcTenancyInput{
@@ -253,6 +217,36 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
 }
 
+func (e *external) updateCIDRBlocks(ctx context.Context, vpcID string, d ec2.CIDRBlocksDiff) error {
+	for _, b := range d.Associate {
+		if _, err := e.client.AssociateVpcCidrBlockRequest(&awsec2.AssociateVpcCidrBlockInput{
+			VpcId:     aws.String(vpcID),
+			CidrBlock: aws.String(b),
+		}).Send(ctx); err != nil {
+			return errors.Wrapf(err, errAssociateCIDR, b)
+		}
+	}
+
+	if d.AssociateIPv6 {
+		if _, err := e.client.AssociateVpcCidrBlockRequest(&awsec2.AssociateVpcCidrBlockInput{
+			VpcId:                       aws.String(vpcID),
+			AmazonProvidedIpv6CidrBlock: aws.Bool(true),
+		}).Send(ctx); err != nil {
+			return errors.Wrap(err, errAssociateIPv6CIDR)
+		}
+	}
+
+	for _, id := range d.Disassociate {
+		if _, err := e.client.DisassociateVpcCidrBlockRequest(&awsec2.DisassociateVpcCidrBlockInput{
+			AssociationId: aws.String(id),
+		}).Send(ctx); err != nil {
+			return errors.Wrapf(err, errDisassociateCIDR, id)
+		}
+	}
+
+	return nil
+}
+
 func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 	cr, ok := mgd.(*v1beta1.VPC)
 	if !ok {
@@ -268,30 +262,10 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 	return errors.Wrap(resource.Ignore(ec2.IsVPCNotFoundErr, err), errDelete)
 }
 
-type tagger struct {
-	kube client.Client
-}
-
-func (t *tagger) Initialize(ctx context.Context, mgd resource.Managed) error {
-	cr, ok := mgd.(*v1beta1.VPC)
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1beta1.VPC)
 	if !ok {
-		return errors.New(errUnexpectedObject)
-	}
-	tagMap := map[string]string{}
-	for _, t := range cr.Spec.ForProvider.Tags {
-		tagMap[t.Key] = t.Value
-	}
-	for k, v := range resource.GetExternalTags(mgd) {
-		tagMap[k] = v
-	}
-	cr.Spec.ForProvider.Tags = make([]v1beta1.Tag, len(tagMap))
-	i := 0
-	for k, v := range tagMap {
-		cr.Spec.ForProvider.Tags[i] = v1beta1.Tag{Key: k, Value: v}
-		i++
+		return nil, errors.New(errUnexpectedObject)
 	}
-	sort.Slice(cr.Spec.ForProvider.Tags, func(i, j int) bool {
-		return cr.Spec.ForProvider.Tags[i].Key < cr.Spec.ForProvider.Tags[j].Key
-	})
-	return errors.Wrap(t.kube.Update(ctx, cr), errKubeUpdateFailed)
+	return &cr.Spec.ForProvider.Tags, nil
 }
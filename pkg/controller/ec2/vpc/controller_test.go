@@ -30,7 +30,6 @@ import (
 	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,26 +38,23 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 
 	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/ec2"
 	"github.com/crossplane/provider-aws/pkg/clients/ec2/fake"
 )
 
 const (
-	providerName    = "aws-creds"
-	secretNamespace = "crossplane-system"
-	testRegion      = "us-east-1"
-
-	connectionSecretName = "my-little-secret"
-	secretKey            = "credentials"
-	credData             = "confidential!"
+	providerName = "aws-creds"
 )
 
 var (
 	vpcID          = "some Id"
 	cidr           = "192.168.0.0/32"
 	tenancyDefault = "default"
+	secondaryCIDR  = "100.64.0.0/16"
+	otherCIDR      = "100.65.0.0/16"
+	associationID  = "vpc-cidr-assoc-1"
 
 	errBoom = errors.New("boom")
 )
@@ -81,6 +77,10 @@ func withTags(tagMaps ...map[string]string) vpcModifier {
 	return func(r *v1beta1.VPC) { r.Spec.ForProvider.Tags = tagList }
 }
 
+func withAnnotations(a map[string]string) vpcModifier {
+	return func(r *v1beta1.VPC) { meta.AddAnnotations(r, a) }
+}
+
 func withExternalName(name string) vpcModifier {
 	return func(r *v1beta1.VPC) { meta.SetExternalName(r, name) }
 }
@@ -112,184 +112,63 @@ func vpc(m ...vpcModifier) *v1beta1.VPC {
 }
 
 var _ managed.ExternalClient = &external{}
-var _ managed.ExternalConnecter = &connector{}
-
-func TestConnect(t *testing.T) {
-	secret := corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      connectionSecretName,
-			Namespace: secretNamespace,
-		},
-		Data: map[string][]byte{
-			secretKey: []byte(credData),
-		},
-	}
 
-	providerSA := func(saVal bool) awsv1alpha3.Provider {
-		return awsv1alpha3.Provider{
-			Spec: awsv1alpha3.ProviderSpec{
-				Region:            testRegion,
-				UseServiceAccount: &saVal,
-				ProviderSpec: runtimev1alpha1.ProviderSpec{
-					CredentialsSecretRef: &runtimev1alpha1.SecretKeySelector{
-						SecretReference: runtimev1alpha1.SecretReference{
-							Namespace: secretNamespace,
-							Name:      connectionSecretName,
-						},
-						Key: secretKey,
-					},
-				},
-			},
-		}
-	}
-	type args struct {
-		kube        client.Client
-		newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (ec2.VPCClient, error)
-		cr          *v1beta1.VPC
-	}
+func TestObserve(t *testing.T) {
 	type want struct {
-		err error
+		cr     *v1beta1.VPC
+		result managed.ExternalObservation
+		err    error
 	}
 
 	cases := map[string]struct {
 		args
 		want
 	}{
-		"Successful": {
+		"SuccessfulAvailable": {
 			args: args{
 				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							secret.DeepCopyInto(obj.(*corev1.Secret))
-							return nil
-						}
-						return errBoom
-					},
-				},
-				newClientFn: func(_ context.Context, credentials []byte, region string, _ awsclients.AuthMethod) (i ec2.VPCClient, e error) {
-					if diff := cmp.Diff(credData, string(credentials)); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					if diff := cmp.Diff(testRegion, region); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return nil, nil
+					MockUpdate: test.NewMockClient().Update,
 				},
-				cr: vpc(),
-			},
-		},
-		"SuccessfulUseServiceAccount": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						if key == (client.ObjectKey{Name: providerName}) {
-							p := providerSA(true)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
+				vpc: &fake.MockVPCClient{
+					MockDescribe: func(input *awsec2.DescribeVpcsInput) awsec2.DescribeVpcsRequest {
+						return awsec2.DescribeVpcsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeVpcsOutput{
+								Vpcs: []awsec2.Vpc{{
+									InstanceTenancy: awsec2.TenancyDefault,
+									State:           awsec2.VpcStateAvailable,
+								}},
+							}},
 						}
-						return errBoom
-					},
-				},
-				newClientFn: func(_ context.Context, credentials []byte, region string, _ awsclients.AuthMethod) (i ec2.VPCClient, e error) {
-					if diff := cmp.Diff("", string(credentials)); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					if diff := cmp.Diff(testRegion, region); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return nil, nil
-				},
-				cr: vpc(),
-			},
-		},
-		"ProviderGetFailed": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						return errBoom
 					},
-				},
-				cr: vpc(),
-			},
-			want: want{
-				err: errors.Wrap(errBoom, errGetProvider),
-			},
-		},
-		"SecretGetFailed": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							return errBoom
-						default:
-							return nil
+					MockDescribeVpcAttributeRequest: func(input *awsec2.DescribeVpcAttributeInput) awsec2.DescribeVpcAttributeRequest {
+						return awsec2.DescribeVpcAttributeRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeVpcAttributeOutput{
+								EnableDnsHostnames: &awsec2.AttributeBooleanValue{},
+								EnableDnsSupport:   &awsec2.AttributeBooleanValue{},
+							}},
 						}
 					},
 				},
-				cr: vpc(),
+				cr: vpc(withSpec(v1beta1.VPCParameters{
+					InstanceTenancy: aws.String(tenancyDefault),
+					CIDRBlock:       cidr,
+				}), withExternalName(vpcID)),
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errGetProviderSecret),
-			},
-		},
-		"SecretGetFailedNil": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.SetCredentialsSecretReference(nil)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							return errBoom
-						default:
-							return nil
-						}
-					},
+				cr: vpc(withSpec(v1beta1.VPCParameters{
+					InstanceTenancy: aws.String(tenancyDefault),
+					CIDRBlock:       cidr,
+				}), withStatus(v1beta1.VPCObservation{
+					VPCState: "available",
+				}), withExternalName(vpcID),
+					withConditions(runtimev1alpha1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
 				},
-				cr: vpc(),
-			},
-			want: want{
-				err: errors.New(errGetProviderSecret),
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			c := &connector{kube: tc.kube, newClientFn: tc.newClientFn}
-			_, err := c.Connect(context.Background(), tc.args.cr)
-			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestObserve(t *testing.T) {
-	type want struct {
-		cr     *v1beta1.VPC
-		result managed.ExternalObservation
-		err    error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"SuccessfulAvailable": {
+		"ReservedTags": {
 			args: args{
 				kube: &test.MockClient{
 					MockUpdate: test.NewMockClient().Update,
@@ -301,6 +180,10 @@ func TestObserve(t *testing.T) {
 								Vpcs: []awsec2.Vpc{{
 									InstanceTenancy: awsec2.TenancyDefault,
 									State:           awsec2.VpcStateAvailable,
+									Tags: []awsec2.Tag{
+										{Key: aws.String("key"), Value: aws.String("value")},
+										{Key: aws.String("aws:cloudformation:stack-name"), Value: aws.String("cool")},
+									},
 								}},
 							}},
 						}
@@ -317,14 +200,18 @@ func TestObserve(t *testing.T) {
 				cr: vpc(withSpec(v1beta1.VPCParameters{
 					InstanceTenancy: aws.String(tenancyDefault),
 					CIDRBlock:       cidr,
-				}), withExternalName(vpcID)),
+				}), withTags(map[string]string{"key": "value"}), withExternalName(vpcID)),
 			},
 			want: want{
 				cr: vpc(withSpec(v1beta1.VPCParameters{
 					InstanceTenancy: aws.String(tenancyDefault),
 					CIDRBlock:       cidr,
-				}), withStatus(v1beta1.VPCObservation{
+				}), withTags(map[string]string{"key": "value"}), withStatus(v1beta1.VPCObservation{
 					VPCState: "available",
+					Tags: []v1beta1.Tag{
+						{Key: "aws:cloudformation:stack-name", Value: "cool"},
+						{Key: "key", Value: "value"},
+					},
 				}), withExternalName(vpcID),
 					withConditions(runtimev1alpha1.Available())),
 				result: managed.ExternalObservation{
@@ -526,6 +413,158 @@ func TestUpdate(t *testing.T) {
 				})),
 			},
 		},
+		"UpdateTags": {
+			args: args{
+				vpc: &fake.MockVPCClient{
+					MockModifyTenancy: func(input *awsec2.ModifyVpcTenancyInput) awsec2.ModifyVpcTenancyRequest {
+						return awsec2.ModifyVpcTenancyRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.ModifyVpcTenancyOutput{}},
+						}
+					},
+					MockCreateTagsRequest: func(input *awsec2.CreateTagsInput) awsec2.CreateTagsRequest {
+						want := &awsec2.CreateTagsInput{Resources: []string{""}, Tags: []awsec2.Tag{{Key: aws.String("foo"), Value: aws.String("bar")}}}
+						if diff := cmp.Diff(want, input); diff != "" {
+							return awsec2.CreateTagsRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(diff)}}
+						}
+						return awsec2.CreateTagsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.CreateTagsOutput{}},
+						}
+					},
+					MockDeleteTagsRequest: func(input *awsec2.DeleteTagsInput) awsec2.DeleteTagsRequest {
+						want := &awsec2.DeleteTagsInput{Resources: []string{""}, Tags: []awsec2.Tag{{Key: aws.String("old")}}}
+						if diff := cmp.Diff(want, input); diff != "" {
+							return awsec2.DeleteTagsRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(diff)}}
+						}
+						return awsec2.DeleteTagsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DeleteTagsOutput{}},
+						}
+					},
+					MockModifyAttribute: func(input *awsec2.ModifyVpcAttributeInput) awsec2.ModifyVpcAttributeRequest {
+						return awsec2.ModifyVpcAttributeRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.ModifyVpcAttributeOutput{}},
+						}
+					},
+				},
+				cr: vpc(withTags(map[string]string{"foo": "bar"}), withStatus(v1beta1.VPCObservation{
+					Tags: []v1beta1.Tag{{Key: "aws:cloudformation:stack-name", Value: "cool"}, {Key: "foo", Value: "baz"}, {Key: "old", Value: "tag"}},
+				})),
+			},
+			want: want{
+				cr: vpc(withTags(map[string]string{"foo": "bar"}), withStatus(v1beta1.VPCObservation{
+					Tags: []v1beta1.Tag{{Key: "aws:cloudformation:stack-name", Value: "cool"}, {Key: "foo", Value: "baz"}, {Key: "old", Value: "tag"}},
+				})),
+			},
+		},
+		"UpdateCIDRBlocks": {
+			args: args{
+				vpc: &fake.MockVPCClient{
+					MockModifyTenancy: func(input *awsec2.ModifyVpcTenancyInput) awsec2.ModifyVpcTenancyRequest {
+						return awsec2.ModifyVpcTenancyRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.ModifyVpcTenancyOutput{}},
+						}
+					},
+					MockModifyAttribute: func(input *awsec2.ModifyVpcAttributeInput) awsec2.ModifyVpcAttributeRequest {
+						return awsec2.ModifyVpcAttributeRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.ModifyVpcAttributeOutput{}},
+						}
+					},
+					MockAssociateCIDRBlock: func(input *awsec2.AssociateVpcCidrBlockInput) awsec2.AssociateVpcCidrBlockRequest {
+						return awsec2.AssociateVpcCidrBlockRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.AssociateVpcCidrBlockOutput{}},
+						}
+					},
+					MockDisassociateCIDRBlock: func(input *awsec2.DisassociateVpcCidrBlockInput) awsec2.DisassociateVpcCidrBlockRequest {
+						return awsec2.DisassociateVpcCidrBlockRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DisassociateVpcCidrBlockOutput{}},
+						}
+					},
+				},
+				cr: vpc(withSpec(v1beta1.VPCParameters{
+					SecondaryCIDRBlocks:         []string{secondaryCIDR},
+					AmazonProvidedIPv6CIDRBlock: aws.Bool(true),
+				}), withStatus(v1beta1.VPCObservation{
+					CIDRBlockAssociationSet: []v1beta1.VPCCIDRBlockAssociation{{
+						AssociationID:  associationID,
+						CIDRBlock:      otherCIDR,
+						CIDRBlockState: v1beta1.VPCCIDRBlockState{State: string(awsec2.VpcCidrBlockStateCodeAssociated)},
+					}},
+				})),
+			},
+			want: want{
+				cr: vpc(withSpec(v1beta1.VPCParameters{
+					SecondaryCIDRBlocks:         []string{secondaryCIDR},
+					AmazonProvidedIPv6CIDRBlock: aws.Bool(true),
+				}), withStatus(v1beta1.VPCObservation{
+					CIDRBlockAssociationSet: []v1beta1.VPCCIDRBlockAssociation{{
+						AssociationID:  associationID,
+						CIDRBlock:      otherCIDR,
+						CIDRBlockState: v1beta1.VPCCIDRBlockState{State: string(awsec2.VpcCidrBlockStateCodeAssociated)},
+					}},
+				})),
+			},
+		},
+		"AssociateCIDRBlockFailed": {
+			args: args{
+				vpc: &fake.MockVPCClient{
+					MockModifyAttribute: func(input *awsec2.ModifyVpcAttributeInput) awsec2.ModifyVpcAttributeRequest {
+						return awsec2.ModifyVpcAttributeRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.ModifyVpcAttributeOutput{}},
+						}
+					},
+					MockAssociateCIDRBlock: func(input *awsec2.AssociateVpcCidrBlockInput) awsec2.AssociateVpcCidrBlockRequest {
+						return awsec2.AssociateVpcCidrBlockRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: vpc(withSpec(v1beta1.VPCParameters{
+					SecondaryCIDRBlocks: []string{secondaryCIDR},
+				})),
+			},
+			want: want{
+				cr: vpc(withSpec(v1beta1.VPCParameters{
+					SecondaryCIDRBlocks: []string{secondaryCIDR},
+				})),
+				err: errors.Wrapf(errBoom, errAssociateCIDR, secondaryCIDR),
+			},
+		},
+		"DisassociateCIDRBlockFailed": {
+			args: args{
+				vpc: &fake.MockVPCClient{
+					MockModifyAttribute: func(input *awsec2.ModifyVpcAttributeInput) awsec2.ModifyVpcAttributeRequest {
+						return awsec2.ModifyVpcAttributeRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.ModifyVpcAttributeOutput{}},
+						}
+					},
+					MockDisassociateCIDRBlock: func(input *awsec2.DisassociateVpcCidrBlockInput) awsec2.DisassociateVpcCidrBlockRequest {
+						return awsec2.DisassociateVpcCidrBlockRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: vpc(withSpec(v1beta1.VPCParameters{
+					SecondaryCIDRBlocks: []string{},
+				}), withStatus(v1beta1.VPCObservation{
+					CIDRBlockAssociationSet: []v1beta1.VPCCIDRBlockAssociation{{
+						AssociationID:  associationID,
+						CIDRBlock:      otherCIDR,
+						CIDRBlockState: v1beta1.VPCCIDRBlockState{State: string(awsec2.VpcCidrBlockStateCodeAssociated)},
+					}},
+				})),
+			},
+			want: want{
+				cr: vpc(withSpec(v1beta1.VPCParameters{
+					SecondaryCIDRBlocks: []string{},
+				}), withStatus(v1beta1.VPCObservation{
+					CIDRBlockAssociationSet: []v1beta1.VPCCIDRBlockAssociation{{
+						AssociationID:  associationID,
+						CIDRBlock:      otherCIDR,
+						CIDRBlockState: v1beta1.VPCCIDRBlockState{State: string(awsec2.VpcCidrBlockStateCodeAssociated)},
+					}},
+				})),
+				err: errors.Wrapf(errBoom, errDisassociateCIDR, associationID),
+			},
+		},
 		"ModifyFailed": {
 			args: args{
 				vpc: &fake.MockVPCClient{
@@ -650,27 +689,39 @@ func TestInitialize(t *testing.T) {
 	}{
 		"Successful": {
 			args: args{
-				cr:   vpc(withTags(map[string]string{"foo": "bar"})),
-				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				cr: vpc(withTags(map[string]string{"foo": "bar"})),
+				kube: &test.MockClient{
+					MockGet:    test.NewMockGetFn(nil),
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
 			},
 			want: want{
 				cr: vpc(withTags(resource.GetExternalTags(vpc()), map[string]string{"foo": "bar"})),
 			},
 		},
-		"UpdateFailed": {
+		"DefaultTags": {
 			args: args{
-				cr:   vpc(),
-				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+				cr: vpc(withTags(map[string]string{"foo": "bar"})),
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj runtime.Object) error {
+						obj.(*v1alpha3.Provider).Spec.DefaultTags = map[string]string{"foo": "baz", "team": "cool"}
+						return nil
+					}),
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errKubeUpdateFailed),
+				cr: vpc(
+					withTags(resource.GetExternalTags(vpc()), map[string]string{"foo": "bar", "team": "cool"}),
+					withAnnotations(map[string]string{awsclients.AnnotationKeyDefaultTags: `{"team":"cool"}`}),
+				),
 			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &tagger{kube: tc.kube}
+			e := awsclients.NewTagger(tc.kube, specTags)
 			err := e.Initialize(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
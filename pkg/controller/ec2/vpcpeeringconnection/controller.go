@@ -0,0 +1,296 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcpeeringconnection
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
+)
+
+const (
+	errUnexpectedObject       = "The managed resource is not a VPCPeeringConnection resource"
+	errDescribe               = "failed to describe VPCPeeringConnection"
+	errNotSingleItem          = "either no or multiple VPCPeeringConnections retrieved for the given vpcPeeringConnectionId"
+	errCreate                 = "failed to create the VPCPeeringConnection resource"
+	errAccept                 = "failed to accept the VPCPeeringConnection resource"
+	errModifyRequesterOptions = "failed to modify the requester options of the VPCPeeringConnection resource"
+	errModifyAccepterOptions  = "failed to modify the accepter options of the VPCPeeringConnection resource"
+	errDelete                 = "failed to delete the VPCPeeringConnection resource"
+	errSpecUpdate             = "cannot update spec of the VPCPeeringConnection resource"
+	errStatusUpdate           = "cannot update status of the VPCPeeringConnection resource"
+	errUpdateTags             = "failed to update tags for the VPCPeeringConnection resource"
+	errGetAccepterConfig      = "cannot get config of the accepter provider of the VPCPeeringConnection resource"
+)
+
+// SetupVPCPeeringConnection adds a controller that reconciles
+// VPCPeeringConnections.
+func SetupVPCPeeringConnection(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
+	name := managed.ControllerName(v1alpha4.VPCPeeringConnectionGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha4.VPCPeeringConnection{}, o.Selected()).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha4.VPCPeeringConnectionGroupVersionKind),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{
+					kube:     mgr.GetClient(),
+					client:   ec2.NewVPCPeeringConnectionClient(cfg),
+					accepter: newAccepterFn(mgr.GetClient(), *cfg),
+				}, nil
+			})),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(awsclients.NewTagger(mgr.GetClient(), specTags)),
+			managed.WithConnectionPublishers(),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+// An accepterFn returns the client that is used to accept the supplied VPC
+// peering connection and to modify the options of its accepter side.
+type accepterFn func(ctx context.Context, cr *v1alpha4.VPCPeeringConnection) (ec2.VPCPeeringConnectionClient, error)
+
+// newAccepterFn returns an accepterFn that connects to AWS using the accepter
+// Provider of the VPC peering connection if it references one, and otherwise
+// using the supplied requester config. Either way the client connects to the
+// region of the peer VPC.
+func newAccepterFn(kube client.Reader, requester aws.Config) accepterFn {
+	return func(ctx context.Context, cr *v1alpha4.VPCPeeringConnection) (ec2.VPCPeeringConnectionClient, error) {
+		cfg := requester
+		if ref := cr.Spec.AccepterProviderReference; ref != nil {
+			c, err := awsclients.GetConfig(ctx, kube, ref)
+			if err != nil {
+				return nil, errors.Wrap(err, errGetAccepterConfig)
+			}
+			cfg = *c
+		}
+		if cr.Spec.ForProvider.PeerRegion != nil {
+			cfg.Region = aws.StringValue(cr.Spec.ForProvider.PeerRegion)
+		}
+		return ec2.NewVPCPeeringConnectionClient(&cfg), nil
+	}
+}
+
+type external struct {
+	kube     client.Client
+	client   ec2.VPCPeeringConnectionClient
+	accepter accepterFn
+}
+
+func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
+	cr, ok := mgd.(*v1alpha4.VPCPeeringConnection)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errUnexpectedObject)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	response, err := e.client.DescribeVpcPeeringConnectionsRequest(&awsec2.DescribeVpcPeeringConnectionsInput{
+		VpcPeeringConnectionIds: []string{meta.GetExternalName(cr)},
+	}).Send(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(ec2.IsVPCPeeringConnectionNotFoundErr, err), errDescribe)
+	}
+
+	// in a successful response, there should be one and only one object
+	if len(response.VpcPeeringConnections) != 1 {
+		return managed.ExternalObservation{}, errors.New(errNotSingleItem)
+	}
+
+	observed := response.VpcPeeringConnections[0]
+	o := ec2.GenerateVPCPeeringConnectionObservation(observed)
+
+	// Deleted VPC peering connections remain visible for a while after their
+	// deletion.
+	if o.Status == string(awsec2.VpcPeeringConnectionStateReasonCodeDeleted) {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	ec2.LateInitializeVPCPeeringConnection(&cr.Spec.ForProvider, &observed)
+	if !cmp.Equal(current, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSpecUpdate)
+		}
+	}
+
+	switch awsec2.VpcPeeringConnectionStateReasonCode(o.Status) {
+	case awsec2.VpcPeeringConnectionStateReasonCodeActive:
+		cr.SetConditions(runtimev1alpha1.Available())
+	case awsec2.VpcPeeringConnectionStateReasonCodeInitiatingRequest,
+		awsec2.VpcPeeringConnectionStateReasonCodePendingAcceptance,
+		awsec2.VpcPeeringConnectionStateReasonCodeProvisioning:
+		cr.SetConditions(runtimev1alpha1.Creating())
+	case awsec2.VpcPeeringConnectionStateReasonCodeFailed,
+		awsec2.VpcPeeringConnectionStateReasonCodeRejected,
+		awsec2.VpcPeeringConnectionStateReasonCodeExpired:
+		cr.SetConditions(runtimev1alpha1.Unavailable().WithMessage(o.StatusMessage))
+	case awsec2.VpcPeeringConnectionStateReasonCodeDeleting:
+		cr.SetConditions(runtimev1alpha1.Deleting())
+	}
+
+	cr.Status.AtProvider = o
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: ec2.IsVPCPeeringConnectionUpToDate(cr.Spec.ForProvider, observed) && !awaitsAcceptance(cr),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha4.VPCPeeringConnection)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errUnexpectedObject)
+	}
+
+	cr.Status.SetConditions(runtimev1alpha1.Creating())
+	if err := e.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errStatusUpdate)
+	}
+
+	result, err := e.client.CreateVpcPeeringConnectionRequest(ec2.GenerateCreateVPCPeeringConnectionInput(cr.Spec.ForProvider)).Send(ctx)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	meta.SetExternalName(cr, aws.StringValue(result.VpcPeeringConnection.VpcPeeringConnectionId))
+
+	return managed.ExternalCreation{}, errors.Wrap(e.kube.Update(ctx, cr), errSpecUpdate)
+}
+
+func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha4.VPCPeeringConnection)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
+	}
+
+	if awaitsAcceptance(cr) {
+		c, err := e.accepter(ctx, cr)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if _, err := c.AcceptVpcPeeringConnectionRequest(&awsec2.AcceptVpcPeeringConnectionInput{
+			VpcPeeringConnectionId: aws.String(meta.GetExternalName(cr)),
+		}).Send(ctx); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errAccept)
+		}
+	}
+
+	if cr.Status.AtProvider.Status == string(awsec2.VpcPeeringConnectionStateReasonCodeActive) {
+		if err := e.updatePeeringOptions(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	err := ec2.UpdateTags(ctx, e.client, meta.GetExternalName(cr), cr.Spec.ForProvider.Tags, cr.Status.AtProvider.Tags)
+
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
+}
+
+// updatePeeringOptions modifies the options of each side of the supplied
+// VPC peering connection that are not up to date. The options of the accepter
+// side are modified using the accepter's client.
+func (e *external) updatePeeringOptions(ctx context.Context, cr *v1alpha4.VPCPeeringConnection) error {
+	p := cr.Spec.ForProvider
+	id := aws.String(meta.GetExternalName(cr))
+
+	if !ec2.IsPeeringOptionsUpToDate(p.RequesterPeeringOptions, cr.Status.AtProvider.RequesterVPCInfo) {
+		if _, err := e.client.ModifyVpcPeeringConnectionOptionsRequest(&awsec2.ModifyVpcPeeringConnectionOptionsInput{
+			VpcPeeringConnectionId:            id,
+			RequesterPeeringConnectionOptions: ec2.GeneratePeeringConnectionOptionsRequest(p.RequesterPeeringOptions),
+		}).Send(ctx); err != nil {
+			return errors.Wrap(err, errModifyRequesterOptions)
+		}
+	}
+
+	if ec2.IsPeeringOptionsUpToDate(p.AccepterPeeringOptions, cr.Status.AtProvider.AccepterVPCInfo) {
+		return nil
+	}
+	c, err := e.accepter(ctx, cr)
+	if err != nil {
+		return err
+	}
+	_, err = c.ModifyVpcPeeringConnectionOptionsRequest(&awsec2.ModifyVpcPeeringConnectionOptionsInput{
+		VpcPeeringConnectionId:           id,
+		AccepterPeeringConnectionOptions: ec2.GeneratePeeringConnectionOptionsRequest(p.AccepterPeeringOptions),
+	}).Send(ctx)
+	return errors.Wrap(err, errModifyAccepterOptions)
+}
+
+func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha4.VPCPeeringConnection)
+	if !ok {
+		return errors.New(errUnexpectedObject)
+	}
+
+	cr.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	// VPC peering connections that are already being deleted cannot be
+	// deleted again.
+	if cr.Status.AtProvider.Status == string(awsec2.VpcPeeringConnectionStateReasonCodeDeleting) {
+		return nil
+	}
+
+	_, err := e.client.DeleteVpcPeeringConnectionRequest(&awsec2.DeleteVpcPeeringConnectionInput{
+		VpcPeeringConnectionId: aws.String(meta.GetExternalName(cr)),
+	}).Send(ctx)
+
+	return errors.Wrap(resource.Ignore(ec2.IsVPCPeeringConnectionNotFoundErr, err), errDelete)
+}
+
+// awaitsAcceptance returns true if the supplied VPC peering connection is
+// pending acceptance, and should be accepted either because it references an
+// accepter Provider or because it is to be accepted automatically.
+func awaitsAcceptance(cr *v1alpha4.VPCPeeringConnection) bool {
+	if cr.Status.AtProvider.Status != string(awsec2.VpcPeeringConnectionStateReasonCodePendingAcceptance) {
+		return false
+	}
+	return cr.Spec.AccepterProviderReference != nil || aws.BoolValue(cr.Spec.ForProvider.AutoAccept)
+}
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1alpha4.VPCPeeringConnection)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
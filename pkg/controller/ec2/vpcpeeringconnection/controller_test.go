@@ -0,0 +1,659 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcpeeringconnection
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/ec2/v1alpha4"
+	"github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2/fake"
+)
+
+const (
+	providerName         = "aws-creds"
+	accepterProviderName = "aws-peer-creds"
+)
+
+var (
+	pcxID     = "pcx-1"
+	vpcID     = "vpc-1"
+	peerVPCID = "vpc-2"
+	expired   = "Expired"
+
+	errBoom = errors.New("boom")
+)
+
+type args struct {
+	pcx      ec2.VPCPeeringConnectionClient
+	accepter accepterFn
+	kube     client.Client
+	cr       *v1alpha4.VPCPeeringConnection
+}
+
+type pcxModifier func(*v1alpha4.VPCPeeringConnection)
+
+func withExternalName(name string) pcxModifier {
+	return func(r *v1alpha4.VPCPeeringConnection) { meta.SetExternalName(r, name) }
+}
+
+func withConditions(c ...runtimev1alpha1.Condition) pcxModifier {
+	return func(r *v1alpha4.VPCPeeringConnection) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withSpec(p v1alpha4.VPCPeeringConnectionParameters) pcxModifier {
+	return func(r *v1alpha4.VPCPeeringConnection) { r.Spec.ForProvider = p }
+}
+
+func withStatus(s v1alpha4.VPCPeeringConnectionObservation) pcxModifier {
+	return func(r *v1alpha4.VPCPeeringConnection) { r.Status.AtProvider = s }
+}
+
+func withAccepterProvider(name string) pcxModifier {
+	return func(r *v1alpha4.VPCPeeringConnection) {
+		r.Spec.AccepterProviderReference = &corev1.ObjectReference{Name: name}
+	}
+}
+
+func pcx(m ...pcxModifier) *v1alpha4.VPCPeeringConnection {
+	cr := &v1alpha4.VPCPeeringConnection{
+		Spec: v1alpha4.VPCPeeringConnectionSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &corev1.ObjectReference{Name: providerName},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func spec() v1alpha4.VPCPeeringConnectionParameters {
+	return v1alpha4.VPCPeeringConnectionParameters{
+		VPCID:     aws.String(vpcID),
+		PeerVPCID: aws.String(peerVPCID),
+	}
+}
+
+func status(code awsec2.VpcPeeringConnectionStateReasonCode) *awsec2.VpcPeeringConnectionStateReason {
+	return &awsec2.VpcPeeringConnectionStateReason{Code: code}
+}
+
+func describe(pcxs ...awsec2.VpcPeeringConnection) func(*awsec2.DescribeVpcPeeringConnectionsInput) awsec2.DescribeVpcPeeringConnectionsRequest {
+	return func(*awsec2.DescribeVpcPeeringConnectionsInput) awsec2.DescribeVpcPeeringConnectionsRequest {
+		return awsec2.DescribeVpcPeeringConnectionsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeVpcPeeringConnectionsOutput{
+				VpcPeeringConnections: pcxs,
+			}},
+		}
+	}
+}
+
+func accepter(c ec2.VPCPeeringConnectionClient, err error) accepterFn {
+	return func(_ context.Context, _ *v1alpha4.VPCPeeringConnection) (ec2.VPCPeeringConnectionClient, error) {
+		return c, err
+	}
+}
+
+func modifyOptions(err error) func(*awsec2.ModifyVpcPeeringConnectionOptionsInput) awsec2.ModifyVpcPeeringConnectionOptionsRequest {
+	return func(*awsec2.ModifyVpcPeeringConnectionOptionsInput) awsec2.ModifyVpcPeeringConnectionOptionsRequest {
+		if err != nil {
+			return awsec2.ModifyVpcPeeringConnectionOptionsRequest{
+				Request: &aws.Request{HTTPRequest: &http.Request{}, Error: err},
+			}
+		}
+		return awsec2.ModifyVpcPeeringConnectionOptionsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.ModifyVpcPeeringConnectionOptionsOutput{}},
+		}
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha4.VPCPeeringConnection
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"NoExternalName": {
+			reason: "A VPCPeeringConnection without an external name should not exist.",
+			args:   args{cr: pcx(withSpec(spec()))},
+			want: want{
+				cr: pcx(withSpec(spec())),
+			},
+		},
+		"Active": {
+			reason: "An active VPC peering connection should be observed as available and up to date.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDescribe: describe(awsec2.VpcPeeringConnection{
+						VpcPeeringConnectionId: aws.String(pcxID),
+						Status:                 status(awsec2.VpcPeeringConnectionStateReasonCodeActive),
+					}),
+				},
+				cr: pcx(withSpec(spec()), withExternalName(pcxID)),
+			},
+			want: want{
+				cr: pcx(withSpec(spec()), withExternalName(pcxID),
+					withStatus(v1alpha4.VPCPeeringConnectionObservation{
+						VPCPeeringConnectionID: pcxID,
+						Status:                 string(awsec2.VpcPeeringConnectionStateReasonCodeActive),
+					}),
+					withConditions(runtimev1alpha1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"PendingAcceptance": {
+			reason: "A VPC peering connection that is pending acceptance by another party should be observed as creating and up to date.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDescribe: describe(awsec2.VpcPeeringConnection{
+						VpcPeeringConnectionId: aws.String(pcxID),
+						Status:                 status(awsec2.VpcPeeringConnectionStateReasonCodePendingAcceptance),
+					}),
+				},
+				cr: pcx(withSpec(spec()), withExternalName(pcxID)),
+			},
+			want: want{
+				cr: pcx(withSpec(spec()), withExternalName(pcxID),
+					withStatus(v1alpha4.VPCPeeringConnectionObservation{
+						VPCPeeringConnectionID: pcxID,
+						Status:                 string(awsec2.VpcPeeringConnectionStateReasonCodePendingAcceptance),
+					}),
+					withConditions(runtimev1alpha1.Creating())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"AwaitsAcceptance": {
+			reason: "A VPC peering connection that is pending acceptance by its accepter Provider should not be up to date.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDescribe: describe(awsec2.VpcPeeringConnection{
+						VpcPeeringConnectionId: aws.String(pcxID),
+						Status:                 status(awsec2.VpcPeeringConnectionStateReasonCodePendingAcceptance),
+					}),
+				},
+				cr: pcx(withSpec(spec()), withExternalName(pcxID), withAccepterProvider(accepterProviderName)),
+			},
+			want: want{
+				cr: pcx(withSpec(spec()), withExternalName(pcxID), withAccepterProvider(accepterProviderName),
+					withStatus(v1alpha4.VPCPeeringConnectionObservation{
+						VPCPeeringConnectionID: pcxID,
+						Status:                 string(awsec2.VpcPeeringConnectionStateReasonCodePendingAcceptance),
+					}),
+					withConditions(runtimev1alpha1.Creating())),
+				result: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+		"Expired": {
+			reason: "An expired VPC peering connection should be observed as unavailable with its status message.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDescribe: describe(awsec2.VpcPeeringConnection{
+						VpcPeeringConnectionId: aws.String(pcxID),
+						Status: &awsec2.VpcPeeringConnectionStateReason{
+							Code:    awsec2.VpcPeeringConnectionStateReasonCodeExpired,
+							Message: aws.String(expired),
+						},
+					}),
+				},
+				cr: pcx(withSpec(spec()), withExternalName(pcxID)),
+			},
+			want: want{
+				cr: pcx(withSpec(spec()), withExternalName(pcxID),
+					withStatus(v1alpha4.VPCPeeringConnectionObservation{
+						VPCPeeringConnectionID: pcxID,
+						Status:                 string(awsec2.VpcPeeringConnectionStateReasonCodeExpired),
+						StatusMessage:          expired,
+					}),
+					withConditions(runtimev1alpha1.Unavailable().WithMessage(expired))),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"Deleted": {
+			reason: "A deleted VPC peering connection should not exist.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDescribe: describe(awsec2.VpcPeeringConnection{
+						VpcPeeringConnectionId: aws.String(pcxID),
+						Status:                 status(awsec2.VpcPeeringConnectionStateReasonCodeDeleted),
+					}),
+				},
+				cr: pcx(withSpec(spec()), withExternalName(pcxID)),
+			},
+			want: want{
+				cr: pcx(withSpec(spec()), withExternalName(pcxID)),
+			},
+		},
+		"NotFound": {
+			reason: "A VPC peering connection that is not found should not exist.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDescribe: func(input *awsec2.DescribeVpcPeeringConnectionsInput) awsec2.DescribeVpcPeeringConnectionsRequest {
+						return awsec2.DescribeVpcPeeringConnectionsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: awserr.New(ec2.VPCPeeringConnectionIDNotFound, "", nil)},
+						}
+					},
+				},
+				cr: pcx(withSpec(spec()), withExternalName(pcxID)),
+			},
+			want: want{
+				cr: pcx(withSpec(spec()), withExternalName(pcxID)),
+			},
+		},
+		"MultipleVPCPeeringConnections": {
+			reason: "An error should be returned if multiple VPC peering connections are described.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDescribe: describe(awsec2.VpcPeeringConnection{}, awsec2.VpcPeeringConnection{}),
+				},
+				cr: pcx(withSpec(spec()), withExternalName(pcxID)),
+			},
+			want: want{
+				cr:  pcx(withSpec(spec()), withExternalName(pcxID)),
+				err: errors.New(errNotSingleItem),
+			},
+		},
+		"FailedRequest": {
+			reason: "Errors describing the VPC peering connection should be returned.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDescribe: func(input *awsec2.DescribeVpcPeeringConnectionsInput) awsec2.DescribeVpcPeeringConnectionsRequest {
+						return awsec2.DescribeVpcPeeringConnectionsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: pcx(withSpec(spec()), withExternalName(pcxID)),
+			},
+			want: want{
+				cr:  pcx(withSpec(spec()), withExternalName(pcxID)),
+				err: errors.Wrap(errBoom, errDescribe),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.pcx, accepter: tc.accepter}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha4.VPCPeeringConnection
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Successful": {
+			reason: "The external name should be set to the ID of the created VPC peering connection.",
+			args: args{
+				kube: &test.MockClient{
+					MockUpdate:       test.NewMockClient().Update,
+					MockStatusUpdate: test.NewMockClient().MockStatusUpdate,
+				},
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockCreate: func(input *awsec2.CreateVpcPeeringConnectionInput) awsec2.CreateVpcPeeringConnectionRequest {
+						return awsec2.CreateVpcPeeringConnectionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.CreateVpcPeeringConnectionOutput{
+								VpcPeeringConnection: &awsec2.VpcPeeringConnection{VpcPeeringConnectionId: aws.String(pcxID)},
+							}},
+						}
+					},
+				},
+				cr: pcx(withSpec(spec())),
+			},
+			want: want{
+				cr: pcx(withSpec(spec()), withExternalName(pcxID), withConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"FailedRequest": {
+			reason: "Errors creating the VPC peering connection should be returned.",
+			args: args{
+				kube: &test.MockClient{
+					MockStatusUpdate: test.NewMockClient().MockStatusUpdate,
+				},
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockCreate: func(input *awsec2.CreateVpcPeeringConnectionInput) awsec2.CreateVpcPeeringConnectionRequest {
+						return awsec2.CreateVpcPeeringConnectionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: pcx(withSpec(spec())),
+			},
+			want: want{
+				cr:  pcx(withSpec(spec()), withConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errBoom, errCreate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.pcx, accepter: tc.accepter}
+			o, err := e.Create(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha4.VPCPeeringConnection
+		result managed.ExternalUpdate
+		err    error
+	}
+
+	tags := []v1beta1.Tag{{Key: "k", Value: "v"}}
+	pending := v1alpha4.VPCPeeringConnectionObservation{Status: string(awsec2.VpcPeeringConnectionStateReasonCodePendingAcceptance)}
+	active := v1alpha4.VPCPeeringConnectionObservation{Status: string(awsec2.VpcPeeringConnectionStateReasonCodeActive)}
+	options := v1alpha4.VPCPeeringConnectionParameters{
+		RequesterPeeringOptions: &v1alpha4.VPCPeeringConnectionOptions{AllowDNSResolutionFromRemoteVPC: aws.Bool(true)},
+		AccepterPeeringOptions:  &v1alpha4.VPCPeeringConnectionOptions{AllowDNSResolutionFromRemoteVPC: aws.Bool(true)},
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Successful": {
+			reason: "Tags that are not observed should be created.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockCreateTags: func(input *awsec2.CreateTagsInput) awsec2.CreateTagsRequest {
+						return awsec2.CreateTagsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.CreateTagsOutput{}},
+						}
+					},
+				},
+				cr: pcx(withSpec(v1alpha4.VPCPeeringConnectionParameters{Tags: tags}), withExternalName(pcxID)),
+			},
+			want: want{
+				cr: pcx(withSpec(v1alpha4.VPCPeeringConnectionParameters{Tags: tags}), withExternalName(pcxID)),
+			},
+		},
+		"Accept": {
+			reason: "A VPC peering connection that is pending acceptance should be accepted using the accepter's client.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{},
+				accepter: accepter(&fake.MockVPCPeeringConnectionClient{
+					MockAccept: func(input *awsec2.AcceptVpcPeeringConnectionInput) awsec2.AcceptVpcPeeringConnectionRequest {
+						return awsec2.AcceptVpcPeeringConnectionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.AcceptVpcPeeringConnectionOutput{}},
+						}
+					},
+				}, nil),
+				cr: pcx(withExternalName(pcxID), withAccepterProvider(accepterProviderName), withStatus(pending)),
+			},
+			want: want{
+				cr: pcx(withExternalName(pcxID), withAccepterProvider(accepterProviderName), withStatus(pending)),
+			},
+		},
+		"AcceptFail": {
+			reason: "Errors accepting the VPC peering connection should be returned.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{},
+				accepter: accepter(&fake.MockVPCPeeringConnectionClient{
+					MockAccept: func(input *awsec2.AcceptVpcPeeringConnectionInput) awsec2.AcceptVpcPeeringConnectionRequest {
+						return awsec2.AcceptVpcPeeringConnectionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				}, nil),
+				cr: pcx(withSpec(v1alpha4.VPCPeeringConnectionParameters{AutoAccept: aws.Bool(true)}), withExternalName(pcxID), withStatus(pending)),
+			},
+			want: want{
+				cr:  pcx(withSpec(v1alpha4.VPCPeeringConnectionParameters{AutoAccept: aws.Bool(true)}), withExternalName(pcxID), withStatus(pending)),
+				err: errors.Wrap(errBoom, errAccept),
+			},
+		},
+		"GetAccepterFail": {
+			reason: "Errors getting the accepter's client should be returned.",
+			args: args{
+				pcx:      &fake.MockVPCPeeringConnectionClient{},
+				accepter: accepter(nil, errBoom),
+				cr:       pcx(withExternalName(pcxID), withAccepterProvider(accepterProviderName), withStatus(pending)),
+			},
+			want: want{
+				cr:  pcx(withExternalName(pcxID), withAccepterProvider(accepterProviderName), withStatus(pending)),
+				err: errBoom,
+			},
+		},
+		"ModifyOptions": {
+			reason: "The options of each side of an active VPC peering connection should be modified using that side's client.",
+			args: args{
+				pcx:      &fake.MockVPCPeeringConnectionClient{MockModifyOptions: modifyOptions(nil)},
+				accepter: accepter(&fake.MockVPCPeeringConnectionClient{MockModifyOptions: modifyOptions(nil)}, nil),
+				cr:       pcx(withSpec(options), withExternalName(pcxID), withAccepterProvider(accepterProviderName), withStatus(active)),
+			},
+			want: want{
+				cr: pcx(withSpec(options), withExternalName(pcxID), withAccepterProvider(accepterProviderName), withStatus(active)),
+			},
+		},
+		"ModifyRequesterOptionsFail": {
+			reason: "Errors modifying the requester options should be returned.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{MockModifyOptions: modifyOptions(errBoom)},
+				cr:  pcx(withSpec(options), withExternalName(pcxID), withStatus(active)),
+			},
+			want: want{
+				cr:  pcx(withSpec(options), withExternalName(pcxID), withStatus(active)),
+				err: errors.Wrap(errBoom, errModifyRequesterOptions),
+			},
+		},
+		"ModifyAccepterOptionsFail": {
+			reason: "Errors modifying the accepter options should be returned.",
+			args: args{
+				pcx:      &fake.MockVPCPeeringConnectionClient{MockModifyOptions: modifyOptions(nil)},
+				accepter: accepter(&fake.MockVPCPeeringConnectionClient{MockModifyOptions: modifyOptions(errBoom)}, nil),
+				cr:       pcx(withSpec(options), withExternalName(pcxID), withStatus(active)),
+			},
+			want: want{
+				cr:  pcx(withSpec(options), withExternalName(pcxID), withStatus(active)),
+				err: errors.Wrap(errBoom, errModifyAccepterOptions),
+			},
+		},
+		"CreateTagsFail": {
+			reason: "Errors updating the tags should be returned.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockCreateTags: func(input *awsec2.CreateTagsInput) awsec2.CreateTagsRequest {
+						return awsec2.CreateTagsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: pcx(withSpec(v1alpha4.VPCPeeringConnectionParameters{Tags: tags}), withExternalName(pcxID)),
+			},
+			want: want{
+				cr:  pcx(withSpec(v1alpha4.VPCPeeringConnectionParameters{Tags: tags}), withExternalName(pcxID)),
+				err: errors.Wrap(errors.Wrap(errBoom, "cannot create tags"), errUpdateTags),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.pcx, accepter: tc.accepter}
+			o, err := e.Update(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		cr  *v1alpha4.VPCPeeringConnection
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Successful": {
+			reason: "The VPC peering connection should be deleted.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDelete: func(input *awsec2.DeleteVpcPeeringConnectionInput) awsec2.DeleteVpcPeeringConnectionRequest {
+						return awsec2.DeleteVpcPeeringConnectionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DeleteVpcPeeringConnectionOutput{}},
+						}
+					},
+				},
+				cr: pcx(withExternalName(pcxID)),
+			},
+			want: want{
+				cr: pcx(withExternalName(pcxID), withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"AlreadyDeleting": {
+			reason: "A VPC peering connection that is already being deleted should not be deleted again.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{},
+				cr: pcx(withExternalName(pcxID),
+					withStatus(v1alpha4.VPCPeeringConnectionObservation{Status: string(awsec2.VpcPeeringConnectionStateReasonCodeDeleting)})),
+			},
+			want: want{
+				cr: pcx(withExternalName(pcxID),
+					withStatus(v1alpha4.VPCPeeringConnectionObservation{Status: string(awsec2.VpcPeeringConnectionStateReasonCodeDeleting)}),
+					withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"NotFound": {
+			reason: "VPC peering connections that are not found should be considered deleted.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDelete: func(input *awsec2.DeleteVpcPeeringConnectionInput) awsec2.DeleteVpcPeeringConnectionRequest {
+						return awsec2.DeleteVpcPeeringConnectionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: awserr.New(ec2.VPCPeeringConnectionIDNotFound, "", nil)},
+						}
+					},
+				},
+				cr: pcx(withExternalName(pcxID)),
+			},
+			want: want{
+				cr: pcx(withExternalName(pcxID), withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"DeleteFail": {
+			reason: "Errors deleting the VPC peering connection should be returned.",
+			args: args{
+				pcx: &fake.MockVPCPeeringConnectionClient{
+					MockDelete: func(input *awsec2.DeleteVpcPeeringConnectionInput) awsec2.DeleteVpcPeeringConnectionRequest {
+						return awsec2.DeleteVpcPeeringConnectionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: pcx(withExternalName(pcxID)),
+			},
+			want: want{
+				cr:  pcx(withExternalName(pcxID), withConditions(runtimev1alpha1.Deleting())),
+				err: errors.Wrap(errBoom, errDelete),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.pcx, accepter: tc.accepter}
+			err := e.Delete(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -29,17 +29,19 @@ import (
 	"github.com/crossplane/crossplane/apis/workload/v1alpha1"
 
 	"github.com/crossplane/provider-aws/apis/eks/v1beta1"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 // SetupClusterTarget adds a controller that propagates EKS Cluster connection
 // secrets to the connection secrets of their targets.
-func SetupClusterTarget(mgr ctrl.Manager, l logging.Logger) error {
+func SetupClusterTarget(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := target.ControllerName(v1beta1.ClusterGroupKind)
 	p := resource.NewPredicates(resource.HasManagedResourceReferenceKind(resource.ManagedKind(v1beta1.ClusterGroupVersionKind)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(strings.ToLower(fmt.Sprintf("kubernetestarget.%s.%s", v1beta1.ClusterKind, v1beta1.Group))).
-		For(&v1alpha1.KubernetesTarget{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.KubernetesTarget{}, o.Selected()).
 		WithEventFilter(p).
 		Complete(target.NewReconciler(mgr,
 			resource.TargetKind(v1alpha1.KubernetesTargetGroupVersionKind),
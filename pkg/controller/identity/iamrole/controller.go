@@ -23,7 +23,6 @@ import (
 	awsiam "github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -35,17 +34,19 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	v1beta1 "github.com/crossplane/provider-aws/apis/identity/v1beta1"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/iam"
-	"github.com/crossplane/provider-aws/pkg/controller/utils"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
 	errUnexpectedObject = "The managed resource is not an IAMRole resource"
-	errClient           = "cannot create a new IAMRole client"
 	errGet              = "failed to get IAMRole with name"
 	errCreate           = "failed to create the IAMRole resource"
 	errDelete           = "failed to delete the IAMRole resource"
 	errUpdate           = "failed to update the IAMRole resource"
+	errTag              = "failed to tag the IAMRole resource"
+	errPatch            = "failed to create a patch for the IAMRole resource"
 	errSDK              = "empty IAMRole received from IAM API"
 
 	errKubeUpdateFailed = "cannot late initialize IAMRole"
@@ -53,45 +54,25 @@ const (
 )
 
 // SetupIAMRole adds a controller that reconciles IAMRoles.
-func SetupIAMRole(mgr ctrl.Manager, l logging.Logger) error {
+func SetupIAMRole(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1beta1.IAMRoleGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1beta1.IAMRole{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.IAMRole{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.IAMRoleGroupVersionKind),
-			managed.WithExternalConnecter(&connector{client: mgr.GetClient(), newClientFn: iam.NewRoleClient, awsConfigFn: utils.RetrieveAwsConfigFromProvider}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: iam.NewRoleClient(cfg), kube: mgr.GetClient()}, nil
+			})),
+			managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
-type connector struct {
-	client      client.Client
-	newClientFn func(*aws.Config) (iam.RoleClient, error)
-	awsConfigFn func(context.Context, client.Reader, *corev1.ObjectReference) (*aws.Config, error)
-}
-
-func (conn *connector) Connect(ctx context.Context, mgd resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mgd.(*v1beta1.IAMRole)
-	if !ok {
-		return nil, errors.New(errUnexpectedObject)
-	}
-
-	awsconfig, err := conn.awsConfigFn(ctx, conn.client, cr.Spec.ProviderReference)
-	if err != nil {
-		return nil, err
-	}
-
-	c, err := conn.newClientFn(awsconfig)
-	if err != nil {
-		return nil, errors.Wrap(err, errClient)
-	}
-	return &external{c, conn.client}, nil
-}
-
 type external struct {
 	client iam.RoleClient
 	kube   client.Client
@@ -170,6 +151,9 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 	}
 
 	patch, err := iam.CreatePatch(observed.Role, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errPatch)
+	}
 
 	if patch.Description != nil || patch.MaxSessionDuration != nil {
 		_, err = e.client.UpdateRoleRequest(&awsiam.UpdateRoleInput{
@@ -188,9 +172,24 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 			PolicyDocument: &cr.Spec.ForProvider.AssumeRolePolicyDocument,
 			RoleName:       aws.String(meta.GetExternalName(cr)),
 		}).Send(ctx)
+
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+		}
 	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	if len(patch.Tags) != 0 {
+		_, err = e.client.TagRoleRequest(&awsiam.TagRoleInput{
+			RoleName: aws.String(meta.GetExternalName(cr)),
+			Tags:     iam.GenerateRoleTags(cr.Spec.ForProvider.Tags),
+		}).Send(ctx)
+
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errTag)
+		}
+	}
+
+	return managed.ExternalUpdate{}, nil
 }
 
 func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
@@ -207,3 +206,11 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 
 	return errors.Wrap(resource.Ignore(iam.IsErrorNotFound, err), errDelete)
 }
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1beta1.IAMRole)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
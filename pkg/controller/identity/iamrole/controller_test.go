@@ -27,7 +27,6 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
@@ -43,7 +42,6 @@ import (
 
 const (
 	providerName = "aws-creds"
-	testRegion   = "us-east-1"
 )
 
 var (
@@ -98,6 +96,12 @@ func withDescription() roleModifier {
 	}
 }
 
+func withTags(tags ...v1beta1.Tag) roleModifier {
+	return func(r *v1beta1.IAMRole) {
+		r.Spec.ForProvider.Tags = tags
+	}
+}
+
 func role(m ...roleModifier) *v1beta1.IAMRole {
 	cr := &v1beta1.IAMRole{
 		Spec: v1beta1.IAMRoleSpec{
@@ -112,79 +116,6 @@ func role(m ...roleModifier) *v1beta1.IAMRole {
 	return cr
 }
 
-func TestConnect(t *testing.T) {
-
-	type args struct {
-		newClientFn func(*aws.Config) (iam.RoleClient, error)
-		awsConfigFn func(context.Context, client.Reader, *corev1.ObjectReference) (*aws.Config, error)
-		cr          resource.Managed
-	}
-	type want struct {
-		err error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"ValidInput": {
-			args: args{
-				newClientFn: func(config *aws.Config) (iam.RoleClient, error) {
-					if diff := cmp.Diff(testRegion, config.Region); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return nil, nil
-				},
-				awsConfigFn: func(_ context.Context, _ client.Reader, p *corev1.ObjectReference) (*aws.Config, error) {
-					if diff := cmp.Diff(providerName, p.Name); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return &aws.Config{Region: testRegion}, nil
-				},
-				cr: role(),
-			},
-		},
-		"InValidInput": {
-			args: args{
-				cr: unexpecedItem,
-			},
-			want: want{
-				err: errors.New(errUnexpectedObject),
-			},
-		},
-		"ProviderFailure": {
-			args: args{
-				newClientFn: func(config *aws.Config) (iam.RoleClient, error) {
-					if diff := cmp.Diff(testRegion, config.Region); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return nil, errBoom
-				},
-				awsConfigFn: func(_ context.Context, _ client.Reader, p *corev1.ObjectReference) (*aws.Config, error) {
-					if diff := cmp.Diff(providerName, p.Name); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return &aws.Config{Region: testRegion}, nil
-				},
-				cr: role(),
-			},
-			want: want{
-				err: errors.Wrap(errBoom, errClient),
-			},
-		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			c := &connector{newClientFn: tc.newClientFn, awsConfigFn: tc.awsConfigFn}
-			_, err := c.Connect(context.Background(), tc.args.cr)
-			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
 func TestObserve(t *testing.T) {
 
 	type want struct {
@@ -448,6 +379,29 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(errBoom, errUpdate),
 			},
 		},
+		"ClientTagRoleError": {
+			args: args{
+				iam: &fake.MockRoleClient{
+					MockGetRoleRequest: func(input *awsiam.GetRoleInput) awsiam.GetRoleRequest {
+						return awsiam.GetRoleRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsiam.GetRoleOutput{
+								Role: &awsiam.Role{},
+							}},
+						}
+					},
+					MockTagRoleRequest: func(input *awsiam.TagRoleInput) awsiam.TagRoleRequest {
+						return awsiam.TagRoleRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: role(withTags(v1beta1.Tag{Key: "cost-center", Value: "cool"})),
+			},
+			want: want{
+				cr:  role(withTags(v1beta1.Tag{Key: "cost-center", Value: "cool"})),
+				err: errors.Wrap(errBoom, errTag),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -23,8 +23,6 @@ import (
 	awsiam "github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -36,78 +34,44 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/identity/v1alpha1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/iam"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 )
 
 const (
-	errNotUserInstance = "managed resource is not an IAMUser custom resource"
-
-	errCreateUserClient  = "cannot create IAM User client"
-	errGetProvider       = "cannot get provider"
-	errGetProviderSecret = "cannot get provider secret"
-
 	errUnexpectedObject = "The managed resource is not an IAM User resource"
 	errGet              = "failed to get IAM User with name"
 	errCreate           = "failed to create the IAM User resource"
 	errDelete           = "failed to delete the IAM User resource"
 	errUpdate           = "failed to update the IAM User resource"
+	errListTags         = "failed to list the tags of the IAM User resource"
+	errTag              = "failed to tag the IAM User resource"
+	errUntag            = "failed to untag the IAM User resource"
 	errSDK              = "empty IAM User received from IAM API"
 
 	errKubeUpdateFailed = "cannot late initialize IAM User"
 )
 
 // SetupIAMUser adds a controller that reconciles Users.
-func SetupIAMUser(mgr ctrl.Manager, l logging.Logger) error {
+func SetupIAMUser(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(v1alpha1.IAMUserGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.IAMUser{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.IAMUser{}, o.Selected()).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.IAMUserGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: iam.NewUserClient}),
+			managed.WithExternalConnecter(awsclients.NewConnector(mgr.GetClient(), func(cfg *aws.Config) (managed.ExternalClient, error) {
+				return &external{client: iam.NewUserClient(cfg), kube: mgr.GetClient()}, nil
+			})),
+			managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), awsclients.NewTagger(mgr.GetClient(), specTags)),
 			managed.WithConnectionPublishers(),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
-type connector struct {
-	kube        client.Client
-	newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (iam.UserClient, error)
-}
-
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1alpha1.IAMUser)
-	if !ok {
-		return nil, errors.New(errNotUserInstance)
-	}
-
-	p := &awsv1alpha3.Provider{}
-	if err := c.kube.Get(ctx, meta.NamespacedNameOf(cr.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
-	}
-
-	if aws.BoolValue(p.Spec.UseServiceAccount) {
-		userClient, err := c.newClientFn(ctx, []byte{}, p.Spec.Region, awsclients.UsePodServiceAccount)
-		return &external{client: userClient, kube: c.kube}, errors.Wrap(err, errCreateUserClient)
-	}
-
-	if p.GetCredentialsSecretReference() == nil {
-		return nil, errors.New(errGetProviderSecret)
-	}
-
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := c.kube.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
-	}
-
-	userClient, err := c.newClientFn(ctx, s.Data[p.Spec.CredentialsSecretRef.Key], p.Spec.Region, awsclients.UseProviderSecret)
-	return &external{client: userClient, kube: c.kube}, errors.Wrap(err, errCreateUserClient)
-}
-
 type external struct {
 	kube   client.Client
 	client iam.UserClient
@@ -140,6 +104,11 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 		}
 	}
 
+	add, remove, err := e.diffTags(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	cr.SetConditions(runtimev1alpha1.Available())
 
 	cr.Status.AtProvider = v1alpha1.IAMUserObservation{
@@ -149,7 +118,7 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: aws.StringValue(cr.Spec.ForProvider.Path) == aws.StringValue(user.Path),
+		ResourceUpToDate: aws.StringValue(cr.Spec.ForProvider.Path) == aws.StringValue(user.Path) && len(add) == 0 && len(remove) == 0,
 	}, nil
 }
 
@@ -176,12 +145,49 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
 	}
 
-	_, err := e.client.UpdateUserRequest(&awsiam.UpdateUserInput{
+	if _, err := e.client.UpdateUserRequest(&awsiam.UpdateUserInput{
 		NewPath:  cr.Spec.ForProvider.Path,
 		UserName: aws.String(meta.GetExternalName(cr)),
-	}).Send(ctx)
+	}).Send(ctx); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+
+	add, remove, err := e.diffTags(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	if len(remove) != 0 {
+		if _, err := e.client.UntagUserRequest(&awsiam.UntagUserInput{
+			UserName: aws.String(meta.GetExternalName(cr)),
+			TagKeys:  remove,
+		}).Send(ctx); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUntag)
+		}
+	}
+
+	if len(add) != 0 {
+		input := &awsiam.TagUserInput{UserName: aws.String(meta.GetExternalName(cr))}
+		awsclients.SetTagMap(&input.Tags, add)
+		if _, err := e.client.TagUserRequest(input).Send(ctx); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errTag)
+		}
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+// diffTags returns the tags that must be added to and the keys of the tags
+// that must be removed from the IAM User for its tags to match the spec.
+func (e *external) diffTags(ctx context.Context, cr *v1alpha1.IAMUser) (map[string]string, []string, error) {
+	res, err := e.client.ListUserTagsRequest(&awsiam.ListUserTagsInput{
+		UserName: aws.String(meta.GetExternalName(cr)),
+	}).Send(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errListTags)
+	}
+	add, remove := awsclients.DiffTags(awsclients.TagMap(cr.Spec.ForProvider.Tags), awsclients.TagMap(res.Tags))
+	return add, remove, nil
 }
 
 func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
@@ -198,3 +204,11 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 
 	return errors.Wrap(resource.Ignore(iam.IsErrorNotFound, err), errDelete)
 }
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	cr, ok := mg.(*v1alpha1.IAMUser)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	return &cr.Spec.ForProvider.Tags, nil
+}
@@ -22,7 +22,6 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsiam "github.com/aws/aws-sdk-go-v2/service/iam"
@@ -31,16 +30,11 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
-	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-aws/apis/identity/v1alpha1"
-	awsv1alpha3 "github.com/crossplane/provider-aws/apis/v1alpha3"
-	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/iam"
 	"github.com/crossplane/provider-aws/pkg/clients/iam/fake"
 )
@@ -53,13 +47,7 @@ var (
 )
 
 const (
-	providerName    = "aws-creds"
-	secretNamespace = "crossplane-system"
-	testRegion      = "us-east-1"
-
-	connectionSecretName = "my-little-secret"
-	secretKey            = "credentials"
-	credData             = "confidential!"
+	providerName = "aws-creds"
 )
 
 type args struct {
@@ -77,6 +65,23 @@ func withExternalName(name string) userModifier {
 	return func(r *v1alpha1.IAMUser) { meta.SetExternalName(r, name) }
 }
 
+func withTags(tags ...v1alpha1.Tag) userModifier {
+	return func(r *v1alpha1.IAMUser) { r.Spec.ForProvider.Tags = tags }
+}
+
+func listTags(tags []awsiam.Tag, err error) func(*awsiam.ListUserTagsInput) awsiam.ListUserTagsRequest {
+	return func(_ *awsiam.ListUserTagsInput) awsiam.ListUserTagsRequest {
+		if err != nil {
+			return awsiam.ListUserTagsRequest{
+				Request: &aws.Request{HTTPRequest: &http.Request{}, Error: err},
+			}
+		}
+		return awsiam.ListUserTagsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsiam.ListUserTagsOutput{Tags: tags}},
+		}
+	}
+}
+
 func user(m ...userModifier) *v1alpha1.IAMUser {
 	cr := &v1alpha1.IAMUser{
 		Spec: v1alpha1.IAMUserSpec{
@@ -91,183 +96,65 @@ func user(m ...userModifier) *v1alpha1.IAMUser {
 	return cr
 }
 
-func TestConnect(t *testing.T) {
-	secret := corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      connectionSecretName,
-			Namespace: secretNamespace,
-		},
-		Data: map[string][]byte{
-			secretKey: []byte(credData),
-		},
-	}
+func TestObserve(t *testing.T) {
 
-	providerSA := func(saVal bool) awsv1alpha3.Provider {
-		return awsv1alpha3.Provider{
-			Spec: awsv1alpha3.ProviderSpec{
-				Region:            testRegion,
-				UseServiceAccount: &saVal,
-				ProviderSpec: runtimev1alpha1.ProviderSpec{
-					CredentialsSecretRef: &runtimev1alpha1.SecretKeySelector{
-						SecretReference: runtimev1alpha1.SecretReference{
-							Namespace: secretNamespace,
-							Name:      connectionSecretName,
-						},
-						Key: secretKey,
-					},
-				},
-			},
-		}
-	}
-	type args struct {
-		kube        client.Client
-		newClientFn func(ctx context.Context, credentials []byte, region string, auth awsclients.AuthMethod) (iam.UserClient, error)
-		cr          *v1alpha1.IAMUser
-	}
 	type want struct {
-		err error
+		cr     resource.Managed
+		result managed.ExternalObservation
+		err    error
 	}
 
 	cases := map[string]struct {
 		args
 		want
 	}{
-		"Successful": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							secret.DeepCopyInto(obj.(*corev1.Secret))
-							return nil
-						}
-						return errBoom
-					},
-				},
-				newClientFn: func(_ context.Context, credentials []byte, region string, _ awsclients.AuthMethod) (i iam.UserClient, e error) {
-					if diff := cmp.Diff(credData, string(credentials)); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					if diff := cmp.Diff(testRegion, region); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return nil, nil
-				},
-				cr: user(),
-			},
-		},
-		"SuccessfulUseServiceAccount": {
+		"VaildInput": {
 			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						if key == (client.ObjectKey{Name: providerName}) {
-							p := providerSA(true)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
+				iam: &fake.MockUserClient{
+					MockGetUser: func(input *awsiam.GetUserInput) awsiam.GetUserRequest {
+						return awsiam.GetUserRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsiam.GetUserOutput{
+								User: &awsiam.User{},
+							}},
 						}
-						return errBoom
 					},
+					MockListTags: listTags([]awsiam.Tag{{Key: aws.String("k"), Value: aws.String("v")}}, nil),
 				},
-				newClientFn: func(_ context.Context, credentials []byte, region string, _ awsclients.AuthMethod) (i iam.UserClient, e error) {
-					if diff := cmp.Diff("", string(credentials)); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					if diff := cmp.Diff(testRegion, region); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					return nil, nil
-				},
-				cr: user(),
-			},
-		},
-		"ProviderGetFailed": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						return errBoom
-					},
-				},
-				cr: user(),
+				cr: user(withExternalName(userName), withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errGetProvider),
-			},
-		},
-		"SecretGetFailed": {
-			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							return errBoom
-						default:
-							return nil
-						}
-					},
+				cr: user(withExternalName(userName), withTags(v1alpha1.Tag{Key: "k", Value: "v"}),
+					withConditions(corev1alpha1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
 				},
-				cr: user(),
-			},
-			want: want{
-				err: errors.Wrap(errBoom, errGetProviderSecret),
 			},
 		},
-		"SecretGetFailedNil": {
+		"TagsOutOfDate": {
 			args: args{
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
-						switch key {
-						case client.ObjectKey{Name: providerName}:
-							p := providerSA(false)
-							p.SetCredentialsSecretReference(nil)
-							p.DeepCopyInto(obj.(*awsv1alpha3.Provider))
-							return nil
-						case client.ObjectKey{Namespace: secretNamespace, Name: connectionSecretName}:
-							return errBoom
-						default:
-							return nil
+				iam: &fake.MockUserClient{
+					MockGetUser: func(input *awsiam.GetUserInput) awsiam.GetUserRequest {
+						return awsiam.GetUserRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsiam.GetUserOutput{
+								User: &awsiam.User{},
+							}},
 						}
 					},
+					MockListTags: listTags([]awsiam.Tag{{Key: aws.String("k"), Value: aws.String("old")}}, nil),
 				},
-				cr: user(),
+				cr: user(withExternalName(userName), withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
 			},
 			want: want{
-				err: errors.New(errGetProviderSecret),
+				cr: user(withExternalName(userName), withTags(v1alpha1.Tag{Key: "k", Value: "v"}),
+					withConditions(corev1alpha1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			c := &connector{kube: tc.kube, newClientFn: tc.newClientFn}
-			_, err := c.Connect(context.Background(), tc.args.cr)
-			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestObserve(t *testing.T) {
-
-	type want struct {
-		cr     resource.Managed
-		result managed.ExternalObservation
-		err    error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"VaildInput": {
+		"ListTagsError": {
 			args: args{
 				iam: &fake.MockUserClient{
 					MockGetUser: func(input *awsiam.GetUserInput) awsiam.GetUserRequest {
@@ -277,16 +164,13 @@ func TestObserve(t *testing.T) {
 							}},
 						}
 					},
+					MockListTags: listTags(nil, errBoom),
 				},
 				cr: user(withExternalName(userName)),
 			},
 			want: want{
-				cr: user(withExternalName(userName),
-					withConditions(corev1alpha1.Available())),
-				result: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
-				},
+				cr:  user(withExternalName(userName)),
+				err: errors.Wrap(errBoom, errListTags),
 			},
 		},
 		"InValidInput": {
@@ -428,6 +312,7 @@ func TestUpdate(t *testing.T) {
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsiam.UpdateUserOutput{}},
 						}
 					},
+					MockListTags: listTags(nil, nil),
 				},
 				cr: user(withExternalName(userName)),
 			},
@@ -435,6 +320,72 @@ func TestUpdate(t *testing.T) {
 				cr: user(withExternalName(userName)),
 			},
 		},
+		"UpdateTags": {
+			args: args{
+				iam: &fake.MockUserClient{
+					MockUpdateUser: func(input *awsiam.UpdateUserInput) awsiam.UpdateUserRequest {
+						return awsiam.UpdateUserRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsiam.UpdateUserOutput{}},
+						}
+					},
+					MockListTags: listTags([]awsiam.Tag{
+						{Key: aws.String("k"), Value: aws.String("old")},
+						{Key: aws.String("gone"), Value: aws.String("v")},
+					}, nil),
+					MockUntagUser: func(input *awsiam.UntagUserInput) awsiam.UntagUserRequest {
+						want := &awsiam.UntagUserInput{UserName: aws.String(userName), TagKeys: []string{"gone"}}
+						if diff := cmp.Diff(want, input); diff != "" {
+							return awsiam.UntagUserRequest{
+								Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(diff)},
+							}
+						}
+						return awsiam.UntagUserRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsiam.UntagUserOutput{}},
+						}
+					},
+					MockTagUser: func(input *awsiam.TagUserInput) awsiam.TagUserRequest {
+						want := &awsiam.TagUserInput{
+							UserName: aws.String(userName),
+							Tags:     []awsiam.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+						}
+						if diff := cmp.Diff(want, input); diff != "" {
+							return awsiam.TagUserRequest{
+								Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(diff)},
+							}
+						}
+						return awsiam.TagUserRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsiam.TagUserOutput{}},
+						}
+					},
+				},
+				cr: user(withExternalName(userName), withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
+			},
+			want: want{
+				cr: user(withExternalName(userName), withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
+			},
+		},
+		"TagError": {
+			args: args{
+				iam: &fake.MockUserClient{
+					MockUpdateUser: func(input *awsiam.UpdateUserInput) awsiam.UpdateUserRequest {
+						return awsiam.UpdateUserRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsiam.UpdateUserOutput{}},
+						}
+					},
+					MockListTags: listTags(nil, nil),
+					MockTagUser: func(input *awsiam.TagUserInput) awsiam.TagUserRequest {
+						return awsiam.TagUserRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: user(withExternalName(userName), withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
+			},
+			want: want{
+				cr:  user(withExternalName(userName), withTags(v1alpha1.Tag{Key: "k", Value: "v"})),
+				err: errors.Wrap(errBoom, errTag),
+			},
+		},
 		"InValidInput": {
 			args: args{
 				cr: unexpecedItem,
@@ -0,0 +1,294 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/endpoints"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/aws-sdk-go-v2/service/sts/stsiface"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
+)
+
+const (
+	reconcileTimeout = 1 * time.Minute
+
+	// DefaultValidationInterval is the default interval at which the
+	// credentials of a Provider are validated.
+	DefaultValidationInterval = 5 * time.Minute
+)
+
+// Error messages.
+const (
+	errGetProvider       = "cannot get provider"
+	errListProviders     = "cannot list providers"
+	errUpdateStatus      = "cannot update provider status"
+	errGetCallerIdentity = "cannot get caller identity"
+	errFmtUnknownRegion  = "%q is not a known AWS region"
+)
+
+// Event reasons.
+const (
+	reasonValidationFailed event.Reason = "CannotValidateCredentials"
+)
+
+// ControllerName returns the name of the controller that validates Providers.
+func ControllerName() string {
+	return "provider/" + strings.ToLower(v1alpha3.ProviderGroupKind)
+}
+
+// SetupProvider adds a controller that validates the credentials of
+// Providers, and revalidates them whenever their credentials Secret changes.
+// The controller also discards the AWS configs cached for Providers that are
+// deleted or whose spec changes.
+func SetupProvider(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
+	name := ControllerName()
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha3.Provider{}, o.Selected()).
+		Watches(&source.Kind{Type: &v1alpha3.Provider{}}, &ConfigCacheInvalidator{cache: awsclients.DefaultConfigCache}).
+		// Connectors read credentials Secrets through the manager's cached
+		// client, so the Secrets this watch informs on are cached anyway.
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: &SecretMapper{client: mgr.GetClient(), log: l.WithValues("controller", name)},
+		}, builder.WithPredicates(resource.NewPredicates(IsCredentialsSecretCandidate))).
+		Complete(NewReconciler(mgr,
+			WithLogger(l.WithValues("controller", name)),
+			WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+// IsCredentialsSecretCandidate returns false for Secrets of types that are
+// managed by Kubernetes or hold other kinds of credentials, which therefore
+// can't be the credentials Secret of a Provider. Service account tokens in
+// particular are numerous and frequently updated.
+func IsCredentialsSecretCandidate(obj runtime.Object) bool {
+	s, ok := obj.(*corev1.Secret)
+	if !ok {
+		return false
+	}
+	switch s.Type {
+	case corev1.SecretTypeServiceAccountToken,
+		corev1.SecretTypeDockercfg,
+		corev1.SecretTypeDockerConfigJson,
+		corev1.SecretTypeTLS,
+		corev1.SecretTypeBootstrapToken:
+		return false
+	}
+	return true
+}
+
+// A SecretMapper enqueues a request for each Provider that references a
+// Secret.
+type SecretMapper struct {
+	client client.Reader
+	log    logging.Logger
+}
+
+// Map a Secret to requests for the Providers that reference it.
+func (m *SecretMapper) Map(o handler.MapObject) []reconcile.Request {
+	l := &v1alpha3.ProviderList{}
+	if err := m.client.List(context.TODO(), l); err != nil {
+		m.log.Debug(errListProviders, "error", err)
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for _, p := range l.Items {
+		ref := p.GetCredentialsSecretReference()
+		if ref == nil || ref.Namespace != o.Meta.GetNamespace() || ref.Name != o.Meta.GetName() {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: p.GetName()}})
+	}
+	return reqs
+}
+
+// A ConfigCacheInvalidator discards the AWS configs cached for a Provider
+// when it is deleted or its spec changes. It never enqueues requests.
+type ConfigCacheInvalidator struct {
+	cache *awsclients.ConfigCache
+}
+
+// Create does nothing; there is nothing cached for a new Provider.
+func (h *ConfigCacheInvalidator) Create(_ ctrlevent.CreateEvent, _ workqueue.RateLimitingInterface) {}
+
+// Update discards the configs cached for a Provider whose spec changed.
+func (h *ConfigCacheInvalidator) Update(e ctrlevent.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	if e.MetaOld == nil || e.MetaNew == nil || e.MetaOld.GetGeneration() == e.MetaNew.GetGeneration() {
+		return
+	}
+	h.cache.Invalidate(e.MetaOld.GetUID())
+}
+
+// Delete discards the configs cached for a deleted Provider.
+func (h *ConfigCacheInvalidator) Delete(e ctrlevent.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	if e.Meta == nil {
+		return
+	}
+	h.cache.Invalidate(e.Meta.GetUID())
+}
+
+// Generic does nothing.
+func (h *ConfigCacheInvalidator) Generic(_ ctrlevent.GenericEvent, _ workqueue.RateLimitingInterface) {
+}
+
+// Reconciler validates the credentials of Providers by using them to call
+// sts:GetCallerIdentity, and reports the result in the Provider's status.
+type Reconciler struct {
+	client      client.Client
+	newClientFn func(cfg *aws.Config) stsiface.ClientAPI
+	interval    time.Duration
+
+	log    logging.Logger
+	record event.Recorder
+}
+
+// A ReconcilerOption configures a Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithLogger specifies how the Reconciler should log messages.
+func WithLogger(l logging.Logger) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.log = l
+	}
+}
+
+// WithRecorder specifies how the Reconciler should record events.
+func WithRecorder(er event.Recorder) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.record = er
+	}
+}
+
+// WithValidationInterval specifies how often the Reconciler should validate
+// the credentials of a Provider.
+func WithValidationInterval(d time.Duration) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.interval = d
+	}
+}
+
+// WithSTSClientFn specifies how the Reconciler should create the STS client it
+// uses to validate credentials.
+func WithSTSClientFn(fn func(cfg *aws.Config) stsiface.ClientAPI) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.newClientFn = fn
+	}
+}
+
+// NewReconciler returns a Reconciler that validates the credentials of
+// Providers.
+func NewReconciler(m ctrl.Manager, o ...ReconcilerOption) *Reconciler {
+	r := &Reconciler{
+		client:      m.GetClient(),
+		newClientFn: func(cfg *aws.Config) stsiface.ClientAPI { return sts.New(*cfg) },
+		interval:    DefaultValidationInterval,
+		log:         logging.NewNopLogger(),
+		record:      event.NewNopRecorder(),
+	}
+
+	for _, ro := range o {
+		ro(r)
+	}
+
+	return r
+}
+
+// Reconcile a Provider by validating its credentials.
+func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer cancel()
+
+	p := &v1alpha3.Provider{}
+	if err := r.client.Get(ctx, req.NamespacedName, p); err != nil {
+		// There's nothing to validate if the Provider no longer exists.
+		log.Debug("Cannot get provider", "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetProvider)
+	}
+
+	if err := r.validate(ctx, p); err != nil {
+		log.Debug("Cannot validate provider credentials", "error", err)
+		r.record.Event(p, event.Warning(reasonValidationFailed, err))
+		p.Status.AccountID = ""
+		p.Status.ARN = ""
+		p.Status.ValidatedRegion = ""
+		p.Status.SetConditions(runtimev1alpha1.Unavailable().WithMessage(err.Error()))
+		return reconcile.Result{RequeueAfter: r.interval}, errors.Wrap(r.client.Status().Update(ctx, p), errUpdateStatus)
+	}
+
+	log.Debug("Validated provider credentials", "account-id", p.Status.AccountID, "arn", p.Status.ARN)
+	p.Status.SetConditions(runtimev1alpha1.Available())
+	return reconcile.Result{RequeueAfter: r.interval}, errors.Wrap(r.client.Status().Update(ctx, p), errUpdateStatus)
+}
+
+// validate the credentials of the supplied Provider, recording the identity
+// they belong to in its status.
+func (r *Reconciler) validate(ctx context.Context, p *v1alpha3.Provider) error {
+	// Regions can't be validated when a custom endpoint is used, because AWS
+	// compatible APIs may use regions that AWS does not.
+	if p.Spec.Endpoint == nil {
+		resolver := endpoints.NewDefaultResolver()
+		resolver.StrictMatching = true
+		if _, err := resolver.ResolveEndpoint(sts.EndpointsID, p.Spec.Region); err != nil {
+			return errors.Errorf(errFmtUnknownRegion, p.Spec.Region)
+		}
+	}
+
+	cfg, err := awsclients.GetConfig(ctx, r.client, &corev1.ObjectReference{Name: p.GetName()})
+	if err != nil {
+		return err
+	}
+
+	rsp, err := r.newClientFn(cfg).GetCallerIdentityRequest(&sts.GetCallerIdentityInput{}).Send(ctx)
+	if err != nil {
+		return errors.Wrap(err, errGetCallerIdentity)
+	}
+
+	p.Status.AccountID = aws.StringValue(rsp.Account)
+	p.Status.ARN = aws.StringValue(rsp.Arn)
+	p.Status.ValidatedRegion = cfg.Region
+	return nil
+}
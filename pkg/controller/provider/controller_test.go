@@ -0,0 +1,402 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/aws-sdk-go-v2/service/sts/stsiface"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/v1alpha3"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+const (
+	providerName    = "cool-provider"
+	secretNamespace = "cool-namespace"
+	secretName      = "cool-secret"
+	secretKey       = "credentials"
+	region          = "us-east-1"
+	accountID       = "123456789012"
+	callerARN       = "arn:aws:iam::123456789012:user/cool"
+	credentials     = "[default]\naws_access_key_id = id\naws_secret_access_key = secret"
+)
+
+var errBoom = errors.New("boom")
+
+type mockSTSClient struct {
+	stsiface.ClientAPI
+	out *sts.GetCallerIdentityOutput
+	err error
+}
+
+func (c *mockSTSClient) GetCallerIdentityRequest(_ *sts.GetCallerIdentityInput) sts.GetCallerIdentityRequest {
+	return sts.GetCallerIdentityRequest{
+		Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: c.out, Error: c.err},
+	}
+}
+
+type providerModifier func(*v1alpha3.Provider)
+
+func withRegion(r string) providerModifier {
+	return func(p *v1alpha3.Provider) { p.Spec.Region = r }
+}
+
+func withEndpoint(url string) providerModifier {
+	return func(p *v1alpha3.Provider) { p.Spec.Endpoint = &v1alpha3.EndpointConfig{URL: &url} }
+}
+
+func withConditions(c ...runtimev1alpha1.Condition) providerModifier {
+	return func(p *v1alpha3.Provider) { p.Status.SetConditions(c...) }
+}
+
+func withStatus(accountID, arn, region string) providerModifier {
+	return func(p *v1alpha3.Provider) {
+		p.Status.AccountID = accountID
+		p.Status.ARN = arn
+		p.Status.ValidatedRegion = region
+	}
+}
+
+func provider(m ...providerModifier) *v1alpha3.Provider {
+	p := &v1alpha3.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: providerName},
+		Spec: v1alpha3.ProviderSpec{
+			Region: region,
+			ProviderSpec: runtimev1alpha1.ProviderSpec{
+				CredentialsSecretRef: &runtimev1alpha1.SecretKeySelector{
+					SecretReference: runtimev1alpha1.SecretReference{Namespace: secretNamespace, Name: secretName},
+					Key:             secretKey,
+				},
+			},
+		},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func mockGet(p *v1alpha3.Provider) test.MockGetFn {
+	return func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+		switch o := obj.(type) {
+		case *v1alpha3.Provider:
+			p.DeepCopyInto(o)
+		case *corev1.Secret:
+			if key != (client.ObjectKey{Namespace: secretNamespace, Name: secretName}) {
+				return errBoom
+			}
+			o.Data = map[string][]byte{secretKey: []byte(credentials)}
+		}
+		return nil
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	type args struct {
+		kube client.Client
+		sts  *mockSTSClient
+	}
+	type want struct {
+		result reconcile.Result
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ProviderNotFound": {
+			reason: "We should return early without error if the Provider does not exist.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, providerName))},
+			},
+			want: want{result: reconcile.Result{}},
+		},
+		"GetProviderError": {
+			reason: "Errors getting the Provider should be returned.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{result: reconcile.Result{}, err: errors.Wrap(errBoom, errGetProvider)},
+		},
+		"UnknownRegion": {
+			reason: "A Provider with an unknown region should be marked unavailable.",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: mockGet(provider(withRegion("us-nowhere-1"), withStatus(accountID, callerARN, region))),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil, func(obj runtime.Object) error {
+						want := provider(withRegion("us-nowhere-1"), withConditions(runtimev1alpha1.Unavailable().WithMessage(errors.Errorf(errFmtUnknownRegion, "us-nowhere-1").Error())))
+						if diff := cmp.Diff(want, obj, test.EquateConditions()); diff != "" {
+							t.Errorf("-want, +got:\n%s", diff)
+						}
+						return nil
+					}),
+				},
+			},
+			want: want{result: reconcile.Result{RequeueAfter: DefaultValidationInterval}},
+		},
+		"GetCallerIdentityError": {
+			reason: "A Provider whose credentials cannot be used to get the caller identity should be marked unavailable.",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: mockGet(provider()),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil, func(obj runtime.Object) error {
+						want := provider(withConditions(runtimev1alpha1.Unavailable().WithMessage(errors.Wrap(errBoom, errGetCallerIdentity).Error())))
+						if diff := cmp.Diff(want, obj, test.EquateConditions()); diff != "" {
+							t.Errorf("-want, +got:\n%s", diff)
+						}
+						return nil
+					}),
+				},
+				sts: &mockSTSClient{err: errBoom},
+			},
+			want: want{result: reconcile.Result{RequeueAfter: DefaultValidationInterval}},
+		},
+		"CustomEndpoint": {
+			reason: "The region of a Provider with a custom endpoint should not be validated.",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: mockGet(provider(withRegion("localstack"), withEndpoint("http://localstack:4566"))),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil, func(obj runtime.Object) error {
+						want := provider(withRegion("localstack"), withEndpoint("http://localstack:4566"), withStatus(accountID, callerARN, "localstack"), withConditions(runtimev1alpha1.Available()))
+						if diff := cmp.Diff(want, obj, test.EquateConditions()); diff != "" {
+							t.Errorf("-want, +got:\n%s", diff)
+						}
+						return nil
+					}),
+				},
+				sts: &mockSTSClient{out: &sts.GetCallerIdentityOutput{Account: aws.String(accountID), Arn: aws.String(callerARN)}},
+			},
+			want: want{result: reconcile.Result{RequeueAfter: DefaultValidationInterval}},
+		},
+		"Successful": {
+			reason: "A Provider with valid credentials should be marked available and record its caller identity.",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: mockGet(provider()),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil, func(obj runtime.Object) error {
+						want := provider(withStatus(accountID, callerARN, region), withConditions(runtimev1alpha1.Available()))
+						if diff := cmp.Diff(want, obj, test.EquateConditions()); diff != "" {
+							t.Errorf("-want, +got:\n%s", diff)
+						}
+						return nil
+					}),
+				},
+				sts: &mockSTSClient{out: &sts.GetCallerIdentityOutput{Account: aws.String(accountID), Arn: aws.String(callerARN)}},
+			},
+			want: want{result: reconcile.Result{RequeueAfter: DefaultValidationInterval}},
+		},
+		"UpdateStatusError": {
+			reason: "Errors updating the Provider's status should be returned.",
+			args: args{
+				kube: &test.MockClient{
+					MockGet:          mockGet(provider()),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(errBoom),
+				},
+				sts: &mockSTSClient{out: &sts.GetCallerIdentityOutput{Account: aws.String(accountID), Arn: aws.String(callerARN)}},
+			},
+			want: want{result: reconcile.Result{RequeueAfter: DefaultValidationInterval}, err: errors.Wrap(errBoom, errUpdateStatus)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewReconciler(&fake.Manager{Client: tc.args.kube},
+				WithSTSClientFn(func(_ *aws.Config) stsiface.ClientAPI { return tc.args.sts }))
+			got, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: providerName}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretMapper(t *testing.T) {
+	referenced := provider()
+	other := provider()
+	other.SetName("other-provider")
+	other.Spec.CredentialsSecretRef.Name = "other-secret"
+	noRef := provider()
+	noRef.SetName("no-ref-provider")
+	noRef.Spec.CredentialsSecretRef = nil
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		want   []reconcile.Request
+	}{
+		"ListError": {
+			reason: "No requests should be returned if Providers cannot be listed.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+		},
+		"Successful": {
+			reason: "A request should be returned for each Provider that references the Secret.",
+			kube: &test.MockClient{MockList: test.NewMockListFn(nil, func(obj runtime.Object) error {
+				obj.(*v1alpha3.ProviderList).Items = []v1alpha3.Provider{*referenced, *other, *noRef}
+				return nil
+			})},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: providerName}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &SecretMapper{client: tc.kube, log: logging.NewNopLogger()}
+			s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: secretNamespace, Name: secretName}}
+			got := m.Map(handler.MapObject{Meta: s, Object: s})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nm.Map(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConfigCacheInvalidator(t *testing.T) {
+	cached := func(uid string, generation int64) *v1alpha3.Provider {
+		return &v1alpha3.Provider{ObjectMeta: metav1.ObjectMeta{Name: providerName, UID: types.UID(uid), Generation: generation}}
+	}
+
+	cases := map[string]struct {
+		reason      string
+		event       func(h *ConfigCacheInvalidator)
+		invalidated bool
+	}{
+		"Deleted": {
+			reason: "The configs cached for a deleted Provider should be discarded.",
+			event: func(h *ConfigCacheInvalidator) {
+				p := cached("uid", 1)
+				h.Delete(ctrlevent.DeleteEvent{Meta: p, Object: p}, nil)
+			},
+			invalidated: true,
+		},
+		"SpecChanged": {
+			reason: "The configs cached for a Provider whose spec changed should be discarded.",
+			event: func(h *ConfigCacheInvalidator) {
+				old, updated := cached("uid", 1), cached("uid", 2)
+				h.Update(ctrlevent.UpdateEvent{MetaOld: old, ObjectOld: old, MetaNew: updated, ObjectNew: updated}, nil)
+			},
+			invalidated: true,
+		},
+		"StatusChanged": {
+			reason: "The configs cached for a Provider whose spec did not change should be kept.",
+			event: func(h *ConfigCacheInvalidator) {
+				old, updated := cached("uid", 1), cached("uid", 1)
+				h.Update(ctrlevent.UpdateEvent{MetaOld: old, ObjectOld: old, MetaNew: updated, ObjectNew: updated}, nil)
+			},
+		},
+		"OtherProviderDeleted": {
+			reason: "The configs cached for other Providers should be kept.",
+			event: func(h *ConfigCacheInvalidator) {
+				p := cached("other-uid", 1)
+				h.Delete(ctrlevent.DeleteEvent{Meta: p, Object: p}, nil)
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			auth := func(_ context.Context, _ []byte, _, region string) (*aws.Config, error) {
+				calls++
+				return &aws.Config{Region: region, Credentials: aws.NewStaticCredentialsProvider("id", "secret", "")}, nil
+			}
+
+			c := awsclients.NewConfigCache(awsclients.DefaultConfigExpiryWindow)
+			p := cached("uid", 1)
+			for i := 0; i < 2; i++ {
+				if _, err := c.AuthMethod(p, nil, auth)(context.Background(), nil, awsclients.DefaultSection, region); err != nil {
+					t.Fatalf("\n%s\nc.AuthMethod(...): %s", tc.reason, err)
+				}
+				if i == 0 {
+					tc.event(&ConfigCacheInvalidator{cache: c})
+				}
+			}
+
+			want := 1
+			if tc.invalidated {
+				want = 2
+			}
+			if diff := cmp.Diff(want, calls); diff != "" {
+				t.Errorf("\n%s\nc.AuthMethod(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsCredentialsSecretCandidate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		obj    runtime.Object
+		want   bool
+	}{
+		"Opaque": {
+			reason: "Opaque Secrets may be credentials Secrets.",
+			obj:    &corev1.Secret{Type: corev1.SecretTypeOpaque},
+			want:   true,
+		},
+		"Untyped": {
+			reason: "Secrets without a type may be credentials Secrets.",
+			obj:    &corev1.Secret{},
+			want:   true,
+		},
+		"ServiceAccountToken": {
+			reason: "Service account tokens can't be credentials Secrets.",
+			obj:    &corev1.Secret{Type: corev1.SecretTypeServiceAccountToken},
+			want:   false,
+		},
+		"NotASecret": {
+			reason: "Objects that aren't Secrets can't be credentials Secrets.",
+			obj:    &v1alpha3.Provider{},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsCredentialsSecretCandidate(tc.obj)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsCredentialsSecretCandidate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/crossplane/provider-aws/apis/storage/v1alpha3"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -46,12 +47,13 @@ var s3ACL = map[storagev1alpha1.PredefinedACL]s3.BucketCannedACL{
 // SetupBucketClaimScheduling adds a controller that reconciles Bucket claims
 // that include a class selector but omit their class and resource references by
 // picking a random matching S3BucketClass, if any.
-func SetupBucketClaimScheduling(mgr ctrl.Manager, l logging.Logger) error {
+func SetupBucketClaimScheduling(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimscheduling.ControllerName(storagev1alpha1.BucketGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&storagev1alpha1.Bucket{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&storagev1alpha1.Bucket{}, o.Selected()).
 		WithEventFilter(resource.NewPredicates(resource.AllOf(
 			resource.HasClassSelector(),
 			resource.HasNoClassReference(),
@@ -67,12 +69,13 @@ func SetupBucketClaimScheduling(mgr ctrl.Manager, l logging.Logger) error {
 
 // SetupBucketClaimDefaulting sets up the BucketClaimDefaultingController using the
 // supplied manager.
-func SetupBucketClaimDefaulting(mgr ctrl.Manager, l logging.Logger) error {
+func SetupBucketClaimDefaulting(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimdefaulting.ControllerName(storagev1alpha1.BucketGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&storagev1alpha1.Bucket{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&storagev1alpha1.Bucket{}, o.Selected()).
 		WithEventFilter(resource.NewPredicates(resource.AllOf(
 			resource.HasNoClassSelector(),
 			resource.HasNoClassReference(),
@@ -88,7 +91,7 @@ func SetupBucketClaimDefaulting(mgr ctrl.Manager, l logging.Logger) error {
 
 // SetupBucketClaimBinding adds a controller that reconciles Bucket claims with
 // S3Buckets, dynamically provisioning them if needed.
-func SetupBucketClaimBinding(mgr ctrl.Manager, l logging.Logger) error {
+func SetupBucketClaimBinding(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := claimbinding.ControllerName(storagev1alpha1.BucketGroupKind)
 
 	r := claimbinding.NewReconciler(mgr,
@@ -112,8 +115,9 @@ func SetupBucketClaimBinding(mgr ctrl.Manager, l logging.Logger) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		Watches(&source.Kind{Type: &v1alpha3.S3Bucket{}}, &resource.EnqueueRequestForClaim{}).
-		For(&storagev1alpha1.Bucket{}).
+		For(&storagev1alpha1.Bucket{}, o.Selected()).
 		WithEventFilter(p).
 		Complete(r)
 }
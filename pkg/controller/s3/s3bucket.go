@@ -30,7 +30,7 @@ import (
 	bucketv1alpha3 "github.com/crossplane/provider-aws/apis/storage/v1alpha3"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/s3"
-	"github.com/crossplane/provider-aws/pkg/controller/utils"
+	"github.com/crossplane/provider-aws/pkg/controller/config"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -42,6 +42,9 @@ import (
 const (
 	controllerName = "s3bucket.aws.crossplane.io"
 	finalizer      = "finalizer." + controllerName
+
+	errNotS3Bucket       = "managed resource is not an S3Bucket custom resource"
+	errObserveOnlyNoUser = "cannot observe an observe-only S3Bucket without an IAM username"
 )
 
 var (
@@ -61,12 +64,13 @@ type Reconciler struct {
 	create  func(*bucketv1alpha3.S3Bucket, s3.Service) (reconcile.Result, error)
 	sync    func(*bucketv1alpha3.S3Bucket, s3.Service) (reconcile.Result, error)
 	delete  func(*bucketv1alpha3.S3Bucket, s3.Service) (reconcile.Result, error)
+	observe func(*bucketv1alpha3.S3Bucket, s3.Service) (reconcile.Result, error)
 
 	log logging.Logger
 }
 
 // SetupS3Bucket adds a controller that reconciles S3Buckets.
-func SetupS3Bucket(mgr ctrl.Manager, l logging.Logger) error {
+func SetupS3Bucket(mgr ctrl.Manager, l logging.Logger, o config.Options) error {
 	name := managed.ControllerName(bucketv1alpha3.S3BucketClassGroupKind)
 
 	r := &Reconciler{
@@ -74,16 +78,21 @@ func SetupS3Bucket(mgr ctrl.Manager, l logging.Logger) error {
 		scheme:              mgr.GetScheme(),
 		ConnectionPublisher: managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()),
 		log:                 l.WithValues("controller", name),
-		initializer:         managed.NewNameAsExternalName(mgr.GetClient()),
+		initializer: managed.InitializerChain{
+			managed.NewNameAsExternalName(mgr.GetClient()),
+			aws.NewTagger(mgr.GetClient(), specTags),
+		},
 	}
 	r.connect = r._connect
 	r.create = r._create
 	r.delete = r._delete
 	r.sync = r._sync
+	r.observe = r._observe
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&bucketv1alpha3.S3Bucket{}).
+		WithOptions(o.ForControllerRuntime()).
+		For(&bucketv1alpha3.S3Bucket{}, o.Selected()).
 		Owns(&corev1.Secret{}).
 		Complete(r)
 }
@@ -95,7 +104,7 @@ func (r *Reconciler) fail(bucket *bucketv1alpha3.S3Bucket, err error) (reconcile
 }
 
 func (r *Reconciler) _connect(instance *bucketv1alpha3.S3Bucket) (s3.Service, error) {
-	config, err := utils.RetrieveAwsConfigFromProvider(ctx, r, instance.Spec.ProviderReference)
+	config, err := aws.GetConfig(ctx, r, instance.Spec.ProviderReference)
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +179,11 @@ func (r *Reconciler) _sync(bucket *bucketv1alpha3.S3Bucket, client s3.Service) (
 		return r.fail(bucket, err)
 	}
 
+	err = client.UpdateTagging(bucket)
+	if err != nil {
+		return r.fail(bucket, err)
+	}
+
 	// Eventually consistent, so we check if this version is newer than our stored version.
 	changed, err := bucket.HasPolicyChanged(bucketInfo.UserPolicyVersion)
 	if err != nil {
@@ -190,6 +204,35 @@ func (r *Reconciler) _sync(bucket *bucketv1alpha3.S3Bucket, client s3.Service) (
 	return result, r.Update(ctx, bucket)
 }
 
+// _observe observes a bucket without ever changing it. Drift between the
+// bucket and its spec is reported rather than corrected, and the bucket is
+// orphaned when the S3Bucket is deleted.
+func (r *Reconciler) _observe(bucket *bucketv1alpha3.S3Bucket, client s3.Service) (reconcile.Result, error) {
+	if bucket.DeletionTimestamp != nil {
+		meta.RemoveFinalizer(bucket, finalizer)
+		return result, r.Update(ctx, bucket)
+	}
+	if bucket.Spec.IAMUsername == "" {
+		return r.fail(bucket, errors.New(errObserveOnlyNoUser))
+	}
+	bucketInfo, err := client.GetBucketInfo(bucket.Spec.IAMUsername, bucket)
+	if err != nil {
+		return r.fail(bucket, err)
+	}
+	changed, err := bucket.HasPolicyChanged(bucketInfo.UserPolicyVersion)
+	if err != nil {
+		return r.fail(bucket, err)
+	}
+
+	drift := aws.NotDrifted()
+	if changed || bucketInfo.Versioning != bucket.Spec.Versioning {
+		drift = aws.Drifted()
+	}
+	bucket.Status.SetConditions(drift, runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
+	resource.SetBindable(bucket)
+	return result, r.Update(ctx, bucket)
+}
+
 func (r *Reconciler) _delete(bucket *bucketv1alpha3.S3Bucket, client s3.Service) (reconcile.Result, error) {
 	bucket.Status.SetConditions(runtimev1alpha1.Deleting(), runtimev1alpha1.ReconcileSuccess())
 	if bucket.Spec.ReclaimPolicy == runtimev1alpha1.ReclaimDelete {
@@ -218,6 +261,11 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		}
 		return result, err
 	}
+	if aws.IsPaused(bucket) {
+		bucket.Status.SetConditions(aws.Paused())
+		return result, r.Update(ctx, bucket)
+	}
+	aws.Resume(bucket)
 	if err := r.initializer.Initialize(ctx, bucket); err != nil {
 		return result, err
 	}
@@ -227,6 +275,12 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return r.fail(bucket, err)
 	}
 
+	// Buckets in dry-run mode are observed without being changed, since the
+	// S3 client does not support planning its requests.
+	if aws.IsObserveOnly(bucket) || aws.IsDryRun(bucket) {
+		return r.observe(bucket, s3Client)
+	}
+
 	// Check for deletion
 	if bucket.DeletionTimestamp != nil {
 		return r.delete(bucket, s3Client)
@@ -240,3 +294,11 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	// Update the bucket if it's no longer there.
 	return r.sync(bucket, s3Client)
 }
+
+func specTags(mg resource.Managed) (interface{}, error) {
+	bucket, ok := mg.(*bucketv1alpha3.S3Bucket)
+	if !ok {
+		return nil, errors.New(errNotS3Bucket)
+	}
+	return &bucket.Spec.Tags, nil
+}
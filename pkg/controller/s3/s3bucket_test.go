@@ -155,6 +155,20 @@ func TestSyncBucketError(t *testing.T) {
 		return nil
 	}
 
+	// update bucket tagging error
+	testError = errors.New("bucket-tagging-update-error")
+	cl.MockUpdateTagging = func(bucket *S3Bucket) error {
+		return testError
+	}
+
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(testError))
+	assert(testResource(), cl, resultRequeue, expectedStatus)
+
+	cl.MockUpdateTagging = func(bucket *S3Bucket) error {
+		return nil
+	}
+
 	// Update policy error
 	perm := storagev1alpha1.WriteOnlyPermission
 	bucketWithPolicyChanges := testResource()
@@ -183,12 +197,17 @@ func TestSyncBucket(t *testing.T) {
 	}
 	//
 	updateBucketACLCalled := false
+	updateTaggingCalled := false
 	getBucketInfoCalled := false
 	cl := &MockS3Client{
 		MockUpdateBucketACL: func(bucket *S3Bucket) error {
 			updateBucketACLCalled = true
 			return nil
 		},
+		MockUpdateTagging: func(bucket *S3Bucket) error {
+			updateTaggingCalled = true
+			return nil
+		},
 		MockGetBucketInfo: func(username string, bucket *S3Bucket) (*client.Bucket, error) {
 			getBucketInfoCalled = true
 			return &client.Bucket{Versioning: false, UserPolicyVersion: "v1"}, nil
@@ -201,6 +220,7 @@ func TestSyncBucket(t *testing.T) {
 	g.Expect(rs).To(Equal(result))
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(updateBucketACLCalled).To(BeTrue())
+	g.Expect(updateTaggingCalled).To(BeTrue())
 	g.Expect(getBucketInfoCalled).To(BeTrue())
 	assertResource(g, r, expectedStatus)
 }
@@ -483,4 +503,68 @@ func TestReconcile(t *testing.T) {
 	}
 	r.Reconcile(request)
 	g.Expect(called).To(BeTrue())
+
+	// test observe-only
+	r.connect = func(instance *S3Bucket) (client client.Service, e error) {
+		instance.SetAnnotations(map[string]string{aws.AnnotationKeyManagementPolicy: aws.ManagementPolicyObserveOnly})
+		return nil, nil
+	}
+	called = false
+	r.sync = func(instance *S3Bucket, client client.Service) (i reconcile.Result, e error) {
+		t.Errorf("r.Reconcile(...): observe-only buckets should not be synced")
+		return result, nil
+	}
+	r.observe = func(instance *S3Bucket, client client.Service) (i reconcile.Result, e error) {
+		called = true
+		return result, nil
+	}
+	r.Reconcile(request)
+	g.Expect(called).To(BeTrue())
+
+	// test paused
+	paused := testResource()
+	g.Expect(kube.Get(ctx, request.NamespacedName, paused)).To(Succeed())
+	paused.SetAnnotations(map[string]string{aws.AnnotationKeyPaused: "true"})
+	g.Expect(kube.Update(ctx, paused)).To(Succeed())
+	r.connect = func(instance *S3Bucket) (client client.Service, e error) {
+		t.Errorf("r.Reconcile(...): paused buckets should not be connected to")
+		return nil, nil
+	}
+
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(testError), aws.Paused())
+
+	rs, err = r.Reconcile(request)
+	g.Expect(rs).To(Equal(result))
+	g.Expect(err).NotTo(HaveOccurred())
+	assertResource(g, r, expectedStatus)
+}
+
+func TestObserve(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tr := testResource()
+	tr.Spec.Versioning = true
+	r := &Reconciler{
+		Client: NewFakeClient(tr),
+		log:    logging.NewNopLogger(),
+	}
+
+	cl := &MockS3Client{
+		MockGetBucketInfo: func(username string, bucket *S3Bucket) (*client.Bucket, error) {
+			return &client.Bucket{Versioning: false}, nil
+		},
+		MockUpdateVersioning: func(bucket *S3Bucket) error {
+			t.Errorf("r._observe(...): observe-only buckets should not be updated")
+			return nil
+		},
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(aws.Drifted(), runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
+
+	rs, err := r._observe(tr, cl)
+	g.Expect(rs).To(Equal(result))
+	g.Expect(err).NotTo(HaveOccurred())
+	assertResource(g, r, expectedStatus)
 }